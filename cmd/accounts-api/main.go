@@ -11,9 +11,12 @@ import (
 	_ "github.com/DIMO-Network/accounts-api/docs"
 	"github.com/DIMO-Network/accounts-api/internal/config"
 	"github.com/DIMO-Network/accounts-api/internal/controller"
+	"github.com/DIMO-Network/accounts-api/internal/outbox"
 	"github.com/DIMO-Network/accounts-api/internal/rpc"
 	"github.com/DIMO-Network/accounts-api/internal/services"
 	"github.com/DIMO-Network/accounts-api/internal/services/cio"
+	"github.com/DIMO-Network/accounts-api/internal/services/devices"
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
 	pb "github.com/DIMO-Network/accounts-api/pkg/grpc"
 	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
@@ -27,6 +30,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 // @title DIMO Accounts API
@@ -72,6 +76,14 @@ func main() {
 			logger.Fatal().Err(err).Msg("Failed to migrate datbase.")
 		}
 
+		return
+	case "purge": // delete accounts whose deletion grace period is over and complete
+		n, err := controller.PurgeDeletedAccounts(ctx, dbs, &logger)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to purge deleted accounts.")
+		}
+		logger.Info().Msgf("Purged %d accounts.", n)
+
 		return
 	}
 
@@ -97,17 +109,8 @@ func main() {
 
 	app.Get("/v1/swagger/*", swagger.HandlerDefault)
 
-	v1 := app.Group("/v1/account", jwtware.New(
-		jwtware.Config{
-			JWKSetURLs: []string{settings.JWTKeySetURL},
-			Claims:     &controller.AccountClaims{},
-			ErrorHandler: func(c *fiber.Ctx, err error) error {
-				return fiber.NewError(fiber.StatusUnauthorized, "Missing or malformed JWT.")
-			},
-		},
-	))
-
 	emailSvc := services.NewEmailService(&settings)
+	identitySvc := services.NewIdentityService(&settings)
 
 	var cioSvc controller.CIOClient
 
@@ -121,11 +124,61 @@ func main() {
 		}
 	}
 
-	accountController, err := controller.NewAccountController(ctx, dbs, emailSvc, cioSvc, &settings, &logger)
+	var eventProducer controller.EventProducer
+
+	if settings.KafkaBrokers == "" {
+		logger.Warn().Msg("No Kafka brokers configured, account events will not be published.")
+		eventProducer = &noOpEvents{}
+	} else {
+		producer, err := events.NewProducer(&settings)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to create Kafka producer.")
+		}
+		defer producer.Close()
+		eventProducer = producer
+	}
+
+	var devicesClient controller.DevicesClient
+
+	if settings.DevicesAPIGRPCAddr == "" {
+		logger.Warn().Msg("No devices-api address configured, account deletion will be refused.")
+	} else {
+		devicesConn, err := grpc.NewClient(settings.DevicesAPIGRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			logger.Fatal().Err(err).Msgf("Failed to create devices-api client for %s.", settings.DevicesAPIGRPCAddr)
+		}
+		defer devicesConn.Close()
+		devicesClient = devices.NewClient(devicesConn)
+	}
+
+	accountController, err := controller.NewAccountController(ctx, dbs, emailSvc, identitySvc, cioSvc, eventProducer, devicesClient, &settings, &logger)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to start account controller.")
 	}
 
+	dispatcher, err := outbox.NewDispatcher(dbs, accountController.OutboxHandlers(), &settings, &logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to create outbox dispatcher.")
+	}
+
+	go dispatcher.Run(ctx)
+
+	//confirm an email from the link that was sent to it; the token in the link stands in for a login,
+	//so these must be registered before the JWT-protected group. opening the link only shows a page
+	//that posts the token back
+	app.Get("/v1/account/email/verify", accountController.ShowEmailLink)
+	app.Post("/v1/account/email/verify", accountController.VerifyEmailLink)
+
+	v1 := app.Group("/v1/account", jwtware.New(
+		jwtware.Config{
+			JWKSetURLs: []string{settings.JWTKeySetURL},
+			Claims:     &controller.AccountClaims{},
+			ErrorHandler: func(c *fiber.Ctx, err error) error {
+				return fiber.NewError(fiber.StatusUnauthorized, "Missing or malformed JWT.")
+			},
+		},
+	))
+
 	//create account based on 0x or email
 	v1.Post("/", accountController.CreateAccount)
 
@@ -136,24 +189,80 @@ func main() {
 	//update account other data(region,etc)
 	v1.Put("/", accountController.UpdateUser)
 
-	//delete account and all associated links, cascade
+	//update the same fields with a JSON merge patch; null clears a field
+	v1.Patch("/", accountController.PatchAccount)
+
+	//schedule the account for deletion; it's locked during the grace period, then purged with all associated links
 	v1.Delete("/", accountController.DeleteUser)
 
+	//cancel a scheduled deletion during the grace period
+	v1.Post("/restore", accountController.RestoreAccount)
+
+	//download everything stored about the account, for subject-access requests
+	v1.Get("/export", accountController.ExportAccount)
+
 	//agree to terms of service, can only be called after both email and wallet are linked
 	v1.Post("/accept-tos", accountController.AcceptTOS)
 
+	//agree to the current versions of the terms of service, privacy policy, or data sharing policy
+	v1.Post("/legal/accept", accountController.AcceptDocuments)
+
+	//read and change which kinds of email and notifications the user agrees to receive
+	v1.Get("/preferences", accountController.GetPreferences)
+	v1.Put("/preferences", accountController.UpdatePreferences)
+
 	//agree to terms of service, can only be called after both email and wallet are linked
 	v1.Post("/referral/submit", accountController.SubmitReferralCode)
 
+	//replace the account's referral code, optionally disabling the old one right away
+	v1.Post("/referral/regenerate", accountController.RegenerateReferralCode)
+
+	//list the accounts that used this account's referral code
+	v1.Get("/referrals", accountController.GetReferrals)
+
 	//link a wallet to the account, required a signed JWT from auth server
 	v1.Post("/link/wallet/token", accountController.LinkWalletToken)
 
+	//issue a nonce for a SIWE message, then link a wallet with the signed message, no auth server needed
+	v1.Get("/link/wallet/siwe/nonce", accountController.GetSIWENonce)
+	v1.Post("/link/wallet/siwe", accountController.LinkWalletSIWE)
+
+	//remove the wallet from an account that has a confirmed email to log in with
+	v1.Delete("/link/wallet", accountController.UnlinkWallet)
+
+	//swap the wallet for a new one, requires signed JWTs for both from auth server
+	v1.Post("/link/wallet/replace", accountController.ReplaceWallet)
+
+	//manage the account's wallets, one of which is primary
+	v1.Get("/wallets", accountController.ListWallets)
+	v1.Post("/wallets", accountController.LinkWalletToken)
+	v1.Delete("/wallets/:address", accountController.RemoveWallet)
+	v1.Put("/wallets/:address/primary", accountController.SetPrimaryWallet)
+
 	//link a google account to the account, required a signed JWT from auth server
 	v1.Post("/link/email/token", accountController.LinkEmailToken)
 
 	//link some other email to the account, no JWT can be provider, so code is sent.
 	v1.Post("/link/email", accountController.LinkEmail)
 
+	//confirm the linked email using the code that was sent to it
+	v1.Post("/link/email/confirm", accountController.ConfirmEmail)
+
+	//send a fresh confirmation code to the linked email, subject to a cooldown and daily limit
+	v1.Post("/link/email/resend", accountController.ResendConfirmationEmail)
+
+	//start changing a confirmed email to a new address, a code is sent to the new address
+	v1.Post("/link/email/change", accountController.ChangeEmail)
+
+	//complete the email change using the code that was sent to the new address
+	v1.Post("/link/email/change/confirm", accountController.ConfirmEmailChange)
+
+	//change the email to one in a signed JWT from auth server
+	v1.Post("/link/email/change/token", accountController.ChangeEmailToken)
+
+	//remove the email from an account that also has a wallet to log in with
+	v1.Delete("/link/email", accountController.UnlinkEmail)
+
 	logger.Info().Msg("Server started on port " + settings.Port)
 
 	serv := grpc.NewServer()
@@ -171,6 +280,30 @@ func main() {
 		}
 	}()
 
+	// Admin services get their own listener, which isn't exposed outside the pod,
+	// and every call must carry the admin token.
+	switch {
+	case settings.AdminGRPCPort == "":
+		logger.Warn().Msg("No admin gRPC port configured, referral campaigns can't be managed.")
+	case settings.AdminGRPCToken == "":
+		logger.Warn().Msg("No admin gRPC token configured, referral campaigns can't be managed.")
+	default:
+		adminServ := grpc.NewServer(grpc.UnaryInterceptor(rpc.AdminAuth(settings.AdminGRPCToken)))
+		pb.RegisterReferralCampaignsServer(adminServ, &rpc.CampaignServer{DBS: dbs})
+
+		adminLis, err := net.Listen("tcp", ":"+settings.AdminGRPCPort)
+		if err != nil {
+			logger.Fatal().Err(err).Msgf("Failed to listen for admin gRPC clients on port %s.", settings.AdminGRPCPort)
+		}
+
+		go func() {
+			err := adminServ.Serve(adminLis)
+			if err != nil {
+				logger.Fatal().Err(err).Send()
+			}
+		}()
+	}
+
 	// Start Server
 	if err := app.Listen(":" + settings.Port); err != nil {
 		logger.Fatal().Err(err).Send()
@@ -187,6 +320,24 @@ func (c *noOpCIO) SetWallet(ctx context.Context, wallet common.Address) error {
 	return nil
 }
 
+func (c *noOpCIO) ClearEmail(ctx context.Context, wallet common.Address) error {
+	return nil
+}
+
+func (c *noOpCIO) SetPreferences(ctx context.Context, wallet common.Address, prefs map[string]bool) error {
+	return nil
+}
+
+func (c *noOpCIO) SetEmailPreferences(ctx context.Context, email string, prefs map[string]bool) error {
+	return nil
+}
+
+type noOpEvents struct{}
+
+func (e *noOpEvents) Emit(ctx context.Context, event *events.Event) error {
+	return nil
+}
+
 func migrateDatabase(ctx context.Context, _ zerolog.Logger, settings *db.Settings, command, migrationsDir string) error {
 	db, err := sql.Open("postgres", settings.BuildConnectionString(true))
 	if err != nil {
@@ -25,7 +25,15 @@ const docTemplate = `{
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get attributes for the authenticated user.",
+                "summary": "Get attributes for the authenticated user. The ETag response header can be sent back in If-Match to make a later write conditional.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity tags from earlier responses; if one is still current, the response is 304 with no body",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -33,6 +41,9 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_controller.UserResponse"
                         }
                     },
+                    "304": {
+                        "description": "Returned if the account hasn't changed."
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -58,6 +69,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/internal_controller.UserUpdateRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag from an earlier response; the update fails unless it's still current",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -78,6 +95,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/internal_controller.ErrorRes"
                         }
+                    },
+                    "412": {
+                        "description": "Returned if the account has changed since the If-Match tag was issued.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
                     }
                 }
             },
@@ -107,7 +130,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "summary": "Delete the authenticated user. Fails if the user has any devices.",
+                "summary": "Schedule the authenticated user's account for deletion. Until the grace period ends, the account is locked and can be restored. Fails if the user has any devices.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity tag from an earlier response; the deletion fails unless it's still current",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -128,7 +159,89 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "Returned if the user still has devices.",
+                        "description": "Returned if the user still has devices, or if a wallet was linked or unlinked during the check.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "410": {
+                        "description": "Returned if the account is already scheduled for deletion.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "412": {
+                        "description": "Returned if the account has changed since the If-Match tag was issued.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "503": {
+                        "description": "Returned if devices-api can't be reached to check for devices.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Modify attributes for the authenticated user using a JSON merge patch (RFC 7396). Fields set to null are cleared; omitted fields are left alone.",
+                "parameters": [
+                    {
+                        "description": "Fields to set, or null to clear",
+                        "name": "userUpdateRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.UserUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag from an earlier response; the patch fails unless it's still current",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.AccountPatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ValidationErrorRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "412": {
+                        "description": "Returned if the account has changed since the If-Match tag was issued.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
                         "schema": {
                             "$ref": "#/definitions/internal_controller.ErrorRes"
                         }
@@ -155,26 +268,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/account/link/email": {
+        "/v1/account/email/verify": {
+            "get": {
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "email"
+                ],
+                "summary": "Show the page an email confirmation link opens. This does not confirm the email; the page posts the token back to confirm it, so that link scanners and prefetchers can't use up the token.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token from the confirmation link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page asking the user to confirm.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Page saying the link is invalid.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Returned if confirmation links are not enabled.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            },
             "post": {
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
                 "tags": [
                     "email"
                 ],
-                "summary": "Add an unconfirmed email to the account.",
+                "summary": "Confirm an email using the token from the link that was sent to it. No login is required; the token is single-use and expires with the code. Form posts from the confirmation page get an HTML page back, other requests get JSON.",
                 "parameters": [
                     {
-                        "description": "Specifies the email to be linked",
-                        "name": "confirmEmailRequest",
+                        "description": "Token from the confirmation link",
+                        "name": "verifyEmailLinkRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller.AddEmailRequest"
+                            "$ref": "#/definitions/internal_controller.VerifyEmailLinkRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -182,14 +340,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_controller.ErrorRes"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Returned if confirmation links are not enabled.",
                         "schema": {
                             "$ref": "#/definitions/internal_controller.ErrorRes"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "410": {
+                        "description": "Returned if the account is scheduled for deletion.",
                         "schema": {
                             "$ref": "#/definitions/internal_controller.ErrorRes"
                         }
@@ -197,20 +355,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/account/link/email/token": {
+        "/v1/account/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Download everything stored about the authenticated user. This works for accounts that are scheduled for deletion.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.AccountExport"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/legal/accept": {
             "post": {
-                "tags": [
-                    "email"
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "Link an email to existing wallet account; require a signed JWT from auth server",
+                "summary": "Agree to the current versions of some of our legal documents",
                 "parameters": [
                     {
-                        "description": "Includes the email token",
-                        "name": "linkEmailRequest",
+                        "description": "The documents, each of which must be the version in force.",
+                        "name": "acceptDocumentsRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller.TokenBody"
+                            "$ref": "#/definitions/internal_controller.AcceptDocumentsRequest"
                         }
                     }
                 ],
@@ -230,23 +415,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/account/link/wallet/token": {
+        "/v1/account/link/email": {
             "post": {
                 "tags": [
-                    "wallet"
+                    "email"
                 ],
-                "summary": "Link a wallet to an existing account.",
+                "summary": "Add an unconfirmed email to the account, replacing any other unconfirmed email. A confirmation code is sent to the address. If sending fails, the email stays linked, codeSent is false, and a new code can be requested right away.",
                 "parameters": [
                     {
-                        "description": "JWT with an ethereum_address claim.",
-                        "name": "linkWalletRequest",
+                        "description": "Specifies the email to be linked",
+                        "name": "confirmEmailRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller.TokenBody"
+                            "$ref": "#/definitions/internal_controller.AddEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.LinkEmailRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
                         }
                     }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "email"
                 ],
+                "summary": "Remove the email from the account. The account must have a wallet so that it can still be logged into.",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -259,24 +476,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/internal_controller.ErrorRes"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
                     }
                 }
             }
         },
-        "/v1/account/referral/submit": {
+        "/v1/account/link/email/change": {
             "post": {
                 "tags": [
-                    "referral"
+                    "email"
                 ],
-                "summary": "Takes the referral code, validates and stores it",
+                "summary": "Start changing the account's confirmed email to a new address. A confirmation code is sent to the new address; the old one stays linked until the change is confirmed. If sending fails, the change can be started again right away.",
                 "parameters": [
                     {
-                        "description": "ReferralCode is the 6-digit, alphanumeric referral code from another user.",
-                        "name": "submitReferralCodeRequest",
+                        "description": "Specifies the new email",
+                        "name": "changeEmailRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller.SubmitReferralCodeRequest"
+                            "$ref": "#/definitions/internal_controller.AddEmailRequest"
                         }
                     }
                 ],
@@ -293,6 +516,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_controller.ErrorRes"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "429": {
+                        "description": "Returned if a code was sent too recently or the daily limit has been reached. The Retry-After header gives the number of seconds to wait.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -301,51 +536,1290 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "internal_controller.AddEmailRequest": {
-            "type": "object",
-            "properties": {
-                "address": {
-                    "type": "string",
-                    "example": "kilgore@kilgore.trout"
-                }
-            }
-        },
-        "internal_controller.ErrorRes": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 400
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Malformed request body."
-                }
-            }
-        },
-        "internal_controller.StandardRes": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Operation succeeded."
-                }
-            }
-        },
-        "internal_controller.SubmitReferralCodeRequest": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "ANBJN5"
-                }
-            }
         },
-        "internal_controller.TokenBody": {
-            "type": "object",
+        "/v1/account/link/email/change/confirm": {
+            "post": {
+                "tags": [
+                    "email"
+                ],
+                "summary": "Complete an email change using the code that was sent to the new address. The old address is notified. Afterwards, the account is found by the new address.",
+                "parameters": [
+                    {
+                        "description": "Specifies the confirmation code",
+                        "name": "confirmEmailRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.CompleteEmailValidation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Returned if the code is incorrect or expired. The message includes the number of remaining attempts.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "429": {
+                        "description": "Returned if there have been too many failed attempts.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/link/email/change/token": {
+            "post": {
+                "tags": [
+                    "email"
+                ],
+                "summary": "Change the account's email to the one in a signed JWT from the auth server. The old address is notified. Afterwards, the account is found by the new address.",
+                "parameters": [
+                    {
+                        "description": "Includes the email token",
+                        "name": "changeEmailRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.TokenBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/link/email/confirm": {
+            "post": {
+                "tags": [
+                    "email"
+                ],
+                "summary": "Confirm the account's linked email using the code that was sent to it.",
+                "parameters": [
+                    {
+                        "description": "Specifies the confirmation code",
+                        "name": "confirmEmailRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.CompleteEmailValidation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Returned if the code is incorrect or expired. The message includes the number of remaining attempts.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "429": {
+                        "description": "Returned if there have been too many failed attempts.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/link/email/resend": {
+            "post": {
+                "tags": [
+                    "email"
+                ],
+                "summary": "Send a new confirmation code to the account's unconfirmed email. Any previous code stops working. If sending fails, a new code can be requested right away.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "429": {
+                        "description": "Returned if a code was sent too recently or the daily limit has been reached. The Retry-After header gives the number of seconds to wait.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/link/email/token": {
+            "post": {
+                "tags": [
+                    "email"
+                ],
+                "summary": "Link an email to existing wallet account; require a signed JWT from auth server",
+                "parameters": [
+                    {
+                        "description": "Includes the email token",
+                        "name": "linkEmailRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.TokenBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/link/wallet": {
+            "delete": {
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Remove the primary wallet from the account. The oldest remaining wallet becomes primary. The account must keep a wallet or a confirmed email to log in with.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/link/wallet/replace": {
+            "post": {
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Replace one of the account's wallets with a new one. Both wallets must be proven with JWTs from the auth server. The new wallet takes over the old one's primary status. Referrals stay with the account.",
+                "parameters": [
+                    {
+                        "description": "JWTs for the current and new wallets.",
+                        "name": "replaceWalletRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ReplaceWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/link/wallet/siwe": {
+            "post": {
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Link a wallet to the account using a Sign-In with Ethereum (EIP-4361) message signed by the wallet. The message must use a nonce from the nonce endpoint. The first wallet on an account becomes its primary wallet.",
+                "parameters": [
+                    {
+                        "description": "Signed SIWE message.",
+                        "name": "linkWalletRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.LinkWalletSIWERequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/link/wallet/siwe/nonce": {
+            "get": {
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Issue a single-use nonce for a Sign-In with Ethereum message that will link a wallet to the account.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.SIWENonceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/link/wallet/token": {
+            "post": {
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Link a wallet to an existing account. The first wallet on an account becomes its primary wallet.",
+                "parameters": [
+                    {
+                        "description": "JWT with an ethereum_address claim.",
+                        "name": "linkWalletRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.TokenBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get the authenticated user's communication preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.CommunicationPreferences"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Change some of the authenticated user's communication preferences",
+                "parameters": [
+                    {
+                        "description": "New values for the preferences to change",
+                        "name": "communicationPreferencesUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.CommunicationPreferencesUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.CommunicationPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/referral/regenerate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "referral"
+                ],
+                "summary": "Replace the authenticated user's referral code with a new one. The old code keeps working for a grace period unless disableImmediately is set. Past referrals stay with the account.",
+                "parameters": [
+                    {
+                        "description": "Whether to stop accepting the old code right away",
+                        "name": "regenerateReferralCodeRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.RegenerateReferralCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.RegenerateReferralCodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "429": {
+                        "description": "Returned if the code was replaced within the cooldown. Retry-After says when to try again.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/referral/submit": {
+            "post": {
+                "tags": [
+                    "referral"
+                ],
+                "summary": "Takes the referral code, validates and stores it",
+                "parameters": [
+                    {
+                        "description": "Code is either the 6-digit, alphanumeric referral code from another user, or a campaign code.",
+                        "name": "submitReferralCodeRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.SubmitReferralCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "409": {
+                        "description": "Returned if the account's wallets changed while the code was being checked.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/referrals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "referral"
+                ],
+                "summary": "List the accounts that used the authenticated user's referral code, most recent first",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The nextCursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ReferralsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/restore": {
+            "post": {
+                "summary": "Cancel the pending deletion of the authenticated user's account.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Returned if the account is not scheduled for deletion.",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/wallets": {
+            "get": {
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "List the account's wallets, oldest first.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.WalletsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Link a wallet to an existing account. The first wallet on an account becomes its primary wallet.",
+                "parameters": [
+                    {
+                        "description": "JWT with an ethereum_address claim.",
+                        "name": "linkWalletRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.TokenBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/wallets/{address}": {
+            "delete": {
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Remove a wallet from the account. If it was the primary wallet then the oldest remaining wallet becomes primary. The account must keep a wallet or a confirmed email to log in with.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/account/wallets/{address}/primary": {
+            "put": {
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Make one of the account's wallets its primary wallet.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.StandardRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.ErrorRes"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "internal_controller.AcceptDocumentsRequest": {
+            "type": "object",
+            "properties": {
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller.AcceptDocumentsRequestDocument"
+                    }
+                }
+            }
+        },
+        "internal_controller.AcceptDocumentsRequestDocument": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "description": "Kind is one of \"tos\", \"privacy\", or \"data_sharing\".",
+                    "type": "string",
+                    "example": "privacy"
+                },
+                "version": {
+                    "description": "Version must be the version currently in force.",
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "internal_controller.AccountExport": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/internal_controller.AccountExportAccount"
+                },
+                "communicationPreferenceChanges": {
+                    "description": "CommunicationPreferenceChanges lists every change to the communication\npreferences, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller.AccountExportPreferenceChange"
+                    }
+                },
+                "communicationPreferences": {
+                    "$ref": "#/definitions/internal_controller.CommunicationPreferences"
+                },
+                "email": {
+                    "description": "Email is the account's email, if it has one.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controller.AccountExportEmail"
+                        }
+                    ]
+                },
+                "exportedAt": {
+                    "description": "ExportedAt is when the export was produced.",
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                },
+                "legalAcceptances": {
+                    "description": "LegalAcceptances lists every version of our legal documents that the user\nagreed to, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller.AccountExportLegalAcceptance"
+                    }
+                },
+                "pendingEmailChange": {
+                    "description": "PendingEmailChange is an email change that was started but not yet confirmed.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controller.AccountExportPendingEmailChange"
+                        }
+                    ]
+                },
+                "referral": {
+                    "$ref": "#/definitions/internal_controller.AccountExportReferral"
+                },
+                "wallets": {
+                    "description": "Wallets lists all of the account's blockchain accounts, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller.UserResponseWallet"
+                    }
+                }
+            }
+        },
+        "internal_controller.AccountExportAccount": {
+            "type": "object",
+            "properties": {
+                "countryCode": {
+                    "description": "CountryCode, if present, is a valid ISO 3166-1 alpha-3 country code.",
+                    "type": "string",
+                    "example": "USA"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt is when the user asked for the account to be deleted, if they have.",
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                },
+                "displayName": {
+                    "type": "string",
+                    "example": "Kilgore Trout"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2mD8CtraxOCAAwIeydt2Q4oCiAQ"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en-US"
+                },
+                "purgeAt": {
+                    "description": "PurgeAt is when the account will be deleted for good, unless it is restored.",
+                    "type": "string",
+                    "example": "2021-12-31T09:00:00Z"
+                },
+                "timeZone": {
+                    "type": "string",
+                    "example": "America/New_York"
+                },
+                "units": {
+                    "type": "string",
+                    "example": "imperial"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                }
+            }
+        },
+        "internal_controller.AccountExportEmail": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "koblitz@dimo.zone"
+                },
+                "codeExpiresAt": {
+                    "description": "CodeExpiresAt is when the most recent confirmation code stops working.",
+                    "type": "string",
+                    "example": "2021-12-01T09:05:00Z"
+                },
+                "codeSentAt": {
+                    "description": "CodeSentAt is when the most recent confirmation code was sent.",
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                },
+                "confirmedAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:41Z"
+                }
+            }
+        },
+        "internal_controller.AccountExportLegalAcceptance": {
+            "type": "object",
+            "properties": {
+                "acceptedAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:41Z"
+                },
+                "kind": {
+                    "description": "Kind is one of \"tos\", \"privacy\", or \"data_sharing\".",
+                    "type": "string",
+                    "example": "tos"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "internal_controller.AccountExportPendingEmailChange": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "kilgore@kilgore.trout"
+                },
+                "codeExpiresAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:05:00Z"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                }
+            }
+        },
+        "internal_controller.AccountExportPreferenceChange": {
+            "type": "object",
+            "properties": {
+                "changedAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                },
+                "channel": {
+                    "description": "Channel is one of \"marketing_email\", \"product_updates\", or \"push_notifications\".",
+                    "type": "string",
+                    "example": "marketing_email"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "source": {
+                    "description": "Source is what made the change. \"user\" is the user through the API.",
+                    "type": "string",
+                    "example": "user"
+                }
+            }
+        },
+        "internal_controller.AccountExportReferral": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "description": "Campaign is the campaign code the account entered, if it entered one instead\nof another user's code.",
+                    "type": "string",
+                    "example": "SPRING-ROADTRIP"
+                },
+                "code": {
+                    "description": "Code is the account's referral code.",
+                    "type": "string",
+                    "example": "ANBJN5"
+                },
+                "referred": {
+                    "description": "Referred lists the accounts that this one referred, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller.AccountExportReferred"
+                    }
+                },
+                "referredAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                },
+                "referredBy": {
+                    "description": "ReferredBy is the primary wallet of the account that referred this one. It's\nempty if the referrer has since deleted their account.",
+                    "type": "string",
+                    "example": "0x142e0C7A098622Ea98E5D67034251C4dFA746B5d"
+                },
+                "retiredCodes": {
+                    "description": "RetiredCodes lists the account's earlier referral codes, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller.AccountExportRetiredCode"
+                    }
+                }
+            }
+        },
+        "internal_controller.AccountExportReferred": {
+            "type": "object",
+            "properties": {
+                "referredAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                },
+                "wallet": {
+                    "description": "Wallet is the referred account's primary wallet.",
+                    "type": "string",
+                    "example": "0x142e0C7A098622Ea98E5D67034251C4dFA746B5d"
+                }
+            }
+        },
+        "internal_controller.AccountExportRetiredCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "ANBJN5"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is when the code stopped, or will stop, working.",
+                    "type": "string",
+                    "example": "2021-12-08T09:00:00Z"
+                },
+                "retiredAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                }
+            }
+        },
+        "internal_controller.AccountPatchResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/internal_controller.UserResponse"
+                },
+                "changed": {
+                    "description": "Changed lists the fields whose values actually changed.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "displayName",
+                        "units"
+                    ]
+                }
+            }
+        },
+        "internal_controller.AddEmailRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "kilgore@kilgore.trout"
+                }
+            }
+        },
+        "internal_controller.CommunicationPreference": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is when the user last changed this preference. It's absent if they\nnever have, in which case Enabled is false.",
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                }
+            }
+        },
+        "internal_controller.CommunicationPreferences": {
+            "type": "object",
+            "properties": {
+                "marketingEmail": {
+                    "description": "MarketingEmail covers promotional email.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controller.CommunicationPreference"
+                        }
+                    ]
+                },
+                "productUpdates": {
+                    "description": "ProductUpdates covers news about DIMO products and features.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controller.CommunicationPreference"
+                        }
+                    ]
+                },
+                "pushNotifications": {
+                    "description": "PushNotifications covers push notifications to the mobile app.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controller.CommunicationPreference"
+                        }
+                    ]
+                }
+            }
+        },
+        "internal_controller.CommunicationPreferencesUpdate": {
+            "type": "object",
+            "properties": {
+                "marketingEmail": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "productUpdates": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "pushNotifications": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_controller.CompleteEmailValidation": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the 6-digit number from the confirmation email",
+                    "type": "string",
+                    "example": "010990"
+                }
+            }
+        },
+        "internal_controller.ErrorRes": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Malformed request body."
+                }
+            }
+        },
+        "internal_controller.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "timeZone"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Unrecognized time zone \"Mars/Olympus_Mons\"."
+                }
+            }
+        },
+        "internal_controller.LinkEmailRes": {
+            "type": "object",
+            "properties": {
+                "codeSent": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Linked unconfirmed email kilgore@kilgore.trout to account. A confirmation code has been sent to it."
+                }
+            }
+        },
+        "internal_controller.LinkWalletSIWERequest": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Message is the exact text that was signed.",
+                    "type": "string",
+                    "example": "app.dimo.zone wants you to sign in with your Ethereum account:\n0x142e0C7A098622Ea98E5D67034251C4dFA746B5d\n\n\nURI: https://app.dimo.zone\nVersion: 1\nChain ID: 137\nNonce: 2mQ1KpE4CwXb2GQ7bGZmv0Xc8Jf\nIssued At: 2021-12-01T09:00:00Z"
+                },
+                "signature": {
+                    "description": "Signature is the hex-encoded personal_sign signature of the message.",
+                    "type": "string",
+                    "example": "0x6fd5a5..."
+                }
+            }
+        },
+        "internal_controller.Referee": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "Deleted is true if the referee's account is scheduled for deletion.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "referredAt": {
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                },
+                "wallet": {
+                    "description": "Wallet is the address of the referee's primary wallet.",
+                    "type": "string",
+                    "example": "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"
+                }
+            }
+        },
+        "internal_controller.ReferralCounts": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "Deleted is the number of referees whose accounts are scheduled for deletion.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "purged": {
+                    "description": "Purged is the number of referees whose accounts have been permanently\ndeleted. They're in the total but no longer listed.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "internal_controller.ReferralsResponse": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "$ref": "#/definitions/internal_controller.ReferralCounts"
+                },
+                "nextCursor": {
+                    "description": "NextCursor, if present, can be passed as the cursor parameter to fetch the\nnext page.",
+                    "type": "string",
+                    "example": "MjAyNC0wMS0wMVQwMDowMDowMFp8Mm1EOEN0cmF4T0NBQXdJZXlkdDJRNG9DaUFR"
+                },
+                "referees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller.Referee"
+                    }
+                }
+            }
+        },
+        "internal_controller.RegenerateReferralCodeRequest": {
+            "type": "object",
+            "properties": {
+                "disableImmediately": {
+                    "description": "DisableImmediately stops the old code from working right away, instead of\nafter the grace period.",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "internal_controller.RegenerateReferralCodeResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the user's new referral code.",
+                    "type": "string",
+                    "example": "7QX2KD"
+                },
+                "retiredCode": {
+                    "type": "string",
+                    "example": "ANBJN5"
+                },
+                "retiredCodeExpiresAt": {
+                    "description": "RetiredCodeExpiresAt is when the old code stops referring users to this\naccount.",
+                    "type": "string",
+                    "example": "2021-12-08T09:00:00Z"
+                }
+            }
+        },
+        "internal_controller.ReplaceWalletRequest": {
+            "type": "object",
+            "properties": {
+                "newToken": {
+                    "description": "NewToken is a JWT from the auth server with the new wallet in its ethereum_address claim.",
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6..."
+                },
+                "oldToken": {
+                    "description": "OldToken is a JWT from the auth server with the current wallet in its ethereum_address claim.",
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6..."
+                }
+            }
+        },
+        "internal_controller.SIWENonceResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "ExpiresAt is the time after which the nonce will no longer be accepted.",
+                    "type": "string",
+                    "example": "2021-12-01T09:10:00Z"
+                },
+                "nonce": {
+                    "type": "string",
+                    "example": "2mQ1KpE4CwXb2GQ7bGZmv0Xc8Jf"
+                }
+            }
+        },
+        "internal_controller.StandardRes": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Operation succeeded."
+                }
+            }
+        },
+        "internal_controller.SubmitReferralCodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "ANBJN5"
+                }
+            }
+        },
+        "internal_controller.TokenBody": {
+            "type": "object",
             "properties": {
                 "token": {
                     "type": "string",
@@ -361,6 +1835,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2021-12-01T09:00:41Z"
                 },
+                "communicationPreferences": {
+                    "description": "CommunicationPreferences describes how the user agrees to be contacted.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controller.CommunicationPreferences"
+                        }
+                    ]
+                },
                 "countryCode": {
                     "description": "CountryCode, if present, is a valid ISO 3166-1 alpha-3 country code.",
                     "type": "string",
@@ -371,6 +1853,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2021-12-01T09:00:00Z"
                 },
+                "displayName": {
+                    "description": "DisplayName is how the user would like to be addressed.",
+                    "type": "string",
+                    "example": "Kilgore Trout"
+                },
                 "email": {
                     "description": "Email describes the user's email and the state of its confirmation.",
                     "allOf": [
@@ -384,6 +1871,18 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2mD8CtraxOCAAwIeydt2Q4oCiAQ"
                 },
+                "language": {
+                    "description": "Language, if present, is a BCP 47 language tag.",
+                    "type": "string",
+                    "example": "en-US"
+                },
+                "outstandingDocuments": {
+                    "description": "OutstandingDocuments lists the legal documents in force that the user has yet\nto accept.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller.UserResponseDocument"
+                    }
+                },
                 "referral": {
                     "description": "Referral describes the account's referral code and information about who, if anyone,\nreferred the account. This is only available if the account has a linked wallet.",
                     "allOf": [
@@ -392,18 +1891,54 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "timeZone": {
+                    "description": "TimeZone, if present, is an IANA time zone name.",
+                    "type": "string",
+                    "example": "America/New_York"
+                },
+                "units": {
+                    "description": "Units, if present, is either \"metric\" or \"imperial\".",
+                    "type": "string",
+                    "example": "imperial"
+                },
                 "updatedAt": {
                     "description": "UpdatedAt reflects the time of the most recent account changes.",
                     "type": "string",
                     "example": "2021-12-01T09:00:00Z"
                 },
                 "wallet": {
-                    "description": "Wallet describes the user's blockchain account.",
+                    "description": "Wallet describes the user's primary blockchain account.",
                     "allOf": [
                         {
                             "$ref": "#/definitions/internal_controller.UserResponseWallet"
                         }
                     ]
+                },
+                "wallets": {
+                    "description": "Wallets lists all of the user's blockchain accounts, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller.UserResponseWallet"
+                    }
+                }
+            }
+        },
+        "internal_controller.UserResponseDocument": {
+            "type": "object",
+            "properties": {
+                "effectiveAt": {
+                    "description": "EffectiveAt is when this version came into force.",
+                    "type": "string",
+                    "example": "2021-12-01T00:00:00Z"
+                },
+                "kind": {
+                    "description": "Kind is one of \"tos\", \"privacy\", or \"data_sharing\".",
+                    "type": "string",
+                    "example": "tos"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1"
                 }
             }
         },
@@ -425,10 +1960,20 @@ const docTemplate = `{
         "internal_controller.UserResponseReferral": {
             "type": "object",
             "properties": {
+                "campaign": {
+                    "description": "Campaign is the campaign code the user entered, if they entered one instead of\nanother user's code.",
+                    "type": "string",
+                    "example": "SPRING-ROADTRIP"
+                },
                 "code": {
                     "description": "Code is the user's referral code.",
                     "type": "string"
                 },
+                "deadline": {
+                    "description": "Deadline is the last moment at which the user can enter someone else's referral\ncode. It's absent if the user has already been referred.",
+                    "type": "string",
+                    "example": "2021-12-31T09:00:00Z"
+                },
                 "referredAt": {
                     "description": "The timestamp at which the user was referred. May be empty if the user wasn't referred.",
                     "type": "string"
@@ -446,6 +1991,16 @@ const docTemplate = `{
                     "description": "Address is the Ethereum address associated with the user.",
                     "type": "string",
                     "example": "0x142e0C7A098622Ea98E5D67034251C4dFA746B5d"
+                },
+                "createdAt": {
+                    "description": "CreatedAt is when the wallet was linked to the account.",
+                    "type": "string",
+                    "example": "2021-12-01T09:00:00Z"
+                },
+                "primary": {
+                    "description": "Primary is true for the one wallet that is used for referrals and Customer.io.",
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
@@ -456,6 +2011,66 @@ const docTemplate = `{
                     "description": "CountryCode should be a valid ISO 3166-1 alpha-3 country code",
                     "type": "string",
                     "example": "USA"
+                },
+                "displayName": {
+                    "description": "DisplayName is how the user would like to be addressed, at most 64 characters.",
+                    "type": "string",
+                    "example": "Kilgore Trout"
+                },
+                "language": {
+                    "description": "Language is a BCP 47 language tag.",
+                    "type": "string",
+                    "example": "en-US"
+                },
+                "timeZone": {
+                    "description": "TimeZone is an IANA time zone name.",
+                    "type": "string",
+                    "example": "America/New_York"
+                },
+                "units": {
+                    "description": "Units is either \"metric\" or \"imperial\".",
+                    "type": "string",
+                    "example": "imperial"
+                }
+            }
+        },
+        "internal_controller.ValidationErrorRes": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "fields": {
+                    "description": "Fields lists the problems, ordered by field name.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Some fields are invalid."
+                }
+            }
+        },
+        "internal_controller.VerifyEmailLinkRequest": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "Token is the token query parameter of the confirmation link.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller.WalletsResponse": {
+            "type": "object",
+            "properties": {
+                "wallets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller.UserResponseWallet"
+                    }
                 }
             }
         }
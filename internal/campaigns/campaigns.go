@@ -0,0 +1,48 @@
+// Package campaigns holds the rules for referral campaign codes, which partners
+// and marketing hand out in place of a user's own referral code.
+package campaigns
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/models"
+)
+
+// CodePattern matches normalized campaign codes. It agrees with the check
+// constraint on referral_campaigns.code, and can't match a six-character account
+// referral code.
+var CodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9-]{5,30}[A-Z0-9]$`)
+
+// NormalizeCode puts a code in the form in which it's stored. Campaign codes are
+// case-insensitive.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Reasons a campaign isn't accepting submissions.
+var (
+	ErrNotStarted = errors.New("campaign has not started")
+	ErrEnded      = errors.New("campaign has ended")
+	ErrUsedUp     = errors.New("campaign has reached its use limit")
+)
+
+// Available returns ErrNotStarted, ErrEnded, or ErrUsedUp if the campaign isn't
+// accepting submissions at the given time.
+func Available(c *models.ReferralCampaign, now time.Time) error {
+	if c.StartsAt.Valid && now.Before(c.StartsAt.Time) {
+		return ErrNotStarted
+	}
+
+	if c.EndsAt.Valid && !now.Before(c.EndsAt.Time) {
+		return ErrEnded
+	}
+
+	if c.MaxUses.Valid && c.Uses >= c.MaxUses.Int {
+		return ErrUsedUp
+	}
+
+	return nil
+}
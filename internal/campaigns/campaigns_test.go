@@ -0,0 +1,32 @@
+package campaigns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestAvailable(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		campaign models.ReferralCampaign
+		err      error
+	}{
+		{"open", models.ReferralCampaign{}, nil},
+		{"not started", models.ReferralCampaign{StartsAt: null.TimeFrom(now.Add(time.Hour))}, ErrNotStarted},
+		{"ended", models.ReferralCampaign{EndsAt: null.TimeFrom(now)}, ErrEnded},
+		{"used up", models.ReferralCampaign{MaxUses: null.IntFrom(3), Uses: 3}, ErrUsedUp},
+		{"uses left", models.ReferralCampaign{MaxUses: null.IntFrom(3), Uses: 2}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.ErrorIs(t, Available(&c.campaign, now), c.err)
+		})
+	}
+}
@@ -9,6 +9,8 @@ type Settings struct {
 	Environment             string      `yaml:"ENVIRONMENT"`
 	Port                    string      `yaml:"PORT"`
 	GRPCPort                string      `yaml:"GRPC_PORT"`
+	AdminGRPCPort           string      `yaml:"ADMIN_GRPC_PORT"`
+	AdminGRPCToken          string      `yaml:"ADMIN_GRPC_TOKEN"`
 	LogLevel                string      `yaml:"LOG_LEVEL"`
 	DB                      db.Settings `yaml:"DB"`
 	ServiceName             string      `yaml:"SERVICE_NAME"`
@@ -20,10 +22,24 @@ type Settings struct {
 	JWTKeySetURL            string      `yaml:"JWT_KEY_SET_URL"`
 	KafkaBrokers            string      `yaml:"KAFKA_BROKERS"`
 	EventsTopic             string      `yaml:"EVENTS_TOPIC"`
+	OutboxMaxAttempts       int         `yaml:"OUTBOX_MAX_ATTEMPTS"`
+	OutboxPollInterval      string      `yaml:"OUTBOX_POLL_INTERVAL"`
 	MonitoringPort          string      `yaml:"MON_PORT"`
 	DevicesAPIGRPCAddr      string      `yaml:"DEVICES_API_GRPC_ADDR"`
+	DeletionGracePeriod     string      `yaml:"DELETION_GRACE_PERIOD"`
 	IdentityAPIURL          string      `yaml:"IDENTITY_API_URL"`
+	ReferralMaxDepth        int         `yaml:"REFERRAL_MAX_DEPTH"`
+	ReferralWindow          string      `yaml:"REFERRAL_WINDOW"`
+	RetiredCodeGracePeriod  string      `yaml:"RETIRED_CODE_GRACE_PERIOD"`
+	ReferralCodeCooldown    string      `yaml:"REFERRAL_CODE_COOLDOWN"`
 	EmailCodeDuration       string      `yaml:"EMAIL_CODE_DURATION"`
+	EmailCodeMaxAttempts    int         `yaml:"EMAIL_CODE_MAX_ATTEMPTS"`
+	EmailResendCooldown     string      `yaml:"EMAIL_RESEND_COOLDOWN"`
+	EmailDailySendLimit     int         `yaml:"EMAIL_DAILY_SEND_LIMIT"`
+	EmailLinkSigningKey     string      `yaml:"EMAIL_LINK_SIGNING_KEY"`
+	EmailLinkBaseURL        string      `yaml:"EMAIL_LINK_BASE_URL"`
+	SIWEDomains             string      `yaml:"SIWE_DOMAINS"`
+	DIMORegistryChainID     int64       `yaml:"DIMO_REGISTRY_CHAIN_ID"`
 	CustomerIOAPIKey        string      `yaml:"CUSTOMER_IO_API_KEY"`
 	DisableCustomerIOEvents bool        `yaml:"DISABLE_CUSTOMER_IO_EVENTS"`
 }
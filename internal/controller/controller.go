@@ -8,10 +8,13 @@ import (
 	"fmt"
 	"html/template"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/DIMO-Network/accounts-api/internal/config"
+	"github.com/DIMO-Network/accounts-api/internal/legal"
 	"github.com/DIMO-Network/accounts-api/internal/services"
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
 	"github.com/DIMO-Network/accounts-api/models"
 
 	"github.com/DIMO-Network/shared/db"
@@ -31,6 +34,12 @@ import (
 //go:embed resources/confirmation_email.html
 var rawConfirmationEmail string
 
+//go:embed resources/email_changed.html
+var rawEmailChangedEmail string
+
+//go:embed resources/verify_email_link.html
+var rawVerifyEmailLinkPage string
+
 // Sorted JSON array of valid ISO 3116-1 apha-3 codes
 //
 //go:embed resources/country_codes.json
@@ -39,17 +48,67 @@ var rawCountryCodes []byte
 type CIOClient interface {
 	SetEmail(ctx context.Context, wallet common.Address, email string) error
 	SetWallet(ctx context.Context, wallet common.Address) error
+	ClearEmail(ctx context.Context, wallet common.Address) error
+	SetPreferences(ctx context.Context, wallet common.Address, prefs map[string]bool) error
+	SetEmailPreferences(ctx context.Context, email string, prefs map[string]bool) error
+}
+
+// DevicesClient counts the devices that would be orphaned by deleting an account.
+// Without one, accounts can't be deleted.
+type DevicesClient interface {
+	CountUserDevices(ctx context.Context, userID string, wallets []common.Address) (int, error)
+}
+
+// EventProducer publishes account lifecycle events for other services.
+type EventProducer interface {
+	Emit(ctx context.Context, event *events.Event) error
 }
 
+const (
+	// defaultMaxCodeAttempts is used when EMAIL_CODE_MAX_ATTEMPTS is unset.
+	defaultMaxCodeAttempts = 5
+	// defaultResendCooldown is used when EMAIL_RESEND_COOLDOWN is unset.
+	defaultResendCooldown = time.Minute
+	// defaultDailySendLimit is used when EMAIL_DAILY_SEND_LIMIT is unset.
+	defaultDailySendLimit = 10
+	// defaultDeletionGracePeriod is used when DELETION_GRACE_PERIOD is unset.
+	defaultDeletionGracePeriod = 30 * 24 * time.Hour
+	// defaultReferralMaxDepth is used when REFERRAL_MAX_DEPTH is unset.
+	defaultReferralMaxDepth = 100
+	// defaultReferralWindow is used when REFERRAL_WINDOW is unset.
+	defaultReferralWindow = 30 * 24 * time.Hour
+	// defaultRetiredCodeGracePeriod is used when RETIRED_CODE_GRACE_PERIOD is unset.
+	defaultRetiredCodeGracePeriod = 7 * 24 * time.Hour
+	// defaultRegenerateCooldown is used when REFERRAL_CODE_COOLDOWN is unset.
+	defaultRegenerateCooldown = 24 * time.Hour
+)
+
 type Controller struct {
 	dbs             db.Store
 	log             *zerolog.Logger
 	allowedLateness time.Duration
+	maxCodeAttempts int
+	resendCooldown  time.Duration
+	dailySendLimit  int
+	deletionGrace   time.Duration
+	referralDepth   int
+	referralWindow  time.Duration
+	retiredGrace    time.Duration
+	regenCooldown   time.Duration
 	countryCodes    []string
 	emailService    services.EmailService
+	identityService services.IdentityService
 	cioService      CIOClient
+	eventProducer   EventProducer
+	devicesClient   DevicesClient
 	jwkResource     keyfunc.Keyfunc
 	emailTemplate   *template.Template
+	changedTemplate *template.Template
+	linkPage        *template.Template
+	linkSigningKey  []byte
+	linkBaseURL     string
+	siweDomains     []string
+	chainID         int64
 }
 
 type AccountClaims struct {
@@ -59,7 +118,7 @@ type AccountClaims struct {
 	jwt.RegisteredClaims
 }
 
-func NewAccountController(ctx context.Context, dbs db.Store, emlSvc services.EmailService, cioSvc CIOClient, settings *config.Settings, logger *zerolog.Logger) (*Controller, error) {
+func NewAccountController(ctx context.Context, dbs db.Store, emlSvc services.EmailService, identitySvc services.IdentityService, cioSvc CIOClient, eventProducer EventProducer, devicesClient DevicesClient, settings *config.Settings, logger *zerolog.Logger) (*Controller, error) {
 	var countryCodes []string
 	if err := json.Unmarshal(rawCountryCodes, &countryCodes); err != nil {
 		return nil, err
@@ -77,15 +136,110 @@ func NewAccountController(ctx context.Context, dbs db.Store, emlSvc services.Ema
 		return nil, fmt.Errorf("email confirmation code duration %s is non-positive", dur)
 	}
 
+	maxAttempts := settings.EmailCodeMaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = defaultMaxCodeAttempts
+	} else if maxAttempts < 0 {
+		return nil, fmt.Errorf("email confirmation code attempt limit %d is negative", maxAttempts)
+	}
+
+	resendCooldown := defaultResendCooldown
+	if settings.EmailResendCooldown != "" {
+		resendCooldown, err = time.ParseDuration(settings.EmailResendCooldown)
+		if err != nil {
+			return nil, err
+		} else if resendCooldown < 0 {
+			return nil, fmt.Errorf("email resend cooldown %s is negative", resendCooldown)
+		}
+	}
+
+	dailySendLimit := settings.EmailDailySendLimit
+	if dailySendLimit == 0 {
+		dailySendLimit = defaultDailySendLimit
+	} else if dailySendLimit < 0 {
+		return nil, fmt.Errorf("daily email send limit %d is negative", dailySendLimit)
+	}
+
+	deletionGrace := defaultDeletionGracePeriod
+	if settings.DeletionGracePeriod != "" {
+		deletionGrace, err = time.ParseDuration(settings.DeletionGracePeriod)
+		if err != nil {
+			return nil, err
+		} else if deletionGrace < 0 {
+			return nil, fmt.Errorf("deletion grace period %s is negative", deletionGrace)
+		}
+	}
+
+	referralDepth := settings.ReferralMaxDepth
+	if referralDepth == 0 {
+		referralDepth = defaultReferralMaxDepth
+	} else if referralDepth < 0 {
+		return nil, fmt.Errorf("referral chain depth limit %d is negative", referralDepth)
+	}
+
+	referralWindow := defaultReferralWindow
+	if settings.ReferralWindow != "" {
+		referralWindow, err = time.ParseDuration(settings.ReferralWindow)
+		if err != nil {
+			return nil, err
+		} else if referralWindow <= 0 {
+			return nil, fmt.Errorf("referral window %s is non-positive", referralWindow)
+		}
+	}
+
+	retiredGrace := defaultRetiredCodeGracePeriod
+	if settings.RetiredCodeGracePeriod != "" {
+		retiredGrace, err = time.ParseDuration(settings.RetiredCodeGracePeriod)
+		if err != nil {
+			return nil, err
+		} else if retiredGrace < 0 {
+			return nil, fmt.Errorf("retired referral code grace period %s is negative", retiredGrace)
+		}
+	}
+
+	regenCooldown := defaultRegenerateCooldown
+	if settings.ReferralCodeCooldown != "" {
+		regenCooldown, err = time.ParseDuration(settings.ReferralCodeCooldown)
+		if err != nil {
+			return nil, err
+		} else if regenCooldown < 0 {
+			return nil, fmt.Errorf("referral code regeneration cooldown %s is negative", regenCooldown)
+		}
+	}
+
+	var siweDomains []string
+	for _, d := range strings.Split(settings.SIWEDomains, ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			siweDomains = append(siweDomains, d)
+		}
+	}
+
 	return &Controller{
 		dbs:             dbs,
 		log:             logger,
 		allowedLateness: dur,
+		maxCodeAttempts: maxAttempts,
+		resendCooldown:  resendCooldown,
+		dailySendLimit:  dailySendLimit,
+		deletionGrace:   deletionGrace,
+		referralDepth:   referralDepth,
+		referralWindow:  referralWindow,
+		retiredGrace:    retiredGrace,
+		regenCooldown:   regenCooldown,
 		countryCodes:    countryCodes,
 		emailService:    emlSvc,
+		identityService: identitySvc,
 		cioService:      cioSvc,
+		eventProducer:   eventProducer,
+		devicesClient:   devicesClient,
 		jwkResource:     jwkResource,
 		emailTemplate:   template.Must(template.New("confirmation_email").Parse(rawConfirmationEmail)),
+		changedTemplate: template.Must(template.New("email_changed").Parse(rawEmailChangedEmail)),
+		linkPage:        template.Must(template.New("verify_email_link").Parse(rawVerifyEmailLinkPage)),
+		linkSigningKey:  []byte(settings.EmailLinkSigningKey),
+		linkBaseURL:     strings.TrimSuffix(settings.EmailLinkBaseURL, "/"),
+		siweDomains:     siweDomains,
+		chainID:         settings.DIMORegistryChainID,
 	}, nil
 }
 
@@ -117,14 +271,34 @@ func getUserAccountClaims(c *fiber.Ctx) (*AccountClaims, error) {
 	return infos, nil
 }
 
+// getUserAccount loads the account for the token, along with its email and
+// wallets. Accounts that are scheduled for deletion are locked until restored.
 func (d *Controller) getUserAccount(ctx context.Context, userAccount *AccountClaims, exec boil.ContextExecutor) (*models.Account, error) {
+	acct, err := d.lookupUserAccount(ctx, userAccount, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	if acct.DeletedAt.Valid {
+		return nil, fiber.NewError(fiber.StatusGone, fmt.Sprintf("Account is scheduled for deletion at %s. Restore it to keep using it.", acct.PurgeAt.Time.Format(time.RFC3339)))
+	}
+
+	return acct, nil
+}
+
+// lookupUserAccount is like getUserAccount, but also returns accounts that are
+// scheduled for deletion.
+func (d *Controller) lookupUserAccount(ctx context.Context, userAccount *AccountClaims, exec boil.ContextExecutor) (*models.Account, error) {
 	switch {
 	case userAccount.EmailAddress != nil:
 		normalEmail := normalizeEmail(*userAccount.EmailAddress)
 		email, err := models.Emails(
 			models.EmailWhere.Address.EQ(normalEmail),
-			qm.Load(qm.Rels(models.EmailRels.Account, models.AccountRels.Wallet)),
-			qm.Load(qm.Rels(models.EmailRels.Account, models.AccountRels.ReferredByAccount, models.AccountRels.Wallet)),
+			qm.Load(qm.Rels(models.EmailRels.Account, models.AccountRels.Wallets), qm.OrderBy(models.WalletColumns.CreatedAt)),
+			qm.Load(qm.Rels(models.EmailRels.Account, models.AccountRels.ReferredByAccount, models.AccountRels.Wallets)),
+			qm.Load(qm.Rels(models.EmailRels.Account, models.AccountRels.LegalAcceptances)),
+			qm.Load(qm.Rels(models.EmailRels.Account, models.AccountRels.CommunicationPreferences)),
+			qm.Load(qm.Rels(models.EmailRels.Account, models.AccountRels.ReferralCampaign)),
 		).One(ctx, exec)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -137,7 +311,11 @@ func (d *Controller) getUserAccount(ctx context.Context, userAccount *AccountCla
 		wallet, err := models.Wallets(
 			models.WalletWhere.Address.EQ(userAccount.EthereumAddress.Bytes()),
 			qm.Load(qm.Rels(models.WalletRels.Account, models.AccountRels.Email)),
-			qm.Load(qm.Rels(models.WalletRels.Account, models.AccountRels.ReferredByAccount, models.AccountRels.Wallet)),
+			qm.Load(qm.Rels(models.WalletRels.Account, models.AccountRels.Wallets), qm.OrderBy(models.WalletColumns.CreatedAt)),
+			qm.Load(qm.Rels(models.WalletRels.Account, models.AccountRels.ReferredByAccount, models.AccountRels.Wallets)),
+			qm.Load(qm.Rels(models.WalletRels.Account, models.AccountRels.LegalAcceptances)),
+			qm.Load(qm.Rels(models.WalletRels.Account, models.AccountRels.CommunicationPreferences)),
+			qm.Load(qm.Rels(models.WalletRels.Account, models.AccountRels.ReferralCampaign)),
 		).One(ctx, exec)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -151,6 +329,20 @@ func (d *Controller) getUserAccount(ctx context.Context, userAccount *AccountCla
 	}
 }
 
+// primaryWallet returns the account's primary wallet, or nil if it has none. The
+// account's wallets must have been loaded.
+func primaryWallet(acct *models.Account) *models.Wallet {
+	if acct.R == nil {
+		return nil
+	}
+	for _, w := range acct.R.Wallets {
+		if w.IsPrimary {
+			return w
+		}
+	}
+	return nil
+}
+
 func (d *Controller) createUser(ctx context.Context, userAccount *AccountClaims, tx *sql.Tx) error {
 	if userAccount.EthereumAddress != nil {
 		conflict, err := models.WalletExists(ctx, tx, userAccount.EthereumAddress.Bytes())
@@ -183,6 +375,10 @@ func (d *Controller) createUser(ctx context.Context, userAccount *AccountClaims,
 		ReferralCode: referralCode,
 	}
 
+	if userAccount.EthereumAddress != nil {
+		acct.FirstWalletLinkedAt = null.TimeFrom(time.Now())
+	}
+
 	if err := acct.Insert(ctx, tx, boil.Infer()); err != nil {
 		return err
 	}
@@ -191,15 +387,18 @@ func (d *Controller) createUser(ctx context.Context, userAccount *AccountClaims,
 		wallet := &models.Wallet{
 			AccountID: acct.ID,
 			Address:   userAccount.EthereumAddress.Bytes(),
+			IsPrimary: true,
 		}
 
 		if err := wallet.Insert(ctx, tx, boil.Infer()); err != nil {
 			return fmt.Errorf("failed to insert wallet: %w", err)
 		}
 
-		if err := d.cioService.SetWallet(ctx, *userAccount.EthereumAddress); err != nil {
-			d.log.Err(err).Msg("Error sending wallet information to Customer.io.")
+		if err := d.syncWallet(ctx, tx, *userAccount.EthereumAddress); err != nil {
+			return err
 		}
+
+		return d.emitEvent(ctx, tx, events.AccountCreatedType, acct.ID, events.AccountCreated{Wallet: userAccount.EthereumAddress})
 	} else if userAccount.EmailAddress != nil {
 		normalEmail := normalizeEmail(*userAccount.EmailAddress)
 
@@ -212,20 +411,47 @@ func (d *Controller) createUser(ctx context.Context, userAccount *AccountClaims,
 		if err := email.Insert(ctx, tx, boil.Infer()); err != nil {
 			return fmt.Errorf("failed to insert email: %w", err)
 		}
+
+		return d.emitEvent(ctx, tx, events.AccountCreatedType, acct.ID, events.AccountCreated{Email: &normalEmail})
 	}
 
 	return nil
 }
 
-func (d *Controller) formatUserAcctResponse(acct *models.Account, wallet *models.Wallet, email *models.Email) (*UserResponse, error) {
+// formatUserAcctResponse describes the account for the API. The account's
+// email, wallets, referrer, legal acceptances, and communication preferences must
+// have been loaded.
+func (d *Controller) formatUserAcctResponse(ctx context.Context, exec boil.ContextExecutor, acct *models.Account) (*UserResponse, error) {
+	current, err := legal.Current(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
 	userResp := &UserResponse{
 		ID:            acct.ID,
 		CreatedAt:     acct.CreatedAt,
 		AcceptedTOSAt: acct.AcceptedTosAt.Ptr(),
 		CountryCode:   acct.CountryCode.Ptr(),
+		DisplayName:   acct.DisplayName.Ptr(),
+		Language:      acct.Language.Ptr(),
+		TimeZone:      acct.TimeZone.Ptr(),
+		Units:         acct.Units.Ptr(),
 		UpdatedAt:     acct.UpdatedAt,
 	}
 
+	userResp.OutstandingDocuments = []UserResponseDocument{}
+	for _, doc := range legal.Outstanding(current, acct.R.LegalAcceptances) {
+		userResp.OutstandingDocuments = append(userResp.OutstandingDocuments, UserResponseDocument{
+			Kind:        doc.Kind,
+			Version:     doc.Version,
+			EffectiveAt: doc.EffectiveAt,
+		})
+	}
+
+	userResp.CommunicationPreferences = formatPreferences(acct.R.CommunicationPreferences)
+
+	email, wallet := acct.R.Email, primaryWallet(acct)
+
 	if email != nil {
 		userResp.Email = &UserResponseEmail{
 			Address:     email.Address,
@@ -233,23 +459,38 @@ func (d *Controller) formatUserAcctResponse(acct *models.Account, wallet *models
 		}
 	}
 
-	if wallet != nil {
-		userResp.Wallet = &UserResponseWallet{
-			Address: common.BytesToAddress(wallet.Address).Hex(),
-		}
+	userResp.Wallets = make([]UserResponseWallet, len(acct.R.Wallets))
+	for i, w := range acct.R.Wallets {
+		userResp.Wallets[i] = formatWallet(w)
+	}
 
-		var referredBy *string
-		if acct.R.ReferredByAccount != nil && acct.R.ReferredByAccount.R.Wallet != nil {
-			a := common.BytesToAddress(acct.R.ReferredByAccount.R.Wallet.Address).Hex()
-			referredBy = &a
-		}
+	if wallet != nil {
+		primary := formatWallet(wallet)
+		userResp.Wallet = &primary
 
 		userResp.Referral = &UserResponseReferral{
 			Code:       acct.ReferralCode,
 			ReferredAt: acct.ReferredAt.Ptr(),
-			ReferredBy: referredBy,
+			ReferredBy: primaryWalletHex(acct.R.ReferredByAccount),
+		}
+
+		if campaign := acct.R.ReferralCampaign; campaign != nil {
+			userResp.Referral.Campaign = &campaign.Code
+		}
+
+		if !acct.ReferredAt.Valid {
+			deadline := d.referralDeadline(acct)
+			userResp.Referral.Deadline = &deadline
 		}
 	}
 
 	return userResp, nil
 }
+
+func formatWallet(wallet *models.Wallet) UserResponseWallet {
+	return UserResponseWallet{
+		Address:   common.BytesToAddress(wallet.Address).Hex(),
+		Primary:   wallet.IsPrimary,
+		CreatedAt: wallet.CreatedAt,
+	}
+}
@@ -5,22 +5,40 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/DIMO-Network/accounts-api/internal/config"
-	"github.com/DIMO-Network/accounts-api/internal/services"
+	"github.com/DIMO-Network/accounts-api/internal/outbox"
+	"github.com/DIMO-Network/accounts-api/internal/preferences"
+	"github.com/DIMO-Network/accounts-api/internal/rpc"
 	"github.com/DIMO-Network/accounts-api/internal/services/cio"
+	"github.com/DIMO-Network/accounts-api/internal/services/devices"
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
 	"github.com/DIMO-Network/accounts-api/internal/test"
 	"github.com/DIMO-Network/accounts-api/models"
+	pb "github.com/DIMO-Network/accounts-api/pkg/grpc"
 
 	"github.com/DIMO-Network/shared/db"
 	"github.com/MicahParks/keyfunc/v3"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/segmentio/ksuid"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 var (
@@ -69,15 +87,18 @@ var dexWalletUsers = []struct {
 
 type AccountControllerTestSuite struct {
 	suite.Suite
-	app          *fiber.App
-	settings     *config.Settings
-	pdb          db.Store
-	pgContainer  testcontainers.Container
-	dexContainer testcontainers.Container
-	ctx          context.Context
-	controller   *Controller
-	emailService services.EmailService
-	cioService   CIOClient
+	app           *fiber.App
+	settings      *config.Settings
+	pdb           db.Store
+	pgContainer   testcontainers.Container
+	dexContainer  testcontainers.Container
+	ctx           context.Context
+	controller    *Controller
+	emailService  *test.EmailService
+	cioService    CIOClient
+	eventProducer *test.EventProducer
+	dispatcher    *outbox.Dispatcher
+	devicesServer *test.DevicesServer
 }
 
 // SetupSuite starts container db
@@ -94,6 +115,10 @@ func (s *AccountControllerTestSuite) SetupSuite() {
 	s.settings = &config.Settings{
 		JWTKeySetURL:            fmt.Sprintf("%s/dex/keys", addr),
 		EmailCodeDuration:       "5m",
+		EmailLinkSigningKey:     "test-signing-key",
+		EmailLinkBaseURL:        "https://accounts-api.test",
+		SIWEDomains:             "app.dimo.test",
+		DIMORegistryChainID:     80002,
 		DisableCustomerIOEvents: true,
 	}
 
@@ -101,24 +126,60 @@ func (s *AccountControllerTestSuite) SetupSuite() {
 	s.Require().NoError(err)
 	s.cioService = cioSvc
 
+	s.eventProducer = &test.EventProducer{}
+
+	devicesServer, devicesConn := test.StartDevicesServer(s.T())
+	s.devicesServer = devicesServer
+
+	acctCont, err := NewAccountController(s.ctx, s.pdb, s.emailService, &test.IdentityService{}, s.cioService, s.eventProducer, devices.NewClient(devicesConn), s.settings, test.Logger())
+	s.Assert().NoError(err)
+	s.controller = acctCont
+
+	dispatcher, err := outbox.NewDispatcher(s.pdb, s.controller.OutboxHandlers(), s.settings, test.Logger())
+	s.Require().NoError(err)
+	s.dispatcher = dispatcher
+
+	// Registered ahead of the JWT middleware, as in main.
+	s.app.Get("/email/verify", s.controller.ShowEmailLink)
+	s.app.Post("/email/verify", s.controller.VerifyEmailLink)
+
 	s.app.Use(jwtware.New(jwtware.Config{
 		JWKSetURLs: []string{s.settings.JWTKeySetURL},
 		Claims:     &AccountClaims{},
 	}))
 
-	acctCont, err := NewAccountController(s.ctx, s.pdb, s.emailService, s.cioService, s.settings, test.Logger())
-	s.Assert().NoError(err)
-	s.controller = acctCont
 	s.app.Post("/", s.controller.CreateAccount)
 	s.app.Get("/", s.controller.GetUserAccount)
 	s.app.Delete("/", s.controller.DeleteUser)
+	s.app.Post("/restore", s.controller.RestoreAccount)
+	s.app.Get("/export", s.controller.ExportAccount)
 	s.app.Put("/update", s.controller.UpdateUser)
+	s.app.Patch("/", s.controller.PatchAccount)
 
 	s.app.Post("/agree-tos", s.controller.AcceptTOS)
+	s.app.Post("/legal/accept", s.controller.AcceptDocuments)
+	s.app.Get("/preferences", s.controller.GetPreferences)
+	s.app.Put("/preferences", s.controller.UpdatePreferences)
 	s.app.Post("/referral/submit", s.controller.SubmitReferralCode)
+	s.app.Get("/referrals", s.controller.GetReferrals)
+	s.app.Post("/referral/regenerate", s.controller.RegenerateReferralCode)
 	s.app.Post("/link/wallet/token", s.controller.LinkWalletToken)
+	s.app.Delete("/link/wallet", s.controller.UnlinkWallet)
+	s.app.Get("/link/wallet/siwe/nonce", s.controller.GetSIWENonce)
+	s.app.Post("/link/wallet/siwe", s.controller.LinkWalletSIWE)
+	s.app.Post("/link/wallet/replace", s.controller.ReplaceWallet)
+	s.app.Get("/wallets", s.controller.ListWallets)
+	s.app.Post("/wallets", s.controller.LinkWalletToken)
+	s.app.Delete("/wallets/:address", s.controller.RemoveWallet)
+	s.app.Put("/wallets/:address/primary", s.controller.SetPrimaryWallet)
 	s.app.Post("/link/email/token", s.controller.LinkEmailToken)
 	s.app.Post("/link/email", s.controller.LinkEmail)
+	s.app.Post("/link/email/confirm", s.controller.ConfirmEmail)
+	s.app.Post("/link/email/resend", s.controller.ResendConfirmationEmail)
+	s.app.Post("/link/email/change", s.controller.ChangeEmail)
+	s.app.Post("/link/email/change/confirm", s.controller.ConfirmEmailChange)
+	s.app.Post("/link/email/change/token", s.controller.ChangeEmailToken)
+	s.app.Delete("/link/email", s.controller.UnlinkEmail)
 
 }
 
@@ -145,6 +206,17 @@ func TestDevicesControllerTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountControllerTestSuite))
 }
 
+// dispatchOutbox delivers everything waiting in the outbox.
+func (s *AccountControllerTestSuite) dispatchOutbox() {
+	for {
+		n, err := s.dispatcher.DispatchOnce(s.ctx)
+		s.Require().NoError(err)
+		if n == 0 {
+			return
+		}
+	}
+}
+
 func (s *AccountControllerTestSuite) Test_EmailFirstAccount_CreateAndDelete() {
 	// Create Account
 	createAcctReq := test.BuildRequest("POST", "/", "", dexEmailUsers[0].AuthToken)
@@ -243,6 +315,187 @@ func (s *AccountControllerTestSuite) Test_EmailFirstAccount_LinkWallet() {
 	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
 }
 
+func (s *AccountControllerTestSuite) Test_EmailFirstAccount_ReplaceAndUnlinkWallet() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexEmailUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	linkWalletBodyBytes, _ := json.Marshal(TokenBody{Token: dexWalletUsers[0].AuthToken})
+	linkReq := test.BuildRequest("POST", "/link/wallet/token", string(linkWalletBodyBytes), dexEmailUsers[0].AuthToken)
+	linkResp, _ := s.app.Test(linkReq)
+	s.Assert().Equal(200, linkResp.StatusCode)
+
+	// The old token has to match the linked wallet.
+	wrongBodyBytes, _ := json.Marshal(ReplaceWalletRequest{OldToken: dexWalletUsers[2].AuthToken, NewToken: dexWalletUsers[1].AuthToken})
+	wrongReq := test.BuildRequest("POST", "/link/wallet/replace", string(wrongBodyBytes), dexEmailUsers[0].AuthToken)
+	wrongResp, _ := s.app.Test(wrongReq)
+	s.Assert().Equal(400, wrongResp.StatusCode)
+
+	replaceBodyBytes, _ := json.Marshal(ReplaceWalletRequest{OldToken: dexWalletUsers[0].AuthToken, NewToken: dexWalletUsers[1].AuthToken})
+	replaceReq := test.BuildRequest("POST", "/link/wallet/replace", string(replaceBodyBytes), dexEmailUsers[0].AuthToken)
+	replaceResp, _ := s.app.Test(replaceReq)
+	s.Assert().Equal(200, replaceResp.StatusCode)
+
+	// The account can now be reached through the new wallet only.
+	oldWalletReq := test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
+	oldWalletResp, _ := s.app.Test(oldWalletReq)
+	s.Assert().Equal(404, oldWalletResp.StatusCode)
+
+	newWalletReq := test.BuildRequest("GET", "/", "", dexWalletUsers[1].AuthToken)
+	newWalletResp, _ := s.app.Test(newWalletReq)
+	s.Assert().Equal(200, newWalletResp.StatusCode)
+
+	var userResp UserResponse
+	s.Require().NoError(json.NewDecoder(newWalletResp.Body).Decode(&userResp))
+	s.Require().NotNil(userResp.Email)
+	s.Assert().Equal(dexEmailUsers[0].Email, userResp.Email.Address)
+
+	unlinkReq := test.BuildRequest("DELETE", "/link/wallet", "", dexEmailUsers[0].AuthToken)
+	unlinkResp, _ := s.app.Test(unlinkReq)
+	s.Assert().Equal(200, unlinkResp.StatusCode)
+
+	count, err := models.Wallets().Count(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Assert().Zero(count)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_EmailFirstAccount_LinkWalletSIWE() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexEmailUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	nonceReq := test.BuildRequest("GET", "/link/wallet/siwe/nonce", "", dexEmailUsers[0].AuthToken)
+	nonceResp, _ := s.app.Test(nonceReq)
+	s.Require().Equal(200, nonceResp.StatusCode)
+
+	var nonce SIWENonceResponse
+	s.Require().NoError(json.NewDecoder(nonceResp.Body).Decode(&nonce))
+
+	key, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	buildMessage := func(domain string) LinkWalletSIWERequest {
+		msg := fmt.Sprintf("%s wants you to sign in with your Ethereum account:\n%s\n\nLink this wallet to your DIMO account.\n\nURI: https://%s\nVersion: 1\nChain ID: 80002\nNonce: %s\nIssued At: %s",
+			domain, addr.Hex(), domain, nonce.Nonce, time.Now().UTC().Format(time.RFC3339))
+		sig, err := crypto.Sign(accounts.TextHash([]byte(msg)), key)
+		s.Require().NoError(err)
+		sig[crypto.RecoveryIDOffset] += 27
+		return LinkWalletSIWERequest{Message: msg, Signature: hexutil.Encode(sig)}
+	}
+
+	badDomainBytes, _ := json.Marshal(buildMessage("evil.test"))
+	badDomainReq := test.BuildRequest("POST", "/link/wallet/siwe", string(badDomainBytes), dexEmailUsers[0].AuthToken)
+	badDomainResp, _ := s.app.Test(badDomainReq)
+	s.Assert().Equal(400, badDomainResp.StatusCode)
+
+	linkBytes, _ := json.Marshal(buildMessage("app.dimo.test"))
+	linkReq := test.BuildRequest("POST", "/link/wallet/siwe", string(linkBytes), dexEmailUsers[0].AuthToken)
+	linkResp, _ := s.app.Test(linkReq)
+	s.Assert().Equal(200, linkResp.StatusCode)
+
+	wallet, err := models.FindWallet(s.ctx, s.pdb.DBS().Reader, addr.Bytes())
+	s.Require().NoError(err)
+
+	emailAcct, err := models.FindEmail(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+	s.Assert().Equal(emailAcct.AccountID, wallet.AccountID)
+
+	// The nonce can't be replayed.
+	replayReq := test.BuildRequest("POST", "/link/wallet/siwe", string(linkBytes), dexEmailUsers[0].AuthToken)
+	replayResp, _ := s.app.Test(replayReq)
+	s.Assert().Equal(400, replayResp.StatusCode)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_UnlinkWallet_NoEmail() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	unlinkReq := test.BuildRequest("DELETE", "/link/wallet", "", dexWalletUsers[0].AuthToken)
+	unlinkResp, _ := s.app.Test(unlinkReq)
+	s.Assert().Equal(400, unlinkResp.StatusCode)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_MultipleWallets() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	addBodyBytes, _ := json.Marshal(TokenBody{Token: dexWalletUsers[1].AuthToken})
+	addReq := test.BuildRequest("POST", "/wallets", string(addBodyBytes), dexWalletUsers[0].AuthToken)
+	addResp, _ := s.app.Test(addReq)
+	s.Assert().Equal(200, addResp.StatusCode)
+
+	listWallets := func() []UserResponseWallet {
+		listReq := test.BuildRequest("GET", "/wallets", "", dexWalletUsers[0].AuthToken)
+		listResp, _ := s.app.Test(listReq)
+		s.Require().Equal(200, listResp.StatusCode)
+
+		var out WalletsResponse
+		s.Require().NoError(json.NewDecoder(listResp.Body).Decode(&out))
+		return out.Wallets
+	}
+
+	wallets := listWallets()
+	s.Require().Len(wallets, 2)
+	s.Assert().Equal(dexWalletUsers[0].Wallet, wallets[0].Address)
+	s.Assert().True(wallets[0].Primary)
+	s.Assert().Equal(dexWalletUsers[1].Wallet, wallets[1].Address)
+	s.Assert().False(wallets[1].Primary)
+
+	// Either wallet reaches the account.
+	secondReq := test.BuildRequest("GET", "/", "", dexWalletUsers[1].AuthToken)
+	secondResp, _ := s.app.Test(secondReq)
+	s.Require().Equal(200, secondResp.StatusCode)
+
+	var userResp UserResponse
+	s.Require().NoError(json.NewDecoder(secondResp.Body).Decode(&userResp))
+	s.Require().NotNil(userResp.Wallet)
+	s.Assert().Equal(dexWalletUsers[0].Wallet, userResp.Wallet.Address)
+	s.Assert().Len(userResp.Wallets, 2)
+
+	primaryReq := test.BuildRequest("PUT", "/wallets/"+dexWalletUsers[1].Wallet+"/primary", "", dexWalletUsers[0].AuthToken)
+	primaryResp, _ := s.app.Test(primaryReq)
+	s.Assert().Equal(200, primaryResp.StatusCode)
+
+	wallets = listWallets()
+	s.Require().Len(wallets, 2)
+	s.Assert().False(wallets[0].Primary)
+	s.Assert().True(wallets[1].Primary)
+
+	unknownReq := test.BuildRequest("DELETE", "/wallets/"+dexWalletUsers[2].Wallet, "", dexWalletUsers[0].AuthToken)
+	unknownResp, _ := s.app.Test(unknownReq)
+	s.Assert().Equal(404, unknownResp.StatusCode)
+
+	// Removing the primary wallet promotes the remaining one.
+	removeReq := test.BuildRequest("DELETE", "/wallets/"+dexWalletUsers[1].Wallet, "", dexWalletUsers[0].AuthToken)
+	removeResp, _ := s.app.Test(removeReq)
+	s.Assert().Equal(200, removeResp.StatusCode)
+
+	wallets = listWallets()
+	s.Require().Len(wallets, 1)
+	s.Assert().Equal(dexWalletUsers[0].Wallet, wallets[0].Address)
+	s.Assert().True(wallets[0].Primary)
+
+	// The last wallet can't go without a confirmed email.
+	lastReq := test.BuildRequest("DELETE", "/wallets/"+dexWalletUsers[0].Wallet, "", dexWalletUsers[0].AuthToken)
+	lastResp, _ := s.app.Test(lastReq)
+	s.Assert().Equal(400, lastResp.StatusCode)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
 func (s *AccountControllerTestSuite) Test_WalletFirstAccount_CreateAndDelete() {
 	// Create Account
 	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[1].AuthToken)
@@ -274,98 +527,1532 @@ func (s *AccountControllerTestSuite) Test_WalletFirstAccount_CreateAndDelete() {
 
 }
 
-func (s *AccountControllerTestSuite) Test_WalletFirstAccount_LinkEmailToken() {
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_DeleteWithDevices() {
 	// Create Account
 	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
 	createAcctResp, _ := s.app.Test(createAcctReq)
-	s.Assert().Equal(200, createAcctResp.StatusCode)
+	s.Require().Equal(201, createAcctResp.StatusCode)
 
-	// Link email via token
-	linkEmailBody := TokenBody{
-		Token: dexEmailUsers[2].AuthToken,
-	}
-	linkEmailBodyBytes, _ := json.Marshal(linkEmailBody)
+	addBodyBytes, _ := json.Marshal(TokenBody{Token: dexWalletUsers[1].AuthToken})
+	addReq := test.BuildRequest("POST", "/wallets", string(addBodyBytes), dexWalletUsers[0].AuthToken)
+	addResp, _ := s.app.Test(addReq)
+	s.Require().Equal(200, addResp.StatusCode)
 
-	putReq := test.BuildRequest("POST", "/link/email/token", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
-	putResp, _ := s.app.Test(putReq)
-	_, err := io.ReadAll(putResp.Body)
+	// Devices on either wallet block deletion. Don't double count.
+	s.devicesServer.SetDevices(dexWalletUsers[0].Wallet, "2fFDCcEVmDIWVHAm0AYf0M8Xp4R")
+	s.devicesServer.SetDevices(dexWalletUsers[1].Wallet, "2fFDCcEVmDIWVHAm0AYf0M8Xp4R", "2fFDCjJnJ5bbZFvk4wTSiSAEb4E")
+	defer s.devicesServer.SetDevices(dexWalletUsers[0].Wallet)
+	defer s.devicesServer.SetDevices(dexWalletUsers[1].Wallet)
+
+	deleteReq := test.BuildRequest("DELETE", "/", "", dexWalletUsers[0].AuthToken)
+	deleteResp, _ := s.app.Test(deleteReq)
+	s.Require().Equal(409, deleteResp.StatusCode)
+
+	var errResp ErrorRes
+	s.Require().NoError(json.NewDecoder(deleteResp.Body).Decode(&errResp))
+	s.Assert().Contains(errResp.Message, "2 devices")
+
+	exists, err := models.WalletExists(s.ctx, s.pdb.DBS().Reader, common.HexToAddress(dexWalletUsers[0].Wallet).Bytes())
 	s.Require().NoError(err)
-	s.Assert().Equal(204, putResp.StatusCode)
+	s.Assert().True(exists)
+
+	s.devicesServer.SetDevices(dexWalletUsers[0].Wallet)
+	s.devicesServer.SetDevices(dexWalletUsers[1].Wallet)
+
+	deleteReq = test.BuildRequest("DELETE", "/", "", dexWalletUsers[0].AuthToken)
+	deleteResp, _ = s.app.Test(deleteReq)
+	s.Assert().Equal(200, deleteResp.StatusCode)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_DeleteDevicesUnavailable() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	s.devicesServer.SetFailing(true)
+	defer s.devicesServer.SetFailing(false)
+
+	deleteReq := test.BuildRequest("DELETE", "/", "", dexWalletUsers[0].AuthToken)
+	deleteResp, _ := s.app.Test(deleteReq)
+	s.Assert().Equal(503, deleteResp.StatusCode)
+
+	acct, err := models.Accounts().One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Assert().False(acct.DeletedAt.Valid)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_Events() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[2].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	var userResp UserResponse
+	s.Require().NoError(json.NewDecoder(createAcctResp.Body).Decode(&userResp))
+
+	updateBodyBytes, _ := json.Marshal(UserUpdateRequest{CountryCode: "USA"})
+	putReq := test.BuildRequest("PUT", "/update", string(updateBodyBytes), dexWalletUsers[2].AuthToken)
+	putResp, _ := s.app.Test(putReq)
+	s.Assert().Equal(200, putResp.StatusCode)
+
+	tosReq := test.BuildRequest("POST", "/agree-tos", "", dexWalletUsers[2].AuthToken)
+	tosResp, _ := s.app.Test(tosReq)
+	s.Assert().Equal(200, tosResp.StatusCode)
+
+	linkBodyBytes, _ := json.Marshal(TokenBody{Token: dexEmailUsers[1].AuthToken})
+	linkReq := test.BuildRequest("POST", "/link/email/token", string(linkBodyBytes), dexWalletUsers[2].AuthToken)
+	linkResp, _ := s.app.Test(linkReq)
+	s.Assert().Equal(200, linkResp.StatusCode)
+
+	deleteReq := test.BuildRequest("DELETE", "/", "", dexWalletUsers[2].AuthToken)
+	deleteResp, _ := s.app.Test(deleteReq)
+	s.Assert().Equal(200, deleteResp.StatusCode)
+
+	s.dispatchOutbox()
+
+	s.Assert().Equal([]string{
+		events.AccountCreatedType,
+		events.CountryChangedType,
+		events.LegalDocumentAcceptedType,
+		events.TOSAcceptedType,
+		events.EmailLinkedType,
+		events.AccountDeletionScheduledType,
+	}, s.eventProducer.Types(userResp.ID))
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_DeleteAndRestore() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	restoreReq := test.BuildRequest("POST", "/restore", "", dexWalletUsers[0].AuthToken)
+	restoreResp, _ := s.app.Test(restoreReq)
+	s.Assert().Equal(400, restoreResp.StatusCode)
 
+	deleteReq := test.BuildRequest("DELETE", "/", "", dexWalletUsers[0].AuthToken)
+	deleteResp, _ := s.app.Test(deleteReq)
+	s.Require().Equal(200, deleteResp.StatusCode)
+
+	// The account is locked, but still there
 	getReq := test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
 	getResp, _ := s.app.Test(getReq)
-	getBody, err := io.ReadAll(getResp.Body)
+	s.Assert().Equal(410, getResp.StatusCode)
+
+	exists, err := models.WalletExists(s.ctx, s.pdb.DBS().Reader, common.HexToAddress(dexWalletUsers[0].Wallet).Bytes())
 	s.Require().NoError(err)
+	s.Assert().True(exists)
+
+	restoreReq = test.BuildRequest("POST", "/restore", "", dexWalletUsers[0].AuthToken)
+	restoreResp, _ = s.app.Test(restoreReq)
+	s.Assert().Equal(200, restoreResp.StatusCode)
+
+	getReq = test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
+	getResp, _ = s.app.Test(getReq)
 	s.Assert().Equal(200, getResp.StatusCode)
 
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_Export() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
 	var userResp UserResponse
-	if err := json.Unmarshal(getBody, &userResp); err != nil {
-		s.Require().NoError(err)
-	}
+	s.Require().NoError(json.NewDecoder(createAcctResp.Body).Decode(&userResp))
+
+	tosReq := test.BuildRequest("POST", "/agree-tos", "", dexWalletUsers[0].AuthToken)
+	tosResp, _ := s.app.Test(tosReq)
+	s.Require().Equal(200, tosResp.StatusCode)
+
+	linkBodyBytes, _ := json.Marshal(TokenBody{Token: dexEmailUsers[0].AuthToken})
+	linkReq := test.BuildRequest("POST", "/link/email/token", string(linkBodyBytes), dexWalletUsers[0].AuthToken)
+	linkResp, _ := s.app.Test(linkReq)
+	s.Require().Equal(200, linkResp.StatusCode)
+
+	// Someone we referred
+	referred, err := test.NewAccount(s.pdb.DBS().Writer)
+	s.Require().NoError(err)
+	referred.ReferredBy = null.StringFrom(userResp.ID)
+	referred.ReferredAt = null.TimeFrom(time.Now())
+	_, err = referred.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	on := true
+	prefsBodyBytes, _ := json.Marshal(CommunicationPreferencesUpdate{MarketingEmail: &on})
+	prefsReq := test.BuildRequest("PUT", "/preferences", string(prefsBodyBytes), dexWalletUsers[0].AuthToken)
+	prefsResp, _ := s.app.Test(prefsReq)
+	s.Require().Equal(200, prefsResp.StatusCode)
+
+	exportReq := test.BuildRequest("GET", "/export", "", dexWalletUsers[0].AuthToken)
+	exportResp, _ := s.app.Test(exportReq)
+	s.Require().Equal(200, exportResp.StatusCode)
+	s.Assert().Contains(exportResp.Header.Get("Content-Disposition"), "attachment")
+
+	var export AccountExport
+	s.Require().NoError(json.NewDecoder(exportResp.Body).Decode(&export))
+
+	s.Assert().Equal(userResp.ID, export.Account.ID)
+	s.Require().NotNil(export.Email)
+	s.Assert().Equal(dexEmailUsers[0].Email, export.Email.Address)
+	s.Assert().NotNil(export.Email.ConfirmedAt)
+	s.Require().Len(export.Wallets, 1)
+	s.Assert().Equal(dexWalletUsers[0].Wallet, export.Wallets[0].Address)
+	s.Assert().Equal(userResp.Referral.Code, export.Referral.Code)
+	s.Require().Len(export.Referral.Referred, 1)
+	s.Require().NotNil(export.Referral.Referred[0].Wallet)
+	s.Assert().Equal(common.BytesToAddress(referred.R.Wallets[0].Address).Hex(), *export.Referral.Referred[0].Wallet)
+	s.Require().Len(export.LegalAcceptances, 1)
+	s.Assert().Equal("tos", export.LegalAcceptances[0].Kind)
+	s.Require().Len(export.CommunicationPreferenceChanges, 1)
+	s.Assert().Equal(preferences.MarketingEmail, export.CommunicationPreferenceChanges[0].Channel)
+	s.Assert().True(export.CommunicationPreferenceChanges[0].Enabled)
+	s.Assert().Equal(preferences.SourceUser, export.CommunicationPreferenceChanges[0].Source)
 
-	s.Assert().NotNil(userResp.Email)
-	s.Assert().Equal(dexEmailUsers[2].Email, userResp.Email.Address)
-	s.Assert().NotNil(userResp.Wallet)
-	s.Assert().Equal(userResp.Wallet.Address, dexWalletUsers[0].Wallet)
 	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
 }
 
-func (s *AccountControllerTestSuite) Test_WalletFirstAccount_LinkEmailConfirm() {
-	s.T().Skip()
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_LegalDocuments() {
 	// Create Account
 	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
 	createAcctResp, _ := s.app.Test(createAcctReq)
-	s.Assert().Equal(200, createAcctResp.StatusCode)
+	s.Require().Equal(201, createAcctResp.StatusCode)
 
-	// Link email via confirmation code
-	linkEmailBody := AddEmailRequest{
-		Address: dexEmailUsers[0].Email,
+	var userResp UserResponse
+	s.Require().NoError(json.NewDecoder(createAcctResp.Body).Decode(&userResp))
+	s.Assert().Len(userResp.OutstandingDocuments, 3)
+
+	tosReq := test.BuildRequest("POST", "/agree-tos", "", dexWalletUsers[0].AuthToken)
+	tosResp, _ := s.app.Test(tosReq)
+	s.Require().Equal(200, tosResp.StatusCode)
+
+	// Old versions can't be accepted
+	badBodyBytes, _ := json.Marshal(AcceptDocumentsRequest{Documents: []AcceptDocumentsRequestDocument{{Kind: "privacy", Version: "0"}}})
+	badReq := test.BuildRequest("POST", "/legal/accept", string(badBodyBytes), dexWalletUsers[0].AuthToken)
+	badResp, _ := s.app.Test(badReq)
+	s.Assert().Equal(400, badResp.StatusCode)
+
+	acceptBodyBytes, _ := json.Marshal(AcceptDocumentsRequest{Documents: []AcceptDocumentsRequestDocument{{Kind: "privacy", Version: "1"}}})
+	acceptReq := test.BuildRequest("POST", "/legal/accept", string(acceptBodyBytes), dexWalletUsers[0].AuthToken)
+	acceptResp, _ := s.app.Test(acceptReq)
+	s.Require().Equal(200, acceptResp.StatusCode)
+
+	getReq := test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
+	getResp, _ := s.app.Test(getReq)
+	s.Require().Equal(200, getResp.StatusCode)
+	s.Require().NoError(json.NewDecoder(getResp.Body).Decode(&userResp))
+	s.Require().Len(userResp.OutstandingDocuments, 1)
+	s.Assert().Equal("data_sharing", userResp.OutstandingDocuments[0].Kind)
+	s.Assert().NotNil(userResp.AcceptedTOSAt)
+
+	// Accepting the same document twice at once is fine.
+	sharingBodyBytes, _ := json.Marshal(AcceptDocumentsRequest{Documents: []AcceptDocumentsRequestDocument{{Kind: "data_sharing", Version: "1"}}})
+	var wg sync.WaitGroup
+	codes := make([]int, 2)
+	for i := range codes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sharingReq := test.BuildRequest("POST", "/legal/accept", string(sharingBodyBytes), dexWalletUsers[0].AuthToken)
+			sharingResp, err := s.app.Test(sharingReq)
+			if err == nil {
+				codes[i] = sharingResp.StatusCode
+			}
+		}()
 	}
-	linkEmailBodyBytes, _ := json.Marshal(linkEmailBody)
-	postReq := test.BuildRequest("POST", "/link/email", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
-	postResp, _ := s.app.Test(postReq)
-	_, err := io.ReadAll(postResp.Body)
+	wg.Wait()
+	s.Assert().Equal([]int{200, 200}, codes)
+
+	// A new version of the terms needs accepting again
+	newTOS := models.LegalDocument{Kind: "tos", Version: "2", EffectiveAt: time.Now().Add(-time.Minute)}
+	s.Require().NoError(newTOS.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+	defer newTOS.Delete(s.ctx, s.pdb.DBS().Writer) //nolint
+
+	getReq = test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
+	getResp, _ = s.app.Test(getReq)
+	s.Require().NoError(json.NewDecoder(getResp.Body).Decode(&userResp))
+	s.Assert().Len(userResp.OutstandingDocuments, 2)
+
+	tosReq = test.BuildRequest("POST", "/agree-tos", "", dexWalletUsers[0].AuthToken)
+	tosResp, _ = s.app.Test(tosReq)
+	s.Require().Equal(200, tosResp.StatusCode)
+
+	acceptances, err := models.LegalAcceptances(models.LegalAcceptanceWhere.AccountID.EQ(userResp.ID)).All(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Assert().Len(acceptances, 4)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_Preferences() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	var userResp UserResponse
+	s.Require().NoError(json.NewDecoder(createAcctResp.Body).Decode(&userResp))
+	s.Assert().False(userResp.CommunicationPreferences.MarketingEmail.Enabled)
+	s.Assert().Nil(userResp.CommunicationPreferences.MarketingEmail.UpdatedAt)
+
+	on := true
+	putBodyBytes, _ := json.Marshal(CommunicationPreferencesUpdate{MarketingEmail: &on})
+	putReq := test.BuildRequest("PUT", "/preferences", string(putBodyBytes), dexWalletUsers[0].AuthToken)
+	putResp, _ := s.app.Test(putReq)
+	s.Require().Equal(200, putResp.StatusCode)
+
+	var prefs CommunicationPreferences
+	s.Require().NoError(json.NewDecoder(putResp.Body).Decode(&prefs))
+	s.Assert().True(prefs.MarketingEmail.Enabled)
+	s.Assert().NotNil(prefs.MarketingEmail.UpdatedAt)
+	s.Assert().False(prefs.ProductUpdates.Enabled)
+
+	// Setting the same value again changes nothing
+	putReq = test.BuildRequest("PUT", "/preferences", string(putBodyBytes), dexWalletUsers[0].AuthToken)
+	putResp, _ = s.app.Test(putReq)
+	s.Require().Equal(200, putResp.StatusCode)
+
+	syncs, err := models.OutboxMessages(models.OutboxMessageWhere.Kind.EQ(cioSetPrefsKind)).Count(s.ctx, s.pdb.DBS().Reader)
 	s.Require().NoError(err)
-	s.Assert().Equal(204, postResp.StatusCode)
+	s.Assert().EqualValues(1, syncs)
 
-	_, err = models.Emails(models.EmailWhere.Address.EQ(dexEmailUsers[0].Email)).One(s.ctx, s.pdb.DBS().Reader)
+	changes, err := models.CommunicationPreferenceChanges(models.CommunicationPreferenceChangeWhere.AccountID.EQ(userResp.ID)).All(s.ctx, s.pdb.DBS().Reader)
 	s.Require().NoError(err)
+	s.Require().Len(changes, 1)
+	s.Assert().Equal(preferences.MarketingEmail, changes[0].Channel)
+	s.Assert().True(changes[0].Enabled)
+	s.Assert().Equal(preferences.SourceUser, changes[0].Source)
 
-	confirmEmailBody := CompleteEmailValidation{
-		// Key: eml.ConfirmationCode.String,
+	getReq := test.BuildRequest("GET", "/preferences", "", dexWalletUsers[0].AuthToken)
+	getResp, _ := s.app.Test(getReq)
+	s.Require().Equal(200, getResp.StatusCode)
+	s.Require().NoError(json.NewDecoder(getResp.Body).Decode(&prefs))
+	s.Assert().True(prefs.MarketingEmail.Enabled)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_EmailFirstAccount_Preferences() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexEmailUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	on, off := true, false
+	for _, update := range []CommunicationPreferencesUpdate{{MarketingEmail: &on}, {MarketingEmail: &off}} {
+		putBodyBytes, _ := json.Marshal(update)
+		putReq := test.BuildRequest("PUT", "/preferences", string(putBodyBytes), dexEmailUsers[0].AuthToken)
+		putResp, _ := s.app.Test(putReq)
+		s.Require().Equal(200, putResp.StatusCode)
 	}
-	confirmEmailBytes, _ := json.Marshal(confirmEmailBody)
-	confirmReq := test.BuildRequest("POST", "/link/email/confirm", string(confirmEmailBytes), dexWalletUsers[0].AuthToken)
-	confirmResp, _ := s.app.Test(confirmReq)
-	_, err = io.ReadAll(confirmResp.Body)
+
+	// Without a wallet, the email's profile gets the preferences.
+	syncs, err := models.OutboxMessages(models.OutboxMessageWhere.Kind.EQ(cioSetPrefsKind), qm.OrderBy(models.OutboxMessageColumns.Seq)).All(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Require().Len(syncs, 2)
+
+	var payload cioPrefsPayload
+	s.Require().NoError(json.Unmarshal(syncs[1].Payload, &payload))
+	s.Assert().Nil(payload.Wallet)
+	s.Assert().Equal(dexEmailUsers[0].Email, payload.Email)
+	s.Assert().False(payload.Preferences[preferences.MarketingEmail])
+
+	changes, err := models.CommunicationPreferenceChanges(qm.OrderBy(models.CommunicationPreferenceChangeColumns.ID)).All(s.ctx, s.pdb.DBS().Reader)
 	s.Require().NoError(err)
-	s.Assert().Equal(204, confirmResp.StatusCode)
+	s.Require().Len(changes, 2)
+	s.Assert().True(changes[0].Enabled)
+	s.Assert().False(changes[1].Enabled)
+
 	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
 }
 
-func (s *AccountControllerTestSuite) Test_SubmitReferralCode() {
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_UpdateProfile() {
 	// Create Account
 	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
 	createAcctResp, _ := s.app.Test(createAcctReq)
-	s.Assert().Equal(200, createAcctResp.StatusCode)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	for _, bad := range []UserUpdateRequest{
+		{DisplayName: strings.Repeat("a", 65)},
+		{Language: "not a language"},
+		{TimeZone: "Mars/Olympus_Mons"},
+		{TimeZone: "Local"},
+		{Units: "furlongs"},
+	} {
+		badBodyBytes, _ := json.Marshal(bad)
+		badReq := test.BuildRequest("PUT", "/update", string(badBodyBytes), dexWalletUsers[0].AuthToken)
+		badResp, _ := s.app.Test(badReq)
+		s.Assert().Equal(400, badResp.StatusCode, "%+v", bad)
+	}
 
-	refAcct, err := test.NewAccount(s.pdb.DBS().Writer) // Create Referrer Account
-	s.Require().NoError(err)
+	updateBodyBytes, _ := json.Marshal(UserUpdateRequest{
+		DisplayName: "  Kilgore Trout ",
+		Language:    "en-us",
+		TimeZone:    "America/New_York",
+		Units:       "imperial",
+	})
+	putReq := test.BuildRequest("PUT", "/update", string(updateBodyBytes), dexWalletUsers[0].AuthToken)
+	putResp, _ := s.app.Test(putReq)
+	s.Require().Equal(200, putResp.StatusCode)
 
-	referralCodeBody := SubmitReferralCodeRequest{
-		Code: refAcct.ReferralCode,
+	var userResp UserResponse
+	s.Require().NoError(json.NewDecoder(putResp.Body).Decode(&userResp))
+	s.Require().NotNil(userResp.DisplayName)
+	s.Assert().Equal("Kilgore Trout", *userResp.DisplayName)
+	s.Require().NotNil(userResp.Language)
+	s.Assert().Equal("en-US", *userResp.Language)
+	s.Require().NotNil(userResp.TimeZone)
+	s.Assert().Equal("America/New_York", *userResp.TimeZone)
+	s.Require().NotNil(userResp.Units)
+	s.Assert().Equal("imperial", *userResp.Units)
+	s.Assert().Nil(userResp.CountryCode)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_PatchAccount() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	patch := func(body string) *http.Response {
+		req := test.BuildRequest("PATCH", "/", body, dexWalletUsers[0].AuthToken)
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		resp, err := s.app.Test(req)
+		s.Require().NoError(err)
+		return resp
 	}
-	referralCodeBodyBytes, _ := json.Marshal(referralCodeBody)
 
-	// Set identity svc to be consistent with referral eligibility
-	test.IdentityServiceResponse = false
+	badResp := patch(`{"units": "furlongs", "timeZone": 5, "favoriteColor": "blue"}`)
+	s.Require().Equal(400, badResp.StatusCode)
+
+	var errResp ValidationErrorRes
+	s.Require().NoError(json.NewDecoder(badResp.Body).Decode(&errResp))
+	s.Require().Len(errResp.Fields, 3)
+	s.Assert().Equal("favoriteColor", errResp.Fields[0].Field)
+	s.Assert().Equal("timeZone", errResp.Fields[1].Field)
+	s.Assert().Equal("units", errResp.Fields[2].Field)
+
+	setResp := patch(`{"displayName": "Kilgore Trout", "units": "metric"}`)
+	s.Require().Equal(200, setResp.StatusCode)
+
+	var patchResp AccountPatchResponse
+	s.Require().NoError(json.NewDecoder(setResp.Body).Decode(&patchResp))
+	s.Assert().Equal([]string{"displayName", "units"}, patchResp.Changed)
+	s.Require().NotNil(patchResp.Account.DisplayName)
+	s.Assert().Equal("Kilgore Trout", *patchResp.Account.DisplayName)
+
+	clearResp := patch(`{"displayName": null, "units": "metric"}`)
+	s.Require().Equal(200, clearResp.StatusCode)
+
+	patchResp = AccountPatchResponse{}
+	s.Require().NoError(json.NewDecoder(clearResp.Body).Decode(&patchResp))
+	s.Assert().Equal([]string{"displayName"}, patchResp.Changed)
+	s.Assert().Nil(patchResp.Account.DisplayName)
+	s.Require().NotNil(patchResp.Account.Units)
+	s.Assert().Equal("metric", *patchResp.Account.Units)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_ConditionalRequests() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	getReq := test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
+	getResp, _ := s.app.Test(getReq)
+	s.Require().Equal(200, getResp.StatusCode)
+
+	etag := getResp.Header.Get("ETag")
+	s.Require().NotEmpty(etag)
+
+	cachedReq := test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
+	cachedReq.Header.Set("If-None-Match", etag)
+	cachedResp, _ := s.app.Test(cachedReq)
+	s.Assert().Equal(304, cachedResp.StatusCode)
+
+	updateBodyBytes, _ := json.Marshal(UserUpdateRequest{Units: "metric"})
+	putReq := test.BuildRequest("PUT", "/update", string(updateBodyBytes), dexWalletUsers[0].AuthToken)
+	putReq.Header.Set("If-Match", etag)
+	putResp, _ := s.app.Test(putReq)
+	s.Require().Equal(200, putResp.StatusCode)
+
+	newETag := putResp.Header.Get("ETag")
+	s.Assert().NotEqual(etag, newETag)
+
+	// The tag in the write response matches what a fresh read returns.
+	getReq = test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
+	getResp, _ = s.app.Test(getReq)
+	s.Require().Equal(200, getResp.StatusCode)
+	s.Assert().Equal(newETag, getResp.Header.Get("ETag"))
+
+	staleBodyBytes, _ := json.Marshal(UserUpdateRequest{Units: "imperial"})
+	staleReq := test.BuildRequest("PUT", "/update", string(staleBodyBytes), dexWalletUsers[0].AuthToken)
+	staleReq.Header.Set("If-Match", etag)
+	staleResp, _ := s.app.Test(staleReq)
+	s.Assert().Equal(412, staleResp.StatusCode)
+
+	deleteReq := test.BuildRequest("DELETE", "/", "", dexWalletUsers[0].AuthToken)
+	deleteReq.Header.Set("If-Match", etag)
+	deleteResp, _ := s.app.Test(deleteReq)
+	s.Assert().Equal(412, deleteResp.StatusCode)
+
+	acct, err := models.Accounts().One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Assert().Equal("metric", acct.Units.String)
+	s.Assert().False(acct.DeletedAt.Valid)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_ListReferrals() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	referrer, err := models.Accounts().One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+
+	// Three referees, a minute apart. The oldest is scheduled for deletion.
+	start := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	referees := make([]*models.Account, 3)
+	for i := range referees {
+		referee, err := test.NewAccount(s.pdb.DBS().Writer)
+		s.Require().NoError(err)
+
+		referee.ReferredBy = null.StringFrom(referrer.ID)
+		referee.ReferredAt = null.TimeFrom(start.Add(time.Duration(i) * time.Minute))
+		if i == 0 {
+			referee.DeletedAt = null.TimeFrom(time.Now())
+			referee.PurgeAt = null.TimeFrom(time.Now().Add(time.Hour))
+		}
+		_, err = referee.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+		s.Require().NoError(err)
+
+		referees[i] = referee
+	}
+
+	getPage := func(query string) ReferralsResponse {
+		req := test.BuildRequest("GET", "/referrals"+query, "", dexWalletUsers[0].AuthToken)
+		resp, err := s.app.Test(req)
+		s.Require().NoError(err)
+		s.Require().Equal(200, resp.StatusCode)
+
+		var page ReferralsResponse
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&page))
+		return page
+	}
+
+	first := getPage("?limit=2")
+	s.Assert().Equal(ReferralCounts{Total: 3, Deleted: 1}, first.Counts)
+	s.Require().Len(first.Referees, 2)
+	s.Assert().Equal(*primaryWalletHex(referees[2]), *first.Referees[0].Wallet)
+	s.Assert().Equal(*primaryWalletHex(referees[1]), *first.Referees[1].Wallet)
+	s.Require().NotNil(first.NextCursor)
+
+	second := getPage("?limit=2&cursor=" + url.QueryEscape(*first.NextCursor))
+	s.Require().Len(second.Referees, 1)
+	s.Assert().Equal(*primaryWalletHex(referees[0]), *second.Referees[0].Wallet)
+	s.Assert().True(second.Referees[0].Deleted)
+	s.Assert().Nil(second.NextCursor)
+
+	badReq := test.BuildRequest("GET", "/referrals?cursor=garbage", "", dexWalletUsers[0].AuthToken)
+	badResp, _ := s.app.Test(badReq)
+	s.Assert().Equal(400, badResp.StatusCode)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_ListReferrals_Purged() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	referrer, err := models.Accounts().One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+
+	// Two referees, one of them past its deletion grace period.
+	referees := make([]*models.Account, 2)
+	for i := range referees {
+		referee, err := test.NewAccount(s.pdb.DBS().Writer)
+		s.Require().NoError(err)
+
+		referee.ReferredBy = null.StringFrom(referrer.ID)
+		referee.ReferredAt = null.TimeFrom(time.Now().Add(-time.Hour))
+		if i == 0 {
+			referee.DeletedAt = null.TimeFrom(time.Now().Add(-48 * time.Hour))
+			referee.PurgeAt = null.TimeFrom(time.Now().Add(-time.Hour))
+		}
+		_, err = referee.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+		s.Require().NoError(err)
+
+		referees[i] = referee
+	}
+
+	n, err := PurgeDeletedAccounts(s.ctx, s.pdb, test.Logger())
+	s.Require().NoError(err)
+	s.Require().Equal(1, n)
+
+	req := test.BuildRequest("GET", "/referrals", "", dexWalletUsers[0].AuthToken)
+	resp, _ := s.app.Test(req)
+	s.Require().Equal(200, resp.StatusCode)
+
+	var page ReferralsResponse
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&page))
+
+	// The purged referee still counts but isn't listed.
+	s.Assert().Equal(ReferralCounts{Total: 2, Deleted: 0, Purged: 1}, page.Counts)
+	s.Require().Len(page.Referees, 1)
+	s.Assert().Equal(*primaryWalletHex(referees[1]), *page.Referees[0].Wallet)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_PurgeDeletedAccounts() {
+	expired, err := test.NewAccount(s.pdb.DBS().Writer)
+	s.Require().NoError(err)
+
+	expired.DeletedAt = null.TimeFrom(time.Now().Add(-48 * time.Hour))
+	expired.PurgeAt = null.TimeFrom(time.Now().Add(-time.Hour))
+	_, err = expired.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	pending, err := test.NewAccount(s.pdb.DBS().Writer)
+	s.Require().NoError(err)
+
+	pending.DeletedAt = null.TimeFrom(time.Now())
+	pending.PurgeAt = null.TimeFrom(time.Now().Add(time.Hour))
+	_, err = pending.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	active, err := test.NewAccount(s.pdb.DBS().Writer)
+	s.Require().NoError(err)
+
+	n, err := PurgeDeletedAccounts(s.ctx, s.pdb, test.Logger())
+	s.Require().NoError(err)
+	s.Assert().Equal(1, n)
+
+	for _, acct := range []*models.Account{expired, pending, active} {
+		exists, err := models.AccountExists(s.ctx, s.pdb.DBS().Reader, acct.ID)
+		s.Require().NoError(err)
+		s.Assert().Equal(acct != expired, exists)
+	}
+
+	s.dispatchOutbox()
+	s.Assert().Equal([]string{events.AccountDeletedType}, s.eventProducer.Types(expired.ID))
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_LinkEmailToken() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(200, createAcctResp.StatusCode)
+
+	// Link email via token
+	linkEmailBody := TokenBody{
+		Token: dexEmailUsers[2].AuthToken,
+	}
+	linkEmailBodyBytes, _ := json.Marshal(linkEmailBody)
+
+	putReq := test.BuildRequest("POST", "/link/email/token", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	putResp, _ := s.app.Test(putReq)
+	_, err := io.ReadAll(putResp.Body)
+	s.Require().NoError(err)
+	s.Assert().Equal(204, putResp.StatusCode)
+
+	getReq := test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
+	getResp, _ := s.app.Test(getReq)
+	getBody, err := io.ReadAll(getResp.Body)
+	s.Require().NoError(err)
+	s.Assert().Equal(200, getResp.StatusCode)
+
+	var userResp UserResponse
+	if err := json.Unmarshal(getBody, &userResp); err != nil {
+		s.Require().NoError(err)
+	}
+
+	s.Assert().NotNil(userResp.Email)
+	s.Assert().Equal(dexEmailUsers[2].Email, userResp.Email.Address)
+	s.Assert().NotNil(userResp.Wallet)
+	s.Assert().Equal(userResp.Wallet.Address, dexWalletUsers[0].Wallet)
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_LinkEmailConfirm() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	// Link email via confirmation code
+	linkEmailBody := AddEmailRequest{
+		Address: dexEmailUsers[0].Email,
+	}
+	linkEmailBodyBytes, _ := json.Marshal(linkEmailBody)
+	postReq := test.BuildRequest("POST", "/link/email", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	_, err := io.ReadAll(postResp.Body)
+	s.Require().NoError(err)
+	s.Assert().Equal(200, postResp.StatusCode)
+
+	eml, err := models.Emails(models.EmailWhere.Address.EQ(dexEmailUsers[0].Email)).One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Require().True(eml.ConfirmationCodeHash.Valid)
+	s.Assert().NotEqual(s.emailService.LastCode(dexEmailUsers[0].Email), eml.ConfirmationCodeHash.String)
+	s.Assert().False(eml.ConfirmedAt.Valid)
+
+	// Wrong code
+	wrongEmailBytes, _ := json.Marshal(CompleteEmailValidation{Code: "abcdef"})
+	wrongReq := test.BuildRequest("POST", "/link/email/confirm", string(wrongEmailBytes), dexWalletUsers[0].AuthToken)
+	wrongResp, _ := s.app.Test(wrongReq)
+	s.Assert().Equal(400, wrongResp.StatusCode)
+
+	confirmEmailBody := CompleteEmailValidation{
+		Code: s.emailService.LastCode(dexEmailUsers[0].Email),
+	}
+	confirmEmailBytes, _ := json.Marshal(confirmEmailBody)
+	confirmReq := test.BuildRequest("POST", "/link/email/confirm", string(confirmEmailBytes), dexWalletUsers[0].AuthToken)
+	confirmResp, _ := s.app.Test(confirmReq)
+	_, err = io.ReadAll(confirmResp.Body)
+	s.Require().NoError(err)
+	s.Assert().Equal(200, confirmResp.StatusCode)
+
+	s.Require().NoError(eml.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Assert().True(eml.ConfirmedAt.Valid)
+	s.Assert().False(eml.ConfirmationCodeHash.Valid)
+	s.Assert().Zero(eml.ConfirmationAttempts)
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_LinkEmail_SendFails() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	s.emailService.SetFailing(true)
+	defer s.emailService.SetFailing(false)
+
+	linkEmailBodyBytes, _ := json.Marshal(AddEmailRequest{Address: dexEmailUsers[0].Email})
+	postReq := test.BuildRequest("POST", "/link/email", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	s.Require().Equal(200, postResp.StatusCode)
+
+	var linkResp LinkEmailRes
+	s.Require().NoError(json.NewDecoder(postResp.Body).Decode(&linkResp))
+	s.Assert().False(linkResp.CodeSent)
+
+	eml, err := models.Emails(models.EmailWhere.Address.EQ(dexEmailUsers[0].Email)).One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Assert().False(eml.CodeSentAt.Valid)
+
+	// The user doesn't have to wait out the cooldown.
+	s.emailService.SetFailing(false)
+
+	resendReq := test.BuildRequest("POST", "/link/email/resend", "", dexWalletUsers[0].AuthToken)
+	resendResp, _ := s.app.Test(resendReq)
+	s.Assert().Equal(200, resendResp.StatusCode)
+	s.Assert().NotEmpty(s.emailService.LastCode(dexEmailUsers[0].Email))
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_LinkEmailConfirm_TooManyAttempts() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	linkEmailBodyBytes, _ := json.Marshal(AddEmailRequest{Address: dexEmailUsers[0].Email})
+	postReq := test.BuildRequest("POST", "/link/email", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	s.Assert().Equal(200, postResp.StatusCode)
+
+	code := s.emailService.LastCode(dexEmailUsers[0].Email)
+	wrongCode := "000000"
+	if code == wrongCode {
+		wrongCode = "111111"
+	}
+	wrongEmailBytes, _ := json.Marshal(CompleteEmailValidation{Code: wrongCode})
+
+	for i := 1; i < defaultMaxCodeAttempts; i++ {
+		wrongReq := test.BuildRequest("POST", "/link/email/confirm", string(wrongEmailBytes), dexWalletUsers[0].AuthToken)
+		wrongResp, _ := s.app.Test(wrongReq)
+		s.Assert().Equal(400, wrongResp.StatusCode)
+	}
+
+	wrongReq := test.BuildRequest("POST", "/link/email/confirm", string(wrongEmailBytes), dexWalletUsers[0].AuthToken)
+	wrongResp, _ := s.app.Test(wrongReq)
+	s.Assert().Equal(429, wrongResp.StatusCode)
+
+	// Even the correct code is now rejected.
+	confirmEmailBytes, _ := json.Marshal(CompleteEmailValidation{Code: code})
+	confirmReq := test.BuildRequest("POST", "/link/email/confirm", string(confirmEmailBytes), dexWalletUsers[0].AuthToken)
+	confirmResp, _ := s.app.Test(confirmReq)
+	s.Assert().Equal(429, confirmResp.StatusCode)
+
+	eml, err := models.FindEmail(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+	s.Assert().False(eml.ConfirmedAt.Valid)
+	s.Assert().Equal(defaultMaxCodeAttempts, eml.ConfirmationAttempts)
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_ResendConfirmationEmail() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	linkEmailBodyBytes, _ := json.Marshal(AddEmailRequest{Address: dexEmailUsers[0].Email})
+	postReq := test.BuildRequest("POST", "/link/email", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	s.Assert().Equal(200, postResp.StatusCode)
+
+	firstCode := s.emailService.LastCode(dexEmailUsers[0].Email)
+
+	// Still inside the cooldown.
+	resendReq := test.BuildRequest("POST", "/link/email/resend", "", dexWalletUsers[0].AuthToken)
+	resendResp, _ := s.app.Test(resendReq)
+	s.Assert().Equal(429, resendResp.StatusCode)
+	s.Assert().NotEmpty(resendResp.Header.Get("Retry-After"))
+
+	eml, err := models.FindEmail(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+	eml.CodeSentAt = null.TimeFrom(time.Now().Add(-time.Hour))
+	_, err = eml.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.EmailColumns.CodeSentAt))
+	s.Require().NoError(err)
+
+	resendReq = test.BuildRequest("POST", "/link/email/resend", "", dexWalletUsers[0].AuthToken)
+	resendResp, _ = s.app.Test(resendReq)
+	s.Assert().Equal(200, resendResp.StatusCode)
+
+	s.Require().NoError(eml.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Assert().Equal(2, eml.SendWindowCount)
+
+	// A failed send doesn't start the cooldown.
+	eml.CodeSentAt = null.TimeFrom(time.Now().Add(-time.Hour))
+	_, err = eml.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.EmailColumns.CodeSentAt))
+	s.Require().NoError(err)
+
+	s.emailService.SetFailing(true)
+	resendReq = test.BuildRequest("POST", "/link/email/resend", "", dexWalletUsers[0].AuthToken)
+	resendResp, _ = s.app.Test(resendReq)
+	s.emailService.SetFailing(false)
+	s.Assert().Equal(500, resendResp.StatusCode)
+
+	s.Require().NoError(eml.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Assert().False(eml.CodeSentAt.Valid)
+
+	resendReq = test.BuildRequest("POST", "/link/email/resend", "", dexWalletUsers[0].AuthToken)
+	resendResp, _ = s.app.Test(resendReq)
+	s.Assert().Equal(200, resendResp.StatusCode)
+
+	s.Require().NoError(eml.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Assert().Equal(4, eml.SendWindowCount)
+
+	// Once the daily limit is used up the cooldown no longer matters.
+	eml.CodeSentAt = null.TimeFrom(time.Now().Add(-time.Hour))
+	eml.SendWindowCount = s.controller.dailySendLimit
+	_, err = eml.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.EmailColumns.CodeSentAt, models.EmailColumns.SendWindowCount))
+	s.Require().NoError(err)
+
+	resendReq = test.BuildRequest("POST", "/link/email/resend", "", dexWalletUsers[0].AuthToken)
+	resendResp, _ = s.app.Test(resendReq)
+	s.Assert().Equal(429, resendResp.StatusCode)
+
+	// Only the latest code is accepted.
+	secondCode := s.emailService.LastCode(dexEmailUsers[0].Email)
+	if firstCode != secondCode {
+		oldCodeBytes, _ := json.Marshal(CompleteEmailValidation{Code: firstCode})
+		oldCodeReq := test.BuildRequest("POST", "/link/email/confirm", string(oldCodeBytes), dexWalletUsers[0].AuthToken)
+		oldCodeResp, _ := s.app.Test(oldCodeReq)
+		s.Assert().Equal(400, oldCodeResp.StatusCode)
+	}
+
+	confirmEmailBytes, _ := json.Marshal(CompleteEmailValidation{Code: secondCode})
+	confirmReq := test.BuildRequest("POST", "/link/email/confirm", string(confirmEmailBytes), dexWalletUsers[0].AuthToken)
+	confirmResp, _ := s.app.Test(confirmReq)
+	s.Assert().Equal(200, confirmResp.StatusCode)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_VerifyEmailLink() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	linkEmailBodyBytes, _ := json.Marshal(AddEmailRequest{Address: dexEmailUsers[0].Email})
+	postReq := test.BuildRequest("POST", "/link/email", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	s.Assert().Equal(200, postResp.StatusCode)
+
+	link, err := url.Parse(s.emailService.LastLink(dexEmailUsers[0].Email))
+	s.Require().NoError(err)
+	s.Require().Equal("/v1/account/email/verify", link.Path)
+	token := link.Query().Get("token")
+
+	badReq := test.BuildRequest("GET", "/email/verify?token="+url.QueryEscape(token+"x"), "", "")
+	badResp, _ := s.app.Test(badReq)
+	s.Assert().Equal(400, badResp.StatusCode)
+
+	// Opening the link only shows the confirm page.
+	pageReq := test.BuildRequest("GET", "/email/verify?token="+url.QueryEscape(token), "", "")
+	pageResp, _ := s.app.Test(pageReq)
+	s.Assert().Equal(200, pageResp.StatusCode)
+	s.Assert().Contains(pageResp.Header.Get("Content-Type"), "text/html")
+
+	eml, err := models.FindEmail(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+	s.Assert().False(eml.ConfirmedAt.Valid)
+
+	// No login is needed to submit the page.
+	verifyReq := buildFormRequest("/email/verify", url.Values{"token": {token}})
+	verifyResp, _ := s.app.Test(verifyReq)
+	s.Assert().Equal(200, verifyResp.StatusCode)
+	s.Assert().Contains(verifyResp.Header.Get("Content-Type"), "text/html")
+
+	s.Require().NoError(eml.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Assert().True(eml.ConfirmedAt.Valid)
+	s.Assert().False(eml.LinkTokenID.Valid)
+	s.Assert().False(eml.ConfirmationCodeHash.Valid)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_VerifyEmailLink_Replaced() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	linkEmailBodyBytes, _ := json.Marshal(AddEmailRequest{Address: dexEmailUsers[0].Email})
+	postReq := test.BuildRequest("POST", "/link/email", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	s.Assert().Equal(200, postResp.StatusCode)
+
+	oldLink, err := url.Parse(s.emailService.LastLink(dexEmailUsers[0].Email))
+	s.Require().NoError(err)
+
+	eml, err := models.FindEmail(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+	eml.CodeSentAt = null.TimeFrom(time.Now().Add(-time.Hour))
+	_, err = eml.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.EmailColumns.CodeSentAt))
+	s.Require().NoError(err)
+
+	resendReq := test.BuildRequest("POST", "/link/email/resend", "", dexWalletUsers[0].AuthToken)
+	resendResp, _ := s.app.Test(resendReq)
+	s.Assert().Equal(200, resendResp.StatusCode)
+
+	verifyBody, _ := json.Marshal(VerifyEmailLinkRequest{Token: oldLink.Query().Get("token")})
+	verifyReq := test.BuildRequest("POST", "/email/verify", string(verifyBody), "")
+	verifyResp, _ := s.app.Test(verifyReq)
+	s.Assert().Equal(400, verifyResp.StatusCode)
+
+	s.Require().NoError(eml.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Assert().False(eml.ConfirmedAt.Valid)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_VerifyEmailLink_DeletedAccount() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	linkEmailBodyBytes, _ := json.Marshal(AddEmailRequest{Address: dexEmailUsers[0].Email})
+	postReq := test.BuildRequest("POST", "/link/email", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	s.Assert().Equal(200, postResp.StatusCode)
+
+	link, err := url.Parse(s.emailService.LastLink(dexEmailUsers[0].Email))
+	s.Require().NoError(err)
+
+	eml, err := models.FindEmail(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+
+	acct, err := models.FindAccount(s.ctx, s.pdb.DBS().Reader, eml.AccountID)
+	s.Require().NoError(err)
+	acct.DeletedAt = null.TimeFrom(time.Now())
+	acct.PurgeAt = null.TimeFrom(time.Now().Add(24 * time.Hour))
+	_, err = acct.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.AccountColumns.DeletedAt, models.AccountColumns.PurgeAt))
+	s.Require().NoError(err)
+
+	verifyBody, _ := json.Marshal(VerifyEmailLinkRequest{Token: link.Query().Get("token")})
+	verifyReq := test.BuildRequest("POST", "/email/verify", string(verifyBody), "")
+	verifyResp, _ := s.app.Test(verifyReq)
+	s.Assert().Equal(410, verifyResp.StatusCode)
+
+	s.Require().NoError(eml.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Assert().False(eml.ConfirmedAt.Valid)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func buildFormRequest(target string, form url.Values) *http.Request {
+	req, _ := http.NewRequest("POST", target, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_ChangeEmail() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	linkEmailBodyBytes, _ := json.Marshal(TokenBody{Token: dexEmailUsers[0].AuthToken})
+	linkReq := test.BuildRequest("POST", "/link/email/token", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	linkResp, _ := s.app.Test(linkReq)
+	s.Assert().Equal(200, linkResp.StatusCode)
+
+	changeBodyBytes, _ := json.Marshal(AddEmailRequest{Address: dexEmailUsers[1].Email})
+	changeReq := test.BuildRequest("POST", "/link/email/change", string(changeBodyBytes), dexWalletUsers[0].AuthToken)
+	changeResp, _ := s.app.Test(changeReq)
+	s.Assert().Equal(200, changeResp.StatusCode)
+
+	// The old address stays linked until the change is confirmed.
+	oldEml, err := models.FindEmail(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+	s.Assert().True(oldEml.ConfirmedAt.Valid)
+	s.Assert().True(oldEml.CodeSentAt.Valid)
+
+	// A failed send doesn't start the cooldown.
+	oldEml.CodeSentAt = null.TimeFrom(time.Now().Add(-time.Hour))
+	_, err = oldEml.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.EmailColumns.CodeSentAt))
+	s.Require().NoError(err)
+
+	s.emailService.SetFailing(true)
+	changeReq = test.BuildRequest("POST", "/link/email/change", string(changeBodyBytes), dexWalletUsers[0].AuthToken)
+	changeResp, _ = s.app.Test(changeReq)
+	s.emailService.SetFailing(false)
+	s.Assert().Equal(500, changeResp.StatusCode)
+
+	s.Require().NoError(oldEml.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Assert().False(oldEml.CodeSentAt.Valid)
+
+	changeReq = test.BuildRequest("POST", "/link/email/change", string(changeBodyBytes), dexWalletUsers[0].AuthToken)
+	changeResp, _ = s.app.Test(changeReq)
+	s.Assert().Equal(200, changeResp.StatusCode)
+
+	confirmBodyBytes, _ := json.Marshal(CompleteEmailValidation{Code: s.emailService.LastCode(dexEmailUsers[1].Email)})
+	confirmReq := test.BuildRequest("POST", "/link/email/change/confirm", string(confirmBodyBytes), dexWalletUsers[0].AuthToken)
+	confirmResp, _ := s.app.Test(confirmReq)
+	s.Assert().Equal(200, confirmResp.StatusCode)
+
+	exists, err := models.EmailExists(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+	s.Assert().False(exists)
+
+	newEml, err := models.FindEmail(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[1].Email)
+	s.Require().NoError(err)
+	s.Assert().Equal(oldEml.AccountID, newEml.AccountID)
+	s.Assert().True(newEml.ConfirmedAt.Valid)
+
+	pending, err := models.PendingEmailChanges().Count(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Assert().Zero(pending)
+
+	s.dispatchOutbox()
+	s.Assert().Equal(dexEmailUsers[1].Email, s.emailService.ChangeNotice(dexEmailUsers[0].Email))
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_ChangeEmailToken() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	linkEmailBodyBytes, _ := json.Marshal(TokenBody{Token: dexEmailUsers[0].AuthToken})
+	linkReq := test.BuildRequest("POST", "/link/email/token", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	linkResp, _ := s.app.Test(linkReq)
+	s.Assert().Equal(200, linkResp.StatusCode)
+
+	changeBodyBytes, _ := json.Marshal(TokenBody{Token: dexEmailUsers[1].AuthToken})
+	changeReq := test.BuildRequest("POST", "/link/email/change/token", string(changeBodyBytes), dexWalletUsers[0].AuthToken)
+	changeResp, _ := s.app.Test(changeReq)
+	s.Assert().Equal(200, changeResp.StatusCode)
+
+	getReq := test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
+	getResp, _ := s.app.Test(getReq)
+	s.Assert().Equal(200, getResp.StatusCode)
+
+	var acct UserResponse
+	s.Require().NoError(json.NewDecoder(getResp.Body).Decode(&acct))
+	s.Require().NotNil(acct.Email)
+	s.Assert().Equal(dexEmailUsers[1].Email, acct.Email.Address)
+
+	s.dispatchOutbox()
+	s.Assert().Equal(dexEmailUsers[1].Email, s.emailService.ChangeNotice(dexEmailUsers[0].Email))
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_ReplaceUnconfirmedEmail() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	linkEmailBodyBytes, _ := json.Marshal(AddEmailRequest{Address: dexEmailUsers[0].Email})
+	postReq := test.BuildRequest("POST", "/link/email", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	s.Assert().Equal(200, postResp.StatusCode)
+
+	// Replacing counts against the same cooldown as resending.
+	eml, err := models.FindEmail(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+	eml.CodeSentAt = null.TimeFrom(time.Now().Add(-time.Hour))
+	_, err = eml.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.EmailColumns.CodeSentAt))
+	s.Require().NoError(err)
+
+	linkEmailBodyBytes, _ = json.Marshal(AddEmailRequest{Address: dexEmailUsers[1].Email})
+	postReq = test.BuildRequest("POST", "/link/email", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ = s.app.Test(postReq)
+	s.Assert().Equal(200, postResp.StatusCode)
+
+	exists, err := models.EmailExists(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+	s.Assert().False(exists)
+
+	newEml, err := models.FindEmail(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[1].Email)
+	s.Require().NoError(err)
+	s.Assert().False(newEml.ConfirmedAt.Valid)
+	s.Assert().Equal(2, newEml.SendWindowCount)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_WalletFirstAccount_UnlinkEmail() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	linkEmailBodyBytes, _ := json.Marshal(TokenBody{Token: dexEmailUsers[0].AuthToken})
+	linkReq := test.BuildRequest("POST", "/link/email/token", string(linkEmailBodyBytes), dexWalletUsers[0].AuthToken)
+	linkResp, _ := s.app.Test(linkReq)
+	s.Assert().Equal(200, linkResp.StatusCode)
+
+	unlinkReq := test.BuildRequest("DELETE", "/link/email", "", dexWalletUsers[0].AuthToken)
+	unlinkResp, _ := s.app.Test(unlinkReq)
+	s.Assert().Equal(200, unlinkResp.StatusCode)
+
+	exists, err := models.EmailExists(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+	s.Assert().False(exists)
+
+	unlinkReq = test.BuildRequest("DELETE", "/link/email", "", dexWalletUsers[0].AuthToken)
+	unlinkResp, _ = s.app.Test(unlinkReq)
+	s.Assert().Equal(400, unlinkResp.StatusCode)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_EmailFirstAccount_UnlinkEmail_NoWallet() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexEmailUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(201, createAcctResp.StatusCode)
+
+	unlinkReq := test.BuildRequest("DELETE", "/link/email", "", dexEmailUsers[0].AuthToken)
+	unlinkResp, _ := s.app.Test(unlinkReq)
+	s.Assert().Equal(400, unlinkResp.StatusCode)
+
+	exists, err := models.EmailExists(s.ctx, s.pdb.DBS().Reader, dexEmailUsers[0].Email)
+	s.Require().NoError(err)
+	s.Assert().True(exists)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_SubmitReferralCode() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Assert().Equal(200, createAcctResp.StatusCode)
+
+	refAcct, err := test.NewAccount(s.pdb.DBS().Writer) // Create Referrer Account
+	s.Require().NoError(err)
+
+	referralCodeBody := SubmitReferralCodeRequest{
+		Code: refAcct.ReferralCode,
+	}
+	referralCodeBodyBytes, _ := json.Marshal(referralCodeBody)
+
+	// Existing owners can't be referred
+	test.IdentityServiceResponse = true
+
+	ownerReq := test.BuildRequest("POST", "/referral/submit", string(referralCodeBodyBytes), dexWalletUsers[0].AuthToken)
+	ownerResp, _ := s.app.Test(ownerReq)
+	s.Assert().Equal(400, ownerResp.StatusCode)
+
+	acct, err := models.Accounts(models.AccountWhere.ReferralCode.NEQ(refAcct.ReferralCode)).One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Assert().False(acct.ReferredBy.Valid)
+
+	// Set identity svc to be consistent with referral eligibility
+	test.IdentityServiceResponse = false
+
+	// Submit referral
+	postReq := test.BuildRequest("POST", "/referral/submit", string(referralCodeBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	_, err = io.ReadAll(postResp.Body)
+	s.Require().NoError(err)
+	s.Assert().Equal(200, postResp.StatusCode)
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_SubmitReferralCode_Cycle() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	acct, err := models.Accounts().One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+
+	// The user referred middle, who referred bottom.
+	middle, err := test.NewAccount(s.pdb.DBS().Writer)
+	s.Require().NoError(err)
+	middle.ReferredBy = null.StringFrom(acct.ID)
+	middle.ReferredAt = null.TimeFrom(time.Now())
+	_, err = middle.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	bottom, err := test.NewAccount(s.pdb.DBS().Writer)
+	s.Require().NoError(err)
+	bottom.ReferredBy = null.StringFrom(middle.ID)
+	bottom.ReferredAt = null.TimeFrom(time.Now())
+	_, err = bottom.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	test.IdentityServiceResponse = false
+
+	referralCodeBodyBytes, _ := json.Marshal(SubmitReferralCodeRequest{Code: bottom.ReferralCode})
+	postReq := test.BuildRequest("POST", "/referral/submit", string(referralCodeBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	s.Assert().Equal(400, postResp.StatusCode)
+
+	s.Require().NoError(acct.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Assert().False(acct.ReferredBy.Valid)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_SubmitReferralCode_ConcurrentCycle() {
+	users := dexWalletUsers[:2]
+	codes := make([]string, len(users))
+	for i, u := range users {
+		createAcctReq := test.BuildRequest("POST", "/", "", u.AuthToken)
+		createAcctResp, _ := s.app.Test(createAcctReq)
+		s.Require().Equal(201, createAcctResp.StatusCode)
+
+		var userResp UserResponse
+		s.Require().NoError(json.NewDecoder(createAcctResp.Body).Decode(&userResp))
+		s.Require().NotNil(userResp.Referral)
+		codes[i] = userResp.Referral.Code
+	}
+
+	test.IdentityServiceResponse = false
+
+	// Each user enters the other's code at the same time. Only one can win.
+	var wg sync.WaitGroup
+	statuses := make([]int, len(users))
+	for i, u := range users {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			referralCodeBodyBytes, _ := json.Marshal(SubmitReferralCodeRequest{Code: codes[1-i]})
+			postReq := test.BuildRequest("POST", "/referral/submit", string(referralCodeBodyBytes), u.AuthToken)
+			postResp, err := s.app.Test(postReq)
+			if err == nil {
+				statuses[i] = postResp.StatusCode
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.Assert().ElementsMatch([]int{200, 400}, statuses)
+
+	referred, err := models.Accounts(models.AccountWhere.ReferredBy.IsNotNull()).Count(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Assert().EqualValues(1, referred)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_SubmitReferralCode_Late() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	// Backdate the account and its first wallet past the window.
+	longAgo := time.Now().Add(-365 * 24 * time.Hour)
+	_, err := models.Accounts().UpdateAll(s.ctx, s.pdb.DBS().Writer, models.M{
+		models.AccountColumns.CreatedAt:           longAgo,
+		models.AccountColumns.FirstWalletLinkedAt: longAgo,
+	})
+	s.Require().NoError(err)
+
+	getReq := test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
+	getResp, _ := s.app.Test(getReq)
+	s.Require().Equal(200, getResp.StatusCode)
+
+	var userResp UserResponse
+	s.Require().NoError(json.NewDecoder(getResp.Body).Decode(&userResp))
+	s.Require().NotNil(userResp.Referral)
+	s.Require().NotNil(userResp.Referral.Deadline)
+	s.Assert().True(userResp.Referral.Deadline.Before(time.Now()))
+
+	refAcct, err := test.NewAccount(s.pdb.DBS().Writer)
+	s.Require().NoError(err)
+
+	test.IdentityServiceResponse = false
+
+	referralCodeBodyBytes, _ := json.Marshal(SubmitReferralCodeRequest{Code: refAcct.ReferralCode})
+	postReq := test.BuildRequest("POST", "/referral/submit", string(referralCodeBodyBytes), dexWalletUsers[0].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	s.Assert().Equal(400, postResp.StatusCode)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_SubmitReferralCode_LateAfterReplacingWallet() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	longAgo := time.Now().Add(-365 * 24 * time.Hour)
+	_, err := models.Accounts().UpdateAll(s.ctx, s.pdb.DBS().Writer, models.M{
+		models.AccountColumns.CreatedAt:           longAgo,
+		models.AccountColumns.FirstWalletLinkedAt: longAgo,
+	})
+	s.Require().NoError(err)
+
+	// A fresh wallet doesn't reopen the window.
+	replaceBodyBytes, _ := json.Marshal(ReplaceWalletRequest{OldToken: dexWalletUsers[0].AuthToken, NewToken: dexWalletUsers[1].AuthToken})
+	replaceReq := test.BuildRequest("POST", "/link/wallet/replace", string(replaceBodyBytes), dexWalletUsers[0].AuthToken)
+	replaceResp, _ := s.app.Test(replaceReq)
+	s.Require().Equal(200, replaceResp.StatusCode)
+
+	refAcct, err := test.NewAccount(s.pdb.DBS().Writer)
+	s.Require().NoError(err)
+
+	test.IdentityServiceResponse = false
+
+	referralCodeBodyBytes, _ := json.Marshal(SubmitReferralCodeRequest{Code: refAcct.ReferralCode})
+	postReq := test.BuildRequest("POST", "/referral/submit", string(referralCodeBodyBytes), dexWalletUsers[1].AuthToken)
+	postResp, _ := s.app.Test(postReq)
+	s.Assert().Equal(400, postResp.StatusCode)
+
+	var errResp ErrorRes
+	s.Require().NoError(json.NewDecoder(postResp.Body).Decode(&errResp))
+	s.Assert().Contains(errResp.Message, "had to be entered by")
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_SubmitReferralCode_Campaign() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	campaign := models.ReferralCampaign{
+		ID:      ksuid.New().String(),
+		Name:    "Spring road trip",
+		Code:    "SPRING-ROADTRIP",
+		MaxUses: null.IntFrom(1),
+	}
+	s.Require().NoError(campaign.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	expired := models.ReferralCampaign{
+		ID:     ksuid.New().String(),
+		Name:   "Last year",
+		Code:   "LAST-YEAR",
+		EndsAt: null.TimeFrom(time.Now().Add(-time.Hour)),
+	}
+	s.Require().NoError(expired.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	test.IdentityServiceResponse = false
+
+	submit := func(code string, token string) int {
+		bodyBytes, _ := json.Marshal(SubmitReferralCodeRequest{Code: code})
+		req := test.BuildRequest("POST", "/referral/submit", string(bodyBytes), token)
+		resp, err := s.app.Test(req)
+		s.Require().NoError(err)
+		return resp.StatusCode
+	}
+
+	s.Assert().Equal(400, submit("last-year", dexWalletUsers[0].AuthToken))
+	s.Assert().Equal(400, submit("NO-SUCH-CAMPAIGN", dexWalletUsers[0].AuthToken))
+
+	// Codes are case-insensitive.
+	s.Require().Equal(200, submit("spring-roadtrip", dexWalletUsers[0].AuthToken))
+
+	getReq := test.BuildRequest("GET", "/", "", dexWalletUsers[0].AuthToken)
+	getResp, _ := s.app.Test(getReq)
+	s.Require().Equal(200, getResp.StatusCode)
+
+	var userResp UserResponse
+	s.Require().NoError(json.NewDecoder(getResp.Body).Decode(&userResp))
+	s.Require().NotNil(userResp.Referral)
+	s.Require().NotNil(userResp.Referral.Campaign)
+	s.Assert().Equal("SPRING-ROADTRIP", *userResp.Referral.Campaign)
+	s.Assert().Nil(userResp.Referral.ReferredBy)
+	s.Assert().NotNil(userResp.Referral.ReferredAt)
+
+	s.Require().NoError(campaign.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Assert().Equal(1, campaign.Uses)
+
+	// The campaign is now used up.
+	createAcctReq = test.BuildRequest("POST", "/", "", dexWalletUsers[1].AuthToken)
+	createAcctResp, _ = s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	s.Assert().Equal(400, submit("SPRING-ROADTRIP", dexWalletUsers[1].AuthToken))
+
+	// Ending a campaign stops its code right away.
+	campaignServer := &rpc.CampaignServer{DBS: s.pdb}
+	leaked, err := campaignServer.CreateCampaign(s.ctx, &pb.CreateCampaignRequest{Name: "Leaked", Code: "LEAKED-CODE"})
+	s.Require().NoError(err)
+	_, err = campaignServer.EndCampaign(s.ctx, &pb.EndCampaignRequest{Id: leaked.Id})
+	s.Require().NoError(err)
+
+	s.Assert().Equal(400, submit("LEAKED-CODE", dexWalletUsers[1].AuthToken))
+
+	// Raising the limit lets the code be used again.
+	_, err = campaignServer.UpdateCampaign(s.ctx, &pb.UpdateCampaignRequest{
+		Id:         campaign.ID,
+		MaxUses:    2,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"max_uses"}},
+	})
+	s.Require().NoError(err)
+
+	s.Assert().Equal(200, submit("SPRING-ROADTRIP", dexWalletUsers[1].AuthToken))
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_RegenerateReferralCode() {
+	// Create Account
+	createAcctReq := test.BuildRequest("POST", "/", "", dexWalletUsers[0].AuthToken)
+	createAcctResp, _ := s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	referrer, err := models.Accounts().One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	originalCode := referrer.ReferralCode
+
+	regenerate := func(body string) RegenerateReferralCodeResponse {
+		req := test.BuildRequest("POST", "/referral/regenerate", body, dexWalletUsers[0].AuthToken)
+		resp, err := s.app.Test(req)
+		s.Require().NoError(err)
+		s.Require().Equal(200, resp.StatusCode)
+
+		var out RegenerateReferralCodeResponse
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&out))
+		return out
+	}
+
+	// The original code keeps working for a while.
+	first := regenerate("")
+	s.Assert().Equal(originalCode, first.RetiredCode)
+	s.Assert().NotEqual(originalCode, first.Code)
+	s.Assert().True(first.RetiredCodeExpiresAt.After(time.Now()))
+
+	// Another replacement has to wait out the cooldown.
+	tooSoonReq := test.BuildRequest("POST", "/referral/regenerate", "", dexWalletUsers[0].AuthToken)
+	tooSoonResp, _ := s.app.Test(tooSoonReq)
+	s.Require().Equal(429, tooSoonResp.StatusCode)
+	s.Assert().NotEmpty(tooSoonResp.Header.Get("Retry-After"))
+
+	_, err = models.RetiredReferralCodes().UpdateAll(s.ctx, s.pdb.DBS().Writer, models.M{models.RetiredReferralCodeColumns.RetiredAt: time.Now().Add(-25 * time.Hour)})
+	s.Require().NoError(err)
+
+	// The second one stops working right away.
+	second := regenerate(`{"disableImmediately": true}`)
+	s.Assert().Equal(first.Code, second.RetiredCode)
+
+	s.Require().NoError(referrer.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Assert().Equal(second.Code, referrer.ReferralCode)
+
+	createAcctReq = test.BuildRequest("POST", "/", "", dexWalletUsers[1].AuthToken)
+	createAcctResp, _ = s.app.Test(createAcctReq)
+	s.Require().Equal(201, createAcctResp.StatusCode)
+
+	test.IdentityServiceResponse = false
+
+	submit := func(code string) int {
+		bodyBytes, _ := json.Marshal(SubmitReferralCodeRequest{Code: code})
+		req := test.BuildRequest("POST", "/referral/submit", string(bodyBytes), dexWalletUsers[1].AuthToken)
+		resp, err := s.app.Test(req)
+		s.Require().NoError(err)
+		return resp.StatusCode
+	}
+
+	s.Assert().Equal(400, submit(first.Code))
+	s.Require().Equal(200, submit(originalCode))
+
+	referee, err := models.Accounts(models.AccountWhere.ID.NEQ(referrer.ID)).One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Assert().Equal(referrer.ID, referee.ReferredBy.String)
+
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func (s *AccountControllerTestSuite) Test_PurgeKeepsReferralCodesReserved() {
+	acct, err := test.NewAccount(s.pdb.DBS().Writer)
+	s.Require().NoError(err)
+
+	retired := models.RetiredReferralCode{
+		Code:      "OLD123",
+		AccountID: null.StringFrom(acct.ID),
+		RetiredAt: time.Now().Add(-time.Hour),
+		ExpiresAt: time.Now().Add(-time.Hour),
+	}
+	s.Require().NoError(retired.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	acct.DeletedAt = null.TimeFrom(time.Now().Add(-48 * time.Hour))
+	acct.PurgeAt = null.TimeFrom(time.Now().Add(-time.Hour))
+	_, err = acct.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	n, err := PurgeDeletedAccounts(s.ctx, s.pdb, test.Logger())
+	s.Require().NoError(err)
+	s.Require().Equal(1, n)
+
+	// Both the old code and the one the account had at the end stay taken.
+	for _, code := range []string{retired.Code, acct.ReferralCode} {
+		row, err := models.FindRetiredReferralCode(s.ctx, s.pdb.DBS().Reader, code)
+		s.Require().NoError(err)
+		s.Assert().False(row.AccountID.Valid)
+	}
 
-	// Submit referral
-	postReq := test.BuildRequest("POST", "/referral/submit", string(referralCodeBodyBytes), dexWalletUsers[0].AuthToken)
-	postResp, _ := s.app.Test(postReq)
-	_, err = io.ReadAll(postResp.Body)
-	s.Require().NoError(err)
-	s.Assert().Equal(200, postResp.StatusCode)
 	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
 }
 
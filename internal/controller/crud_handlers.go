@@ -3,11 +3,11 @@ package controller
 import (
 	"database/sql"
 	"fmt"
-	"regexp"
-	"slices"
 	"time"
 
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
 	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
@@ -53,7 +53,7 @@ func (d *Controller) CreateAccount(c *fiber.Ctx) error {
 		d.log.Info().Str("account", acct.ID).Msgf("Created account with wallet %s.", *userAccount.EthereumAddress)
 	}
 
-	formattedAcct, err := d.formatUserAcctResponse(acct, acct.R.Wallet, acct.R.Email)
+	formattedAcct, err := d.formatUserAcctResponse(c.Context(), d.dbs.DBS().Reader, acct)
 	if err != nil {
 		return err
 	}
@@ -62,9 +62,11 @@ func (d *Controller) CreateAccount(c *fiber.Ctx) error {
 }
 
 // GetUserAccount godoc
-// @Summary Get attributes for the authenticated user.
+// @Summary Get attributes for the authenticated user. The ETag response header can be sent back in If-Match to make a later write conditional.
 // @Produce json
+// @Param If-None-Match header string false "Entity tags from earlier responses; if one is still current, the response is 304 with no body"
 // @Success 200 {object} controller.UserResponse
+// @Success 304 "Returned if the account hasn't changed."
 // @Failure 403 {object} controller.ErrorRes
 // @Security BearerAuth
 // @Router /v1/account [get]
@@ -80,24 +82,31 @@ func (d *Controller) GetUserAccount(c *fiber.Ctx) error {
 		return err
 	}
 
-	formattedAcct, err := d.formatUserAcctResponse(acct, acct.R.Wallet, acct.R.Email)
+	formattedAcct, err := d.formatUserAcctResponse(c.Context(), d.dbs.DBS().Reader, acct)
 	if err != nil {
 		return err
 	}
 
+	etag := accountETag(formattedAcct)
+	c.Set(fiber.HeaderETag, etag)
+
+	if ifNoneMatch := c.Get(fiber.HeaderIfNoneMatch); ifNoneMatch != "" && etagMatches(ifNoneMatch, etag, true) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	return c.JSON(formattedAcct)
 }
 
-var countryCodePattern = regexp.MustCompile("^[A-Z]{3}$")
-
 // UpdateUser godoc
 // @Summary Modify attributes for the authenticated user
 // @Accept json
 // @Produce json
 // @Param userUpdateRequest body controller.UserUpdateRequest true "New field values"
+// @Param If-Match header string false "Entity tag from an earlier response; the update fails unless it's still current"
 // @Success 200 {object} controller.UserResponse
 // @Success 400 {object} controller.ErrorRes
 // @Failure 403 {object} controller.ErrorRes
+// @Failure 412 {object} controller.ErrorRes "Returned if the account has changed since the If-Match tag was issued."
 // @Router /v1/account [put]
 func (d *Controller) UpdateUser(c *fiber.Ctx) error {
 	userAccount, err := getUserAccountClaims(c)
@@ -106,7 +115,13 @@ func (d *Controller) UpdateUser(c *fiber.Ctx) error {
 		return err
 	}
 
-	acct, err := d.getUserAccount(c.Context(), userAccount, d.dbs.DBS().Reader)
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccountForUpdate(c.Context(), userAccount, tx)
 	if err != nil {
 		d.log.Err(err).Msg("failed to get user account")
 		return err
@@ -115,45 +130,68 @@ func (d *Controller) UpdateUser(c *fiber.Ctx) error {
 	logger := d.log.With().Str("account", acct.ID).Logger()
 	c.Locals("logger", &logger)
 
+	if err := d.checkIfMatch(c, tx, acct); err != nil {
+		return err
+	}
+
 	var body UserUpdateRequest
 	if err := c.BodyParser(&body); err != nil {
 		return err
 	}
 
-	if body.CountryCode != "" {
-		if !countryCodePattern.MatchString(body.CountryCode) {
-			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Unrecognized country code %q. Country codes consist of three capital letters.", body.CountryCode))
-		}
+	values := map[string]string{
+		"countryCode": body.CountryCode,
+		"displayName": body.DisplayName,
+		"language":    body.Language,
+		"timeZone":    body.TimeZone,
+		"units":       body.Units,
+	}
 
-		if !slices.Contains(d.countryCodes, body.CountryCode) {
-			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Unrecognized country code %q.", body.CountryCode))
+	updates := make(map[string]null.String)
+	for _, f := range d.editableFields() {
+		if values[f.name] == "" {
+			continue
 		}
 
-		if !acct.CountryCode.Valid || acct.CountryCode.String != body.CountryCode {
-			acct.CountryCode = null.StringFrom(body.CountryCode)
+		value, err := f.validate(values[f.name])
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		updates[f.name] = null.StringFrom(value)
+	}
 
-			if _, err := acct.Update(c.Context(), d.dbs.DBS().Reader, boil.Whitelist(models.AccountColumns.CountryCode, models.AccountColumns.UpdatedAt)); err != nil {
-				return err
-			}
+	changed, err := d.saveAccountFields(c.Context(), tx, acct, updates)
+	if err != nil {
+		return err
+	}
 
-			logger.Info().Str("account", acct.ID).Msgf("Updated country to %s.", body.CountryCode)
+	if len(changed) != 0 {
+		if err := tx.Commit(); err != nil {
+			return err
 		}
+
+		logger.Info().Strs("fields", changed).Msg("Updated account.")
 	}
 
-	userResp, err := d.formatUserAcctResponse(acct, acct.R.Wallet, acct.R.Email)
+	userResp, err := d.formatUserAcctResponse(c.Context(), d.dbs.DBS().Reader, acct)
 	if err != nil {
 		return err
 	}
 
+	c.Set(fiber.HeaderETag, accountETag(userResp))
 	return c.JSON(userResp)
 }
 
 // DeleteUser godoc
-// @Summary Delete the authenticated user. Fails if the user has any devices.
+// @Summary Schedule the authenticated user's account for deletion. Until the grace period ends, the account is locked and can be restored. Fails if the user has any devices.
+// @Param If-Match header string false "Entity tag from an earlier response; the deletion fails unless it's still current"
 // @Success 200 {object} controller.StandardRes
 // @Failure 400 {object} controller.ErrorRes
 // @Failure 403 {object} controller.ErrorRes
-// @Failure 409 {object} controller.ErrorRes "Returned if the user still has devices."
+// @Failure 409 {object} controller.ErrorRes "Returned if the user still has devices, or if a wallet was linked or unlinked during the check."
+// @Failure 410 {object} controller.ErrorRes "Returned if the account is already scheduled for deletion."
+// @Failure 412 {object} controller.ErrorRes "Returned if the account has changed since the If-Match tag was issued."
+// @Failure 503 {object} controller.ErrorRes "Returned if devices-api can't be reached to check for devices."
 // @Router /v1/account [delete]
 func (d *Controller) DeleteUser(c *fiber.Ctx) error {
 	userAccount, err := getUserAccountClaims(c)
@@ -162,18 +200,66 @@ func (d *Controller) DeleteUser(c *fiber.Ctx) error {
 		return err
 	}
 
+	// Ask devices-api before taking the account lock, so that nothing is held
+	// while we wait on it.
+	acct, err := d.getUserAccount(c.Context(), userAccount, d.dbs.DBS().Reader)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	wallets := make([]common.Address, len(acct.R.Wallets))
+	for i, w := range acct.R.Wallets {
+		wallets[i] = common.BytesToAddress(w.Address)
+	}
+
+	if d.devicesClient == nil {
+		logger.Error().Msg("Refused deletion because no devices-api is configured.")
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Couldn't check the account for devices. Try again later.")
+	}
+
+	deviceCount, err := d.devicesClient.CountUserDevices(c.Context(), userAccount.Subject, wallets)
+	if err != nil {
+		logger.Err(err).Msg("Failed to count devices.")
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Couldn't check the account for devices. Try again later.")
+	}
+
+	if deviceCount != 0 {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Account still has %d devices. Delete them before deleting the account.", deviceCount))
+	}
+
+	checkedWallets := acct.R.Wallets
+
 	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback() //nolint
 
-	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	acct, err = d.getUserAccountForUpdate(c.Context(), userAccount, tx)
 	if err != nil {
 		return err
 	}
 
-	if _, err := acct.Delete(c.Context(), tx); err != nil {
+	if err := d.checkIfMatch(c, tx, acct); err != nil {
+		return err
+	}
+
+	if !sameWallets(checkedWallets, acct.R.Wallets) {
+		return fiber.NewError(fiber.StatusConflict, "Account's wallets changed while it was being checked for devices. Try again.")
+	}
+
+	now := time.Now()
+	acct.DeletedAt = null.TimeFrom(now)
+	acct.PurgeAt = null.TimeFrom(now.Add(d.deletionGrace))
+
+	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.DeletedAt, models.AccountColumns.PurgeAt, models.AccountColumns.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := d.emitEvent(c.Context(), tx, events.AccountDeletionScheduledType, acct.ID, events.AccountDeletionScheduled{PurgeAt: acct.PurgeAt.Time}); err != nil {
 		return err
 	}
 
@@ -181,44 +267,60 @@ func (d *Controller) DeleteUser(c *fiber.Ctx) error {
 		return err
 	}
 
-	d.log.Info().Str("userId", acct.ID).Msg("Deleted user.")
+	logger.Info().Msgf("Scheduled account for deletion at %s.", acct.PurgeAt.Time.Format(time.RFC3339))
 	return c.JSON(StandardRes{
-		Message: fmt.Sprintf("Deleted account %s.", acct.ID),
+		Message: fmt.Sprintf("Account %s will be deleted at %s. Until then it can be restored.", acct.ID, acct.PurgeAt.Time.Format(time.RFC3339)),
 	})
 }
 
-// AcceptTOS godoc
-// @Summary Agree to the current terms of service
+// RestoreAccount godoc
+// @Summary Cancel the pending deletion of the authenticated user's account.
 // @Success 200 {object} controller.StandardRes
-// @Failure 400 {object} controller.ErrorRes
-// @Router /v1/account/accept-tos [post]
-func (d *Controller) AcceptTOS(c *fiber.Ctx) error {
+// @Failure 400 {object} controller.ErrorRes "Returned if the account is not scheduled for deletion."
+// @Failure 403 {object} controller.ErrorRes
+// @Router /v1/account/restore [post]
+func (d *Controller) RestoreAccount(c *fiber.Ctx) error {
 	userAccount, err := getUserAccountClaims(c)
 	if err != nil {
 		d.log.Err(err).Msg("failed to parse user")
 		return err
 	}
 
-	acct, err := d.getUserAccount(c.Context(), userAccount, d.dbs.DBS().Reader)
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.lookupUserAccount(c.Context(), userAccount, tx)
 	if err != nil {
 		return err
 	}
 
-	if acct.AcceptedTosAt.Valid {
-		return c.JSON(StandardRes{
-			Message: fmt.Sprintf("Already accepted the terms of service at %s.", acct.AcceptedTosAt.Time),
-		})
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	if !acct.DeletedAt.Valid {
+		return fiber.NewError(fiber.StatusBadRequest, "Account is not scheduled for deletion.")
 	}
 
-	accTime := time.Now()
+	acct.DeletedAt = null.Time{}
+	acct.PurgeAt = null.Time{}
+
+	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.DeletedAt, models.AccountColumns.PurgeAt, models.AccountColumns.UpdatedAt)); err != nil {
+		return err
+	}
 
-	acct.AcceptedTosAt = null.TimeFrom(accTime)
+	if err := d.emitEvent(c.Context(), tx, events.AccountRestoredType, acct.ID, events.AccountRestored{}); err != nil {
+		return err
+	}
 
-	if _, err := acct.Update(c.Context(), d.dbs.DBS().Reader, boil.Whitelist(models.AccountColumns.AcceptedTosAt, models.AccountColumns.UpdatedAt)); err != nil {
+	if err := tx.Commit(); err != nil {
 		return err
 	}
 
+	logger.Info().Msg("Restored account.")
 	return c.JSON(StandardRes{
-		Message: "Accepted the terms of service.",
+		Message: fmt.Sprintf("Restored account %s.", acct.ID),
 	})
 }
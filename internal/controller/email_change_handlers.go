@@ -0,0 +1,361 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ChangeEmail godoc
+// @Summary Start changing the account's confirmed email to a new address. A confirmation code is sent to the new address; the old one stays linked until the change is confirmed. If sending fails, the change can be started again right away.
+// @Param changeEmailRequest body controller.AddEmailRequest true "Specifies the new email"
+// @Tags email
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Failure 403 {object} controller.ErrorRes
+// @Failure 429 {object} controller.ErrorRes "Returned if a code was sent too recently or the daily limit has been reached. The Retry-After header gives the number of seconds to wait."
+// @Failure 500 {object} controller.ErrorRes
+// @Router /v1/account/link/email/change [post]
+func (d *Controller) ChangeEmail(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	var body AddEmailRequest
+	if err := c.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	normalAddr := normalizeEmail(body.Address)
+
+	if !emailPattern.MatchString(normalAddr) {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Email address %q is invalid.", normalAddr))
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	if acct.R.Email == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "No email linked to account.")
+	}
+
+	if !acct.R.Email.ConfirmedAt.Valid {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Email %s is not confirmed. Link the new address directly instead.", acct.R.Email.Address))
+	}
+
+	if acct.R.Email.Address == normalAddr {
+		return fiber.NewError(fiber.StatusBadRequest, "Account already linked to this email.")
+	}
+
+	if inUse, err := models.EmailExists(c.Context(), tx, normalAddr); err != nil {
+		return err
+	} else if inUse {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Email address %s already linked to another account.", normalAddr))
+	}
+
+	// The send limits live on the current email row.
+	email, err := models.Emails(
+		models.EmailWhere.Address.EQ(acct.R.Email.Address),
+		qm.For("UPDATE"),
+	).One(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	if err := d.checkSendLimits(c, email, now); err != nil {
+		return err
+	}
+
+	code, err := generateConfirmationCode()
+	if err != nil {
+		return err
+	}
+
+	codeHash, err := hashConfirmationCode(code)
+	if err != nil {
+		return err
+	}
+
+	change := models.PendingEmailChange{
+		AccountID:            acct.ID,
+		Address:              normalAddr,
+		ConfirmationCodeHash: codeHash,
+		CodeExpiresAt:        now.Add(d.allowedLateness),
+		ConfirmationAttempts: 0,
+		CreatedAt:            now,
+	}
+
+	// Starting over replaces any earlier change that was never confirmed.
+	if err := change.Upsert(c.Context(), tx, true, []string{models.PendingEmailChangeColumns.AccountID}, boil.Infer(), boil.Infer()); err != nil {
+		return err
+	}
+
+	recordSend(email, now)
+	if _, err := email.Update(c.Context(), tx, boil.Whitelist(models.EmailColumns.CodeSentAt, models.EmailColumns.SendWindowStartedAt, models.EmailColumns.SendWindowCount)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Started changing email %s to %s.", email.Address, normalAddr)
+
+	if err := d.sendConfirmationEmail(c.Context(), normalAddr, code, ""); err != nil {
+		logger.Err(err).Msgf("Failed to send confirmation email to %s.", normalAddr)
+		d.liftResendCooldown(c.Context(), &logger, email.Address)
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to send the confirmation code.")
+	}
+
+	return c.JSON(StandardRes{
+		Message: fmt.Sprintf("A confirmation code has been sent to %s.", normalAddr),
+	})
+}
+
+// ConfirmEmailChange godoc
+// @Summary Complete an email change using the code that was sent to the new address. The old address is notified. Afterwards, the account is found by the new address.
+// @Param confirmEmailRequest body controller.CompleteEmailValidation true "Specifies the confirmation code"
+// @Tags email
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes "Returned if the code is incorrect or expired. The message includes the number of remaining attempts."
+// @Failure 403 {object} controller.ErrorRes
+// @Failure 429 {object} controller.ErrorRes "Returned if there have been too many failed attempts."
+// @Router /v1/account/link/email/change/confirm [post]
+func (d *Controller) ConfirmEmailChange(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	var body CompleteEmailValidation
+	if err := c.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	change, err := models.PendingEmailChanges(
+		models.PendingEmailChangeWhere.AccountID.EQ(acct.ID),
+		qm.For("UPDATE"),
+	).One(c.Context(), tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "No email change in progress.")
+		}
+		return err
+	}
+
+	if change.ConfirmationAttempts >= d.maxCodeAttempts {
+		return fiber.NewError(fiber.StatusTooManyRequests, "Too many failed attempts. Start the email change again.")
+	}
+
+	if time.Now().After(change.CodeExpiresAt) {
+		return fiber.NewError(fiber.StatusBadRequest, "Confirmation code has expired.")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(change.ConfirmationCodeHash), []byte(body.Code)); err != nil {
+		if !errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return err
+		}
+
+		change.ConfirmationAttempts++
+		if _, err := change.Update(c.Context(), tx, boil.Whitelist(models.PendingEmailChangeColumns.ConfirmationAttempts)); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		remaining := d.maxCodeAttempts - change.ConfirmationAttempts
+		logger.Warn().Msgf("Incorrect confirmation code for email change to %s, %d attempts remaining.", change.Address, remaining)
+
+		if remaining <= 0 {
+			return fiber.NewError(fiber.StatusTooManyRequests, "Incorrect confirmation code. Too many failed attempts, start the email change again.")
+		}
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Incorrect confirmation code. %d attempts remaining.", remaining))
+	}
+
+	oldAddr, err := replaceEmail(c.Context(), tx, acct, change.Address)
+	if err != nil {
+		return err
+	}
+
+	if err := d.emailChanged(c.Context(), tx, acct, oldAddr, change.Address); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Changed email %s to %s.", oldAddr, change.Address)
+
+	return c.JSON(StandardRes{
+		Message: fmt.Sprintf("Changed email to %s.", change.Address),
+	})
+}
+
+// ChangeEmailToken godoc
+// @Summary Change the account's email to the one in a signed JWT from the auth server. The old address is notified. Afterwards, the account is found by the new address.
+// @Param changeEmailRequest body controller.TokenBody true "Includes the email token"
+// @Tags email
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Router /v1/account/link/email/change/token [post]
+func (d *Controller) ChangeEmailToken(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	var tb TokenBody
+	if err := c.BodyParser(&tb); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	var infos AccountClaims
+	if _, err = jwt.ParseWithClaims(tb.Token, &infos, d.jwkResource.Keyfunc); err != nil {
+		return err
+	}
+
+	if infos.EmailAddress == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Token in the body does not have an email claim.")
+	}
+
+	normalEmail := normalizeEmail(*infos.EmailAddress)
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	if acct.R.Email == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "No email linked to account.")
+	}
+
+	if acct.R.Email.Address == normalEmail {
+		return fiber.NewError(fiber.StatusBadRequest, "Account already linked to this email.")
+	}
+
+	oldAddr, err := replaceEmail(c.Context(), tx, acct, normalEmail)
+	if err != nil {
+		return err
+	}
+
+	if err := d.emailChanged(c.Context(), tx, acct, oldAddr, normalEmail); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Changed email %s to %s.", oldAddr, normalEmail)
+
+	return c.JSON(StandardRes{
+		Message: fmt.Sprintf("Changed email to %s.", normalEmail),
+	})
+}
+
+// replaceEmail swaps the account's email row for a confirmed one with the new
+// address and discards any pending change. It returns the old address.
+func replaceEmail(ctx context.Context, tx *sql.Tx, acct *models.Account, newAddr string) (string, error) {
+	if inUse, err := models.EmailExists(ctx, tx, newAddr); err != nil {
+		return "", err
+	} else if inUse {
+		return "", fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Email address %s already linked to another account.", newAddr))
+	}
+
+	oldAddr := acct.R.Email.Address
+
+	if _, err := acct.R.Email.Delete(ctx, tx); err != nil {
+		return "", err
+	}
+
+	email := models.Email{
+		Address:     newAddr,
+		AccountID:   acct.ID,
+		ConfirmedAt: null.TimeFrom(time.Now()),
+	}
+
+	if err := email.Insert(ctx, tx, boil.Infer()); err != nil {
+		return "", err
+	}
+
+	if _, err := models.PendingEmailChanges(models.PendingEmailChangeWhere.AccountID.EQ(acct.ID)).DeleteAll(ctx, tx); err != nil {
+		return "", err
+	}
+
+	if _, err := acct.Update(ctx, tx, boil.Whitelist(models.AccountColumns.UpdatedAt)); err != nil {
+		return "", err
+	}
+
+	return oldAddr, nil
+}
+
+// emailChanged queues notifying the old address of the change and syncing the
+// new address to Customer.io and Kafka.
+func (d *Controller) emailChanged(ctx context.Context, tx *sql.Tx, acct *models.Account, oldAddr, newAddr string) error {
+	if err := d.notifyEmailChanged(ctx, tx, oldAddr, newAddr); err != nil {
+		return err
+	}
+
+	if wallet := primaryWallet(acct); wallet != nil {
+		if err := d.syncEmail(ctx, tx, common.BytesToAddress(wallet.Address), newAddr); err != nil {
+			return err
+		}
+	}
+
+	return d.emitEvent(ctx, tx, events.EmailLinkedType, acct.ID, events.EmailLinked{Email: newAddr, Confirmed: true})
+}
@@ -1,26 +1,151 @@
 package controller
 
 import (
+	"context"
+	"crypto/rand"
 	"database/sql"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
 	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func normalizeEmail(s string) string {
 	return strings.ToLower(strings.TrimSpace(s))
 }
 
+// generateConfirmationCode returns a uniformly random six-digit code, padded with
+// leading zeros if necessary.
+func generateConfirmationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashConfirmationCode returns a salted hash of the code suitable for storage.
+func hashConfirmationCode(code string) (string, error) {
+	h, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(h), nil
+}
+
+// sendWindow is the period over which the daily send limit is counted.
+const sendWindow = 24 * time.Hour
+
+// issueConfirmationCode generates a new code for the email, storing its hash and
+// resetting the attempt counter, and, if links are enabled, a confirmation link
+// that replaces any earlier one. The send is counted against the daily limit. The
+// caller is responsible for saving the row and mailing the returned code and link.
+func (d *Controller) issueConfirmationCode(email *models.Email, now time.Time) (string, string, error) {
+	code, err := generateConfirmationCode()
+	if err != nil {
+		return "", "", err
+	}
+
+	codeHash, err := hashConfirmationCode(code)
+	if err != nil {
+		return "", "", err
+	}
+
+	link, linkID, err := d.newConfirmationLink(email.AccountID, email.Address, now)
+	if err != nil {
+		return "", "", err
+	}
+
+	email.ConfirmationCodeHash = null.StringFrom(codeHash)
+	email.CodeExpiresAt = null.TimeFrom(now.Add(d.allowedLateness))
+	email.ConfirmationAttempts = 0
+	email.LinkTokenID = null.NewString(linkID, linkID != "")
+	recordSend(email, now)
+
+	return code, link, nil
+}
+
+// checkSendLimits returns a 429 error, with Retry-After set, if a code was sent
+// to the account within the cooldown or the daily limit has been reached. The
+// limits are tracked on the account's email row.
+func (d *Controller) checkSendLimits(c *fiber.Ctx, email *models.Email, now time.Time) error {
+	if email.CodeSentAt.Valid {
+		if wait := email.CodeSentAt.Time.Add(d.resendCooldown).Sub(now); wait > 0 {
+			setRetryAfter(c, wait)
+			return fiber.NewError(fiber.StatusTooManyRequests, "A confirmation code was sent recently. Try again later.")
+		}
+	}
+
+	if email.SendWindowStartedAt.Valid && email.SendWindowCount >= d.dailySendLimit {
+		if wait := email.SendWindowStartedAt.Time.Add(sendWindow).Sub(now); wait > 0 {
+			setRetryAfter(c, wait)
+			return fiber.NewError(fiber.StatusTooManyRequests, "Daily limit of confirmation emails reached. Try again later.")
+		}
+	}
+
+	return nil
+}
+
+// recordSend counts a code sent at the given time against the email's limits.
+func recordSend(email *models.Email, now time.Time) {
+	email.CodeSentAt = null.TimeFrom(now)
+
+	if !email.SendWindowStartedAt.Valid || now.Sub(email.SendWindowStartedAt.Time) >= sendWindow {
+		email.SendWindowStartedAt = null.TimeFrom(now)
+		email.SendWindowCount = 0
+	}
+	email.SendWindowCount++
+}
+
+// liftResendCooldown clears the cooldown on the email row after a send failed.
+// Nothing was sent, so the user shouldn't have to sit it out before asking
+// again. The send still counts toward the daily limit.
+func (d *Controller) liftResendCooldown(ctx context.Context, logger *zerolog.Logger, address string) {
+	if _, err := models.Emails(models.EmailWhere.Address.EQ(address)).UpdateAll(ctx, d.dbs.DBS().Writer, models.M{models.EmailColumns.CodeSentAt: nil}); err != nil {
+		logger.Err(err).Msgf("Failed to lift the resend cooldown for %s.", address)
+	}
+}
+
+// sendConfirmationEmail mails the confirmation code and link to the given address.
+func (d *Controller) sendConfirmationEmail(ctx context.Context, address, code, link string) error {
+	return d.emailService.SendConfirmationEmail(ctx, d.emailTemplate, address, code, link)
+}
+
+// markEmailConfirmed confirms the email and clears any outstanding code or link.
+func markEmailConfirmed(ctx context.Context, tx *sql.Tx, email *models.Email) error {
+	email.ConfirmedAt = null.TimeFrom(time.Now())
+	email.ConfirmationCodeHash = null.StringFromPtr(nil)
+	email.CodeExpiresAt = null.TimeFromPtr(nil)
+	email.ConfirmationAttempts = 0
+	email.LinkTokenID = null.StringFromPtr(nil)
+
+	_, err := email.Update(ctx, tx, boil.Whitelist(
+		models.EmailColumns.ConfirmedAt,
+		models.EmailColumns.ConfirmationCodeHash,
+		models.EmailColumns.CodeExpiresAt,
+		models.EmailColumns.ConfirmationAttempts,
+		models.EmailColumns.LinkTokenID,
+	))
+	return err
+}
+
 // LinkEmail godoc
-// @Summary Add an unconfirmed email to the account.
-// @Success 204
+// @Summary Add an unconfirmed email to the account, replacing any other unconfirmed email. A confirmation code is sent to the address. If sending fails, the email stays linked, codeSent is false, and a new code can be requested right away.
+// @Success 200 {object} controller.LinkEmailRes
 // @Tags email
 // @Param confirmEmailRequest body controller.AddEmailRequest true "Specifies the email to be linked"
 // @Failure 400 {object} controller.ErrorRes
@@ -59,14 +184,10 @@ func (d *Controller) LinkEmail(c *fiber.Ctx) error {
 	logger := d.log.With().Str("account", acct.ID).Logger()
 	c.Locals("logger", &logger)
 
-	if acct.R.Email != nil {
-		if acct.R.Email.Address == normalAddr {
-			return c.JSON(StandardRes{Message: "Account already linked to this email."})
-		}
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Account already has a linked email address %s.", acct.R.Email.Address))
-	}
-
-	if existingUse, err := models.FindEmail(c.Context(), tx, normalAddr); err != nil {
+	if existingUse, err := models.Emails(
+		models.EmailWhere.Address.EQ(normalAddr),
+		models.EmailWhere.AccountID.NEQ(acct.ID),
+	).One(c.Context(), tx); err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
 			return err
 		}
@@ -75,12 +196,44 @@ func (d *Controller) LinkEmail(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Email address %s already linked to another account.", normalAddr))
 	}
 
+	now := time.Now()
+
 	email := models.Email{
 		Address:     normalAddr,
 		AccountID:   acct.ID,
 		ConfirmedAt: null.TimeFromPtr(nil),
 	}
 
+	if oldEmail := acct.R.Email; oldEmail != nil {
+		if oldEmail.Address == normalAddr {
+			return c.JSON(StandardRes{Message: "Account already linked to this email."})
+		}
+		if oldEmail.ConfirmedAt.Valid {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Account already has a confirmed email address %s. Use the change email flow to replace it.", oldEmail.Address))
+		}
+
+		// The old address was never confirmed, most likely mistyped. Carry its send
+		// limits over so that replacing it can't be used to get around them.
+		if err := d.checkSendLimits(c, oldEmail, now); err != nil {
+			return err
+		}
+
+		email.CodeSentAt = oldEmail.CodeSentAt
+		email.SendWindowStartedAt = oldEmail.SendWindowStartedAt
+		email.SendWindowCount = oldEmail.SendWindowCount
+
+		if _, err := oldEmail.Delete(c.Context(), tx); err != nil {
+			return err
+		}
+
+		logger.Info().Msgf("Replacing unconfirmed email %s.", oldEmail.Address)
+	}
+
+	code, link, err := d.issueConfirmationCode(&email, now)
+	if err != nil {
+		return err
+	}
+
 	if err := email.Insert(c.Context(), tx, boil.Infer()); err != nil {
 		return err
 	}
@@ -89,20 +242,297 @@ func (d *Controller) LinkEmail(c *fiber.Ctx) error {
 		return err
 	}
 
+	if wallet := primaryWallet(acct); wallet != nil {
+		if err := d.syncEmail(c.Context(), tx, common.BytesToAddress(wallet.Address), normalAddr); err != nil {
+			return err
+		}
+	}
+
+	if err := d.emitEvent(c.Context(), tx, events.EmailLinkedType, acct.ID, events.EmailLinked{Email: normalAddr}); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
 
 	logger.Info().Msgf("Added unconfirmed email %s to account.", normalAddr)
 
-	if userAccount.EthereumAddress != nil {
-		if err := d.cioService.SetEmail(c.Context(), *userAccount.EthereumAddress, normalAddr); err != nil {
-			d.log.Err(err).Str("account", acct.ID).Msg("Failed to send email to Customer.io.")
+	if err := d.sendConfirmationEmail(c.Context(), normalAddr, code, link); err != nil {
+		logger.Err(err).Msgf("Failed to send confirmation email to %s.", normalAddr)
+
+		d.liftResendCooldown(c.Context(), &logger, normalAddr)
+
+		return c.JSON(LinkEmailRes{
+			Message:  fmt.Sprintf("Linked unconfirmed email %s to account, but failed to send the confirmation code. Request a new one.", normalAddr),
+			CodeSent: false,
+		})
+	}
+
+	return c.JSON(LinkEmailRes{
+		Message:  fmt.Sprintf("Linked unconfirmed email %s to account. A confirmation code has been sent to it.", normalAddr),
+		CodeSent: true,
+	})
+}
+
+// ConfirmEmail godoc
+// @Summary Confirm the account's linked email using the code that was sent to it.
+// @Param confirmEmailRequest body controller.CompleteEmailValidation true "Specifies the confirmation code"
+// @Tags email
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes "Returned if the code is incorrect or expired. The message includes the number of remaining attempts."
+// @Failure 403 {object} controller.ErrorRes
+// @Failure 429 {object} controller.ErrorRes "Returned if there have been too many failed attempts."
+// @Router /v1/account/link/email/confirm [post]
+func (d *Controller) ConfirmEmail(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	var body CompleteEmailValidation
+	if err := c.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	if acct.R.Email == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "No email linked to account.")
+	}
+
+	// Lock the row so that concurrent guesses are counted.
+	email, err := models.Emails(
+		models.EmailWhere.Address.EQ(acct.R.Email.Address),
+		qm.For("UPDATE"),
+	).One(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	if email.ConfirmedAt.Valid {
+		return fiber.NewError(fiber.StatusBadRequest, "Email already confirmed.")
+	}
+
+	if !email.ConfirmationCodeHash.Valid {
+		return fiber.NewError(fiber.StatusBadRequest, "No confirmation code has been issued for this email.")
+	}
+
+	if email.ConfirmationAttempts >= d.maxCodeAttempts {
+		return fiber.NewError(fiber.StatusTooManyRequests, "Too many failed attempts. Request a new confirmation code.")
+	}
+
+	if time.Now().After(email.CodeExpiresAt.Time) {
+		return fiber.NewError(fiber.StatusBadRequest, "Confirmation code has expired.")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(email.ConfirmationCodeHash.String), []byte(body.Code)); err != nil {
+		if !errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return err
+		}
+
+		email.ConfirmationAttempts++
+		if _, err := email.Update(c.Context(), tx, boil.Whitelist(models.EmailColumns.ConfirmationAttempts)); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
 		}
+
+		remaining := d.maxCodeAttempts - email.ConfirmationAttempts
+		logger.Warn().Msgf("Incorrect confirmation code for email %s, %d attempts remaining.", email.Address, remaining)
+
+		if remaining <= 0 {
+			return fiber.NewError(fiber.StatusTooManyRequests, "Incorrect confirmation code. Too many failed attempts, request a new confirmation code.")
+		}
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Incorrect confirmation code. %d attempts remaining.", remaining))
 	}
 
+	if err := markEmailConfirmed(c.Context(), tx, email); err != nil {
+		return err
+	}
+
+	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := d.emitEvent(c.Context(), tx, events.EmailConfirmedType, acct.ID, events.EmailConfirmed{Email: email.Address}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Confirmed email %s.", email.Address)
+
 	return c.JSON(StandardRes{
-		Message: fmt.Sprintf("Linked unconfirmed email %s to account.", normalAddr),
+		Message: fmt.Sprintf("Confirmed email %s.", email.Address),
+	})
+}
+
+// ResendConfirmationEmail godoc
+// @Summary Send a new confirmation code to the account's unconfirmed email. Any previous code stops working. If sending fails, a new code can be requested right away.
+// @Tags email
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Failure 403 {object} controller.ErrorRes
+// @Failure 429 {object} controller.ErrorRes "Returned if a code was sent too recently or the daily limit has been reached. The Retry-After header gives the number of seconds to wait."
+// @Failure 500 {object} controller.ErrorRes
+// @Router /v1/account/link/email/resend [post]
+func (d *Controller) ResendConfirmationEmail(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	if acct.R.Email == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "No email linked to account.")
+	}
+
+	// Lock the row so that concurrent requests can't skip the limits.
+	email, err := models.Emails(
+		models.EmailWhere.Address.EQ(acct.R.Email.Address),
+		qm.For("UPDATE"),
+	).One(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	if email.ConfirmedAt.Valid {
+		return fiber.NewError(fiber.StatusBadRequest, "Email already confirmed.")
+	}
+
+	now := time.Now()
+
+	if err := d.checkSendLimits(c, email, now); err != nil {
+		return err
+	}
+
+	code, link, err := d.issueConfirmationCode(email, now)
+	if err != nil {
+		return err
+	}
+
+	if _, err := email.Update(c.Context(), tx, boil.Infer()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if err := d.sendConfirmationEmail(c.Context(), email.Address, code, link); err != nil {
+		logger.Err(err).Msgf("Failed to send confirmation email to %s.", email.Address)
+		d.liftResendCooldown(c.Context(), &logger, email.Address)
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to send the confirmation code.")
+	}
+
+	logger.Info().Msgf("Resent confirmation code to %s.", email.Address)
+
+	return c.JSON(StandardRes{
+		Message: fmt.Sprintf("A new confirmation code has been sent to %s.", email.Address),
+	})
+}
+
+// setRetryAfter sets the Retry-After header to the given wait, rounded up to
+// whole seconds.
+func setRetryAfter(c *fiber.Ctx, wait time.Duration) {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+}
+
+// UnlinkEmail godoc
+// @Summary Remove the email from the account. The account must have a wallet so that it can still be logged into.
+// @Tags email
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Failure 403 {object} controller.ErrorRes
+// @Router /v1/account/link/email [delete]
+func (d *Controller) UnlinkEmail(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	if acct.R.Email == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "No email linked to account.")
+	}
+
+	wallet := primaryWallet(acct)
+	if wallet == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Can't remove the only way to log in to the account. Link a wallet first.")
+	}
+
+	address := acct.R.Email.Address
+
+	if _, err := acct.R.Email.Delete(c.Context(), tx); err != nil {
+		return err
+	}
+
+	if _, err := models.PendingEmailChanges(models.PendingEmailChangeWhere.AccountID.EQ(acct.ID)).DeleteAll(c.Context(), tx); err != nil {
+		return err
+	}
+
+	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := d.clearEmail(c.Context(), tx, common.BytesToAddress(wallet.Address)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Unlinked email %s.", address)
+
+	return c.JSON(StandardRes{
+		Message: fmt.Sprintf("Unlinked email %s.", address),
 	})
 }
 
@@ -190,14 +620,18 @@ func (d *Controller) LinkEmailToken(c *fiber.Ctx) error {
 		return err
 	}
 
-	if err := tx.Commit(); err != nil {
+	if wallet := primaryWallet(acct); wallet != nil {
+		if err := d.syncEmail(c.Context(), tx, common.BytesToAddress(wallet.Address), normalEmail); err != nil {
+			return err
+		}
+	}
+
+	if err := d.emitEvent(c.Context(), tx, events.EmailLinkedType, acct.ID, events.EmailLinked{Email: normalEmail, Confirmed: true}); err != nil {
 		return err
 	}
 
-	if userAccount.EthereumAddress != nil {
-		if err := d.cioService.SetEmail(c.Context(), *userAccount.EthereumAddress, normalEmail); err != nil {
-			logger.Err(err).Str("account", acct.ID).Msg("Failed to send email to Customer.io.")
-		}
+	if err := tx.Commit(); err != nil {
+		return err
 	}
 
 	logger.Info().Msgf("Linked confirmed email %s.", normalEmail)
@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// emailLinkAudience keeps confirmation link tokens from being accepted anywhere else.
+const emailLinkAudience = "accounts-api/email-verify"
+
+// emailLinkClaims are carried by the token in an email confirmation link. The
+// subject is the account ID and the token ID must match emails.link_token_id.
+type emailLinkClaims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// newConfirmationLink returns a signed, single-use link confirming the address
+// for the account, along with the token ID to store. Both are empty if links
+// are not configured.
+func (d *Controller) newConfirmationLink(accountID, address string, now time.Time) (string, string, error) {
+	if len(d.linkSigningKey) == 0 || d.linkBaseURL == "" {
+		return "", "", nil
+	}
+
+	tokenID := ksuid.New().String()
+
+	claims := emailLinkClaims{
+		Email: address,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   accountID,
+			Audience:  jwt.ClaimStrings{emailLinkAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(d.allowedLateness)),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(d.linkSigningKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return d.linkBaseURL + "/v1/account/email/verify?token=" + url.QueryEscape(token), tokenID, nil
+}
+
+func (d *Controller) parseConfirmationLinkToken(token string) (*emailLinkClaims, error) {
+	var claims emailLinkClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return d.linkSigningKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(emailLinkAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// emailLinkPage is rendered by the confirmation link endpoints. With a token
+// it shows the confirm form, which posts back to the URL it was opened from,
+// otherwise the outcome in Message.
+type emailLinkPage struct {
+	Email   string
+	Token   string
+	Message string
+	Failed  bool
+}
+
+func (d *Controller) renderEmailLinkPage(c *fiber.Ctx, page emailLinkPage) error {
+	var buf bytes.Buffer
+	if err := d.linkPage.Execute(&buf, page); err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Type("html", "utf-8")
+	return c.Send(buf.Bytes())
+}
+
+// ShowEmailLink godoc
+// @Summary Show the page an email confirmation link opens. This does not confirm the email; the page posts the token back to confirm it, so that link scanners and prefetchers can't use up the token.
+// @Param token query string true "Token from the confirmation link"
+// @Tags email
+// @Produce html
+// @Success 200 {string} string "Page asking the user to confirm."
+// @Failure 400 {string} string "Page saying the link is invalid."
+// @Failure 404 {object} controller.ErrorRes "Returned if confirmation links are not enabled."
+// @Router /v1/account/email/verify [get]
+func (d *Controller) ShowEmailLink(c *fiber.Ctx) error {
+	if len(d.linkSigningKey) == 0 {
+		return fiber.NewError(fiber.StatusNotFound, "Confirmation links are not enabled.")
+	}
+
+	token := c.Query("token")
+
+	claims, err := d.parseConfirmationLinkToken(token)
+	if err != nil {
+		c.Status(fiber.StatusBadRequest)
+		return d.renderEmailLinkPage(c, emailLinkPage{Message: "Confirmation link is invalid or has expired.", Failed: true})
+	}
+
+	return d.renderEmailLinkPage(c, emailLinkPage{
+		Email: claims.Email,
+		Token: token,
+	})
+}
+
+// VerifyEmailLinkRequest carries the token from a confirmation link.
+type VerifyEmailLinkRequest struct {
+	// Token is the token query parameter of the confirmation link.
+	Token string `json:"token" form:"token"`
+}
+
+// VerifyEmailLink godoc
+// @Summary Confirm an email using the token from the link that was sent to it. No login is required; the token is single-use and expires with the code. Form posts from the confirmation page get an HTML page back, other requests get JSON.
+// @Param verifyEmailLinkRequest body controller.VerifyEmailLinkRequest true "Token from the confirmation link"
+// @Accept json
+// @Accept x-www-form-urlencoded
+// @Tags email
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Failure 404 {object} controller.ErrorRes "Returned if confirmation links are not enabled."
+// @Failure 410 {object} controller.ErrorRes "Returned if the account is scheduled for deletion."
+// @Router /v1/account/email/verify [post]
+func (d *Controller) VerifyEmailLink(c *fiber.Ctx) error {
+	if len(d.linkSigningKey) == 0 {
+		return fiber.NewError(fiber.StatusNotFound, "Confirmation links are not enabled.")
+	}
+
+	var req VerifyEmailLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	msg, err := d.verifyEmailLink(c, req.Token)
+
+	if !strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationForm) {
+		if err != nil {
+			return err
+		}
+		return c.JSON(StandardRes{Message: msg})
+	}
+
+	if err != nil {
+		var fe *fiber.Error
+		if !errors.As(err, &fe) {
+			return err
+		}
+		c.Status(fe.Code)
+		return d.renderEmailLinkPage(c, emailLinkPage{Message: fe.Message, Failed: true})
+	}
+
+	return d.renderEmailLinkPage(c, emailLinkPage{Message: msg})
+}
+
+func (d *Controller) verifyEmailLink(c *fiber.Ctx, token string) (string, error) {
+	claims, err := d.parseConfirmationLinkToken(token)
+	if err != nil {
+		d.log.Info().Err(err).Msg("Rejected email confirmation link.")
+		return "", fiber.NewError(fiber.StatusBadRequest, "Confirmation link is invalid or has expired.")
+	}
+
+	logger := d.log.With().Str("account", claims.Subject).Logger()
+	c.Locals("logger", &logger)
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback() //nolint
+
+	email, err := models.Emails(
+		models.EmailWhere.Address.EQ(claims.Email),
+		models.EmailWhere.AccountID.EQ(claims.Subject),
+		qm.For("UPDATE"),
+	).One(c.Context(), tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fiber.NewError(fiber.StatusBadRequest, "Email is no longer linked to this account.")
+		}
+		return "", err
+	}
+
+	acct, err := models.FindAccount(c.Context(), tx, email.AccountID)
+	if err != nil {
+		return "", err
+	}
+
+	if acct.DeletedAt.Valid {
+		return "", fiber.NewError(fiber.StatusGone, fmt.Sprintf("Account is scheduled for deletion at %s. Restore it to keep using it.", acct.PurgeAt.Time.Format(time.RFC3339)))
+	}
+
+	if email.ConfirmedAt.Valid {
+		return fmt.Sprintf("Email %s already confirmed.", email.Address), nil
+	}
+
+	if !email.LinkTokenID.Valid || email.LinkTokenID.String != claims.ID {
+		return "", fiber.NewError(fiber.StatusBadRequest, "Confirmation link has already been used or was replaced by a newer one.")
+	}
+
+	if err := markEmailConfirmed(c.Context(), tx, email); err != nil {
+		return "", err
+	}
+
+	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.UpdatedAt)); err != nil {
+		return "", err
+	}
+
+	if err := d.emitEvent(c.Context(), tx, events.EmailConfirmedType, acct.ID, events.EmailConfirmed{Email: email.Address}); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	logger.Info().Msgf("Confirmed email %s using link.", email.Address)
+
+	return fmt.Sprintf("Confirmed email %s.", email.Address), nil
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// accountETag returns a strong entity tag for the account as described by resp.
+// Every write to an account bumps its updated_at, so that serves as the version.
+// The outstanding documents are mixed in because publishing a new legal document
+// changes the response without touching the account.
+func accountETag(resp *UserResponse) string {
+	h := sha256.New()
+	// The database rounds to microseconds, so don't let a freshly written
+	// timestamp produce a different tag from the one read back later.
+	fmt.Fprintf(h, "%s\n%d\n", resp.ID, resp.UpdatedAt.Round(time.Microsecond).UnixMicro())
+	for _, doc := range resp.OutstandingDocuments {
+		fmt.Fprintf(h, "%s %s\n", doc.Kind, doc.Version)
+	}
+	return `"` + base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:18]) + `"`
+}
+
+// etagMatches reports whether etag appears in the list of entity tags in an
+// If-Match or If-None-Match header. Per RFC 9110, If-Match uses the strong
+// comparison, under which weak tags never match, and If-None-Match the weak one.
+func etagMatches(header, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = tag[2:]
+		}
+
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIfMatch fails with 412 if the request has an If-Match header that doesn't
+// match the account's current entity tag. Requests without the header are let
+// through. To avoid racing other writers, the account should have been loaded
+// with getUserAccountForUpdate.
+func (d *Controller) checkIfMatch(c *fiber.Ctx, exec boil.ContextExecutor, acct *models.Account) error {
+	ifMatch := c.Get(fiber.HeaderIfMatch)
+	if ifMatch == "" {
+		return nil
+	}
+
+	resp, err := d.formatUserAcctResponse(c.Context(), exec, acct)
+	if err != nil {
+		return err
+	}
+
+	if !etagMatches(ifMatch, accountETag(resp), false) {
+		return fiber.NewError(fiber.StatusPreconditionFailed, "Account has changed since it was last fetched. Fetch it again and retry.")
+	}
+
+	return nil
+}
+
+// getUserAccountForUpdate is like getUserAccount, but also locks the account row
+// until the transaction ends.
+func (d *Controller) getUserAccountForUpdate(ctx context.Context, userAccount *AccountClaims, exec boil.ContextExecutor) (*models.Account, error) {
+	acct, err := d.getUserAccount(ctx, userAccount, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	locked, err := models.Accounts(
+		qm.Select(models.AccountColumns.UpdatedAt),
+		models.AccountWhere.ID.EQ(acct.ID),
+		qm.For("UPDATE"),
+	).One(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Someone else wrote to the account between reading it and locking it.
+	// Now that we hold the lock, the next read is current.
+	if !locked.UpdatedAt.Equal(acct.UpdatedAt) {
+		return d.getUserAccount(ctx, userAccount, exec)
+	}
+
+	return acct, nil
+}
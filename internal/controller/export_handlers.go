@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// ExportAccount godoc
+// @Summary Download everything stored about the authenticated user. This works for accounts that are scheduled for deletion.
+// @Produce json
+// @Success 200 {object} controller.AccountExport
+// @Failure 403 {object} controller.ErrorRes
+// @Security BearerAuth
+// @Router /v1/account/export [get]
+func (d *Controller) ExportAccount(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	// Read everything from one snapshot.
+	tx, err := d.dbs.DBS().Reader.BeginTx(c.Context(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.lookupUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	pending, err := acct.PendingEmailChange().One(c.Context(), tx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	referred, err := acct.ReferredByAccounts(
+		qm.Load(models.AccountRels.Wallets),
+		qm.OrderBy(models.AccountColumns.ReferredAt),
+	).All(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	acceptances, err := acct.LegalAcceptances(
+		qm.OrderBy(models.LegalAcceptanceColumns.AcceptedAt),
+	).All(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	prefChanges, err := acct.CommunicationPreferenceChanges(
+		qm.OrderBy(models.CommunicationPreferenceChangeColumns.ChangedAt+", "+models.CommunicationPreferenceChangeColumns.ID),
+	).All(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	retiredCodes, err := acct.RetiredReferralCodes(
+		qm.OrderBy(models.RetiredReferralCodeColumns.RetiredAt),
+	).All(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	out := AccountExport{
+		ExportedAt: time.Now(),
+		Account: AccountExportAccount{
+			ID:          acct.ID,
+			CountryCode: acct.CountryCode.Ptr(),
+			DisplayName: acct.DisplayName.Ptr(),
+			Language:    acct.Language.Ptr(),
+			TimeZone:    acct.TimeZone.Ptr(),
+			Units:       acct.Units.Ptr(),
+			CreatedAt:   acct.CreatedAt,
+			UpdatedAt:   acct.UpdatedAt,
+			DeletedAt:   acct.DeletedAt.Ptr(),
+			PurgeAt:     acct.PurgeAt.Ptr(),
+		},
+		Wallets: make([]UserResponseWallet, len(acct.R.Wallets)),
+		Referral: AccountExportReferral{
+			Code:         acct.ReferralCode,
+			ReferredBy:   primaryWalletHex(acct.R.ReferredByAccount),
+			ReferredAt:   acct.ReferredAt.Ptr(),
+			RetiredCodes: make([]AccountExportRetiredCode, len(retiredCodes)),
+			Referred:     make([]AccountExportReferred, len(referred)),
+		},
+		CommunicationPreferences:       formatPreferences(acct.R.CommunicationPreferences),
+		CommunicationPreferenceChanges: make([]AccountExportPreferenceChange, len(prefChanges)),
+		LegalAcceptances:               []AccountExportLegalAcceptance{},
+	}
+
+	if campaign := acct.R.ReferralCampaign; campaign != nil {
+		out.Referral.Campaign = &campaign.Code
+	}
+
+	if email := acct.R.Email; email != nil {
+		out.Email = &AccountExportEmail{
+			Address:       email.Address,
+			ConfirmedAt:   email.ConfirmedAt.Ptr(),
+			CodeSentAt:    email.CodeSentAt.Ptr(),
+			CodeExpiresAt: email.CodeExpiresAt.Ptr(),
+		}
+	}
+
+	if pending != nil {
+		out.PendingEmailChange = &AccountExportPendingEmailChange{
+			Address:       pending.Address,
+			CreatedAt:     pending.CreatedAt,
+			CodeExpiresAt: pending.CodeExpiresAt,
+		}
+	}
+
+	for i, w := range acct.R.Wallets {
+		out.Wallets[i] = formatWallet(w)
+	}
+
+	for i, r := range retiredCodes {
+		out.Referral.RetiredCodes[i] = AccountExportRetiredCode{
+			Code:      r.Code,
+			RetiredAt: r.RetiredAt,
+			ExpiresAt: r.ExpiresAt,
+		}
+	}
+
+	for i, r := range referred {
+		out.Referral.Referred[i] = AccountExportReferred{
+			Wallet:     primaryWalletHex(r),
+			ReferredAt: r.ReferredAt.Time,
+		}
+	}
+
+	for i, p := range prefChanges {
+		out.CommunicationPreferenceChanges[i] = AccountExportPreferenceChange{
+			Channel:   p.Channel,
+			Enabled:   p.Enabled,
+			ChangedAt: p.ChangedAt,
+			Source:    p.Source,
+		}
+	}
+
+	for _, a := range acceptances {
+		out.LegalAcceptances = append(out.LegalAcceptances, AccountExportLegalAcceptance{
+			Kind:       a.Kind,
+			Version:    a.Version,
+			AcceptedAt: a.AcceptedAt,
+		})
+	}
+
+	logger.Info().Msg("Exported account data.")
+
+	c.Attachment(fmt.Sprintf("dimo-account-%s.json", acct.ID))
+	return c.JSON(out)
+}
+
+// primaryWalletHex returns the hex address of the account's primary wallet, or
+// nil if there's no account or it has no wallet.
+func primaryWalletHex(acct *models.Account) *string {
+	if acct == nil {
+		return nil
+	}
+	w := primaryWallet(acct)
+	if w == nil {
+		return nil
+	}
+	a := common.BytesToAddress(w.Address).Hex()
+	return &a
+}
@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/internal/legal"
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// AcceptTOS godoc
+// @Summary Agree to the current terms of service
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Router /v1/account/accept-tos [post]
+func (d *Controller) AcceptTOS(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	current, err := legal.Current(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	tos := legal.CurrentOf(current, legal.KindTOS)
+	if tos == nil {
+		return errors.New("no terms of service in force")
+	}
+
+	if legal.Accepted(tos, acct.R.LegalAcceptances) {
+		return c.JSON(StandardRes{
+			Message: fmt.Sprintf("Already accepted version %s of the terms of service.", tos.Version),
+		})
+	}
+
+	if err := d.acceptDocument(c.Context(), tx, acct, tos, time.Now()); err != nil {
+		return err
+	}
+
+	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.AcceptedTosAt, models.AccountColumns.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return c.JSON(StandardRes{
+		Message: fmt.Sprintf("Accepted version %s of the terms of service.", tos.Version),
+	})
+}
+
+// AcceptDocuments godoc
+// @Summary Agree to the current versions of some of our legal documents
+// @Accept json
+// @Param acceptDocumentsRequest body controller.AcceptDocumentsRequest true "The documents, each of which must be the version in force."
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Router /v1/account/legal/accept [post]
+func (d *Controller) AcceptDocuments(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	var body AcceptDocumentsRequest
+	if err := c.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	if len(body.Documents) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "No documents provided.")
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	current, err := legal.Current(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	// Validate everything before accepting anything.
+	var toAccept []*models.LegalDocument
+	for _, req := range body.Documents {
+		doc := legal.CurrentOf(current, req.Kind)
+		if doc == nil {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Unrecognized document kind %q.", req.Kind))
+		}
+
+		if doc.Version != req.Version {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Version %q of %s is not in force; the current version is %q.", req.Version, req.Kind, doc.Version))
+		}
+
+		if !legal.Accepted(doc, acct.R.LegalAcceptances) && !slices.Contains(toAccept, doc) {
+			toAccept = append(toAccept, doc)
+		}
+	}
+
+	if len(toAccept) == 0 {
+		return c.JSON(StandardRes{
+			Message: "Already accepted these documents.",
+		})
+	}
+
+	now := time.Now()
+	for _, doc := range toAccept {
+		if err := d.acceptDocument(c.Context(), tx, acct, doc, now); err != nil {
+			return err
+		}
+	}
+
+	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.AcceptedTosAt, models.AccountColumns.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Accepted %d legal documents.", len(toAccept))
+	return c.JSON(StandardRes{
+		Message: fmt.Sprintf("Accepted %d documents.", len(toAccept)),
+	})
+}
+
+// acceptDocument records the account's acceptance of the document and queues the
+// events for it. For the terms of service it also sets AcceptedTosAt, which the
+// caller must save. If a concurrent request already recorded the acceptance,
+// nothing is done.
+func (d *Controller) acceptDocument(ctx context.Context, tx *sql.Tx, acct *models.Account, doc *models.LegalDocument, acceptedAt time.Time) error {
+	res, err := queries.Raw(fmt.Sprintf("INSERT INTO %s (%s, %s, %s, %s) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING",
+		models.TableNames.LegalAcceptances,
+		models.LegalAcceptanceColumns.AccountID,
+		models.LegalAcceptanceColumns.Kind,
+		models.LegalAcceptanceColumns.Version,
+		models.LegalAcceptanceColumns.AcceptedAt,
+	), acct.ID, doc.Kind, doc.Version, acceptedAt).ExecContext(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return nil
+	}
+
+	if err := d.emitEvent(ctx, tx, events.LegalDocumentAcceptedType, acct.ID, events.LegalDocumentAccepted{
+		Kind:       doc.Kind,
+		Version:    doc.Version,
+		AcceptedAt: acceptedAt,
+	}); err != nil {
+		return err
+	}
+
+	if doc.Kind != legal.KindTOS {
+		return nil
+	}
+
+	acct.AcceptedTosAt = null.TimeFrom(acceptedAt)
+
+	return d.emitEvent(ctx, tx, events.TOSAcceptedType, acct.ID, events.TOSAccepted{
+		Version:    doc.Version,
+		AcceptedAt: acceptedAt,
+	})
+}
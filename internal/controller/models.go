@@ -26,6 +26,10 @@ type UserResponseEmail struct {
 type UserResponseWallet struct {
 	// Address is the Ethereum address associated with the user.
 	Address string `json:"address" swaggertype:"string" example:"0x142e0C7A098622Ea98E5D67034251C4dFA746B5d"`
+	// Primary is true for the one wallet that is used for referrals and Customer.io.
+	Primary bool `json:"primary" example:"true"`
+	// CreatedAt is when the wallet was linked to the account.
+	CreatedAt time.Time `json:"createdAt" example:"2021-12-01T09:00:00Z"`
 }
 
 type UserResponseReferral struct {
@@ -37,6 +41,75 @@ type UserResponseReferral struct {
 	ReferredBy *string `json:"referredBy,omitempty"`
 	// The timestamp at which the user was referred. May be empty if the user wasn't referred.
 	ReferredAt *time.Time `json:"referredAt,omitempty"`
+	// Campaign is the campaign code the user entered, if they entered one instead of
+	// another user's code.
+	Campaign *string `json:"campaign,omitempty" swaggertype:"string" example:"SPRING-ROADTRIP"`
+	// Deadline is the last moment at which the user can enter someone else's referral
+	// code. It's absent if the user has already been referred.
+	Deadline *time.Time `json:"deadline,omitempty" swaggertype:"string" example:"2021-12-31T09:00:00Z"`
+}
+
+// ReferralsResponse lists the accounts that used the caller's referral code,
+// most recent first.
+type ReferralsResponse struct {
+	Counts   ReferralCounts `json:"counts"`
+	Referees []Referee      `json:"referees"`
+	// NextCursor, if present, can be passed as the cursor parameter to fetch the
+	// next page.
+	NextCursor *string `json:"nextCursor,omitempty" swaggertype:"string" example:"MjAyNC0wMS0wMVQwMDowMDowMFp8Mm1EOEN0cmF4T0NBQXdJZXlkdDJRNG9DaUFR"`
+}
+
+// ReferralCounts summarizes all of the caller's referees, including those whose
+// accounts have been purged.
+type ReferralCounts struct {
+	Total int64 `json:"total" example:"12"`
+	// Deleted is the number of referees whose accounts are scheduled for deletion.
+	Deleted int64 `json:"deleted" example:"1"`
+	// Purged is the number of referees whose accounts have been permanently
+	// deleted. They're in the total but no longer listed.
+	Purged int64 `json:"purged" example:"2"`
+}
+
+type Referee struct {
+	// Wallet is the address of the referee's primary wallet.
+	Wallet     *string   `json:"wallet" swaggertype:"string" example:"0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"`
+	ReferredAt time.Time `json:"referredAt" example:"2021-12-01T09:00:00Z"`
+	// Deleted is true if the referee's account is scheduled for deletion.
+	Deleted bool `json:"deleted" example:"false"`
+}
+
+// CommunicationPreference is the user's consent to one kind of communication.
+type CommunicationPreference struct {
+	Enabled bool `json:"enabled" example:"true"`
+	// UpdatedAt is when the user last changed this preference. It's absent if they
+	// never have, in which case Enabled is false.
+	UpdatedAt *time.Time `json:"updatedAt,omitempty" example:"2021-12-01T09:00:00Z"`
+}
+
+type CommunicationPreferences struct {
+	// MarketingEmail covers promotional email.
+	MarketingEmail CommunicationPreference `json:"marketingEmail"`
+	// ProductUpdates covers news about DIMO products and features.
+	ProductUpdates CommunicationPreference `json:"productUpdates"`
+	// PushNotifications covers push notifications to the mobile app.
+	PushNotifications CommunicationPreference `json:"pushNotifications"`
+}
+
+// CommunicationPreferencesUpdate changes some communication preferences. Omitted
+// preferences are left alone.
+type CommunicationPreferencesUpdate struct {
+	MarketingEmail    *bool `json:"marketingEmail,omitempty" example:"false"`
+	ProductUpdates    *bool `json:"productUpdates,omitempty" example:"true"`
+	PushNotifications *bool `json:"pushNotifications,omitempty"`
+}
+
+// UserResponseDocument identifies a version of one of our legal documents.
+type UserResponseDocument struct {
+	// Kind is one of "tos", "privacy", or "data_sharing".
+	Kind    string `json:"kind" example:"tos"`
+	Version string `json:"version" example:"1"`
+	// EffectiveAt is when this version came into force.
+	EffectiveAt time.Time `json:"effectiveAt" example:"2021-12-01T00:00:00Z"`
 }
 
 type UserResponse struct {
@@ -45,8 +118,10 @@ type UserResponse struct {
 
 	// Email describes the user's email and the state of its confirmation.
 	Email *UserResponseEmail `json:"email,omitempty"`
-	// Wallet describes the user's blockchain account.
+	// Wallet describes the user's primary blockchain account.
 	Wallet *UserResponseWallet `json:"wallet,omitempty"`
+	// Wallets lists all of the user's blockchain accounts, oldest first.
+	Wallets []UserResponseWallet `json:"wallets"`
 
 	// Referral describes the account's referral code and information about who, if anyone,
 	// referred the account. This is only available if the account has a linked wallet.
@@ -54,8 +129,21 @@ type UserResponse struct {
 
 	// CountryCode, if present, is a valid ISO 3166-1 alpha-3 country code.
 	CountryCode *string `json:"countryCode" swaggertype:"string" example:"USA"`
+	// DisplayName is how the user would like to be addressed.
+	DisplayName *string `json:"displayName" swaggertype:"string" example:"Kilgore Trout"`
+	// Language, if present, is a BCP 47 language tag.
+	Language *string `json:"language" swaggertype:"string" example:"en-US"`
+	// TimeZone, if present, is an IANA time zone name.
+	TimeZone *string `json:"timeZone" swaggertype:"string" example:"America/New_York"`
+	// Units, if present, is either "metric" or "imperial".
+	Units *string `json:"units" swaggertype:"string" example:"imperial"`
 	// AcceptedTOSAt is the time at which the user last agreed to the terms of service.
 	AcceptedTOSAt *time.Time `json:"acceptedTosAt,omitempty" swaggertype:"string" example:"2021-12-01T09:00:41Z"`
+	// OutstandingDocuments lists the legal documents in force that the user has yet
+	// to accept.
+	OutstandingDocuments []UserResponseDocument `json:"outstandingDocuments"`
+	// CommunicationPreferences describes how the user agrees to be contacted.
+	CommunicationPreferences CommunicationPreferences `json:"communicationPreferences"`
 
 	// CreatedAt is when the user first logged in.
 	CreatedAt time.Time `json:"createdAt" example:"2021-12-01T09:00:00Z"`
@@ -63,16 +151,39 @@ type UserResponse struct {
 	UpdatedAt time.Time `json:"updatedAt" example:"2021-12-01T09:00:00Z"`
 }
 
+type RegenerateReferralCodeRequest struct {
+	// DisableImmediately stops the old code from working right away, instead of
+	// after the grace period.
+	DisableImmediately bool `json:"disableImmediately" example:"false"`
+}
+
+type RegenerateReferralCodeResponse struct {
+	// Code is the user's new referral code.
+	Code        string `json:"code" example:"7QX2KD"`
+	RetiredCode string `json:"retiredCode" example:"ANBJN5"`
+	// RetiredCodeExpiresAt is when the old code stops referring users to this
+	// account.
+	RetiredCodeExpiresAt time.Time `json:"retiredCodeExpiresAt" example:"2021-12-08T09:00:00Z"`
+}
+
 type SubmitReferralCodeRequest struct {
 	Code string `json:"code" example:"ANBJN5"`
 }
 
-// UserUpdateRequest describes a user's request to modify or delete certain fields
-// Currently contains only CountryCode as dedicated endpoints exist for other types
-// of updates a user might make
+// UserUpdateRequest describes a user's request to modify certain fields. Empty
+// fields are left alone. Dedicated endpoints exist for other types of updates a
+// user might make.
 type UserUpdateRequest struct {
 	// CountryCode should be a valid ISO 3166-1 alpha-3 country code
 	CountryCode string `json:"countryCode,omitempty" swaggertype:"string" example:"USA"`
+	// DisplayName is how the user would like to be addressed, at most 64 characters.
+	DisplayName string `json:"displayName,omitempty" example:"Kilgore Trout"`
+	// Language is a BCP 47 language tag.
+	Language string `json:"language,omitempty" example:"en-US"`
+	// TimeZone is an IANA time zone name.
+	TimeZone string `json:"timeZone,omitempty" example:"America/New_York"`
+	// Units is either "metric" or "imperial".
+	Units string `json:"units,omitempty" example:"imperial"`
 }
 
 // AddEmailRequest request body used for adding an email that cannot be authenticated via federated sign in to account
@@ -80,16 +191,182 @@ type AddEmailRequest struct {
 	Address string `json:"address" swaggertype:"string" example:"kilgore@kilgore.trout"`
 }
 
+// ReplaceWalletRequest carries proof of ownership for both the account's current
+// wallet and the wallet that should replace it.
+type ReplaceWalletRequest struct {
+	// OldToken is a JWT from the auth server with the current wallet in its ethereum_address claim.
+	OldToken string `json:"oldToken" example:"eyJhbGciOiJSUzI1NiIsImtpZCI6..."`
+	// NewToken is a JWT from the auth server with the new wallet in its ethereum_address claim.
+	NewToken string `json:"newToken" example:"eyJhbGciOiJSUzI1NiIsImtpZCI6..."`
+}
+
+// SIWENonceResponse carries a nonce to put in a Sign-In with Ethereum message.
+type SIWENonceResponse struct {
+	Nonce string `json:"nonce" example:"2mQ1KpE4CwXb2GQ7bGZmv0Xc8Jf"`
+	// ExpiresAt is the time after which the nonce will no longer be accepted.
+	ExpiresAt time.Time `json:"expiresAt" example:"2021-12-01T09:10:00Z"`
+}
+
+// LinkWalletSIWERequest is a signed EIP-4361 message proving ownership of a wallet.
+type LinkWalletSIWERequest struct {
+	// Message is the exact text that was signed.
+	Message string `json:"message" example:"app.dimo.zone wants you to sign in with your Ethereum account:\n0x142e0C7A098622Ea98E5D67034251C4dFA746B5d\n\n\nURI: https://app.dimo.zone\nVersion: 1\nChain ID: 137\nNonce: 2mQ1KpE4CwXb2GQ7bGZmv0Xc8Jf\nIssued At: 2021-12-01T09:00:00Z"`
+	// Signature is the hex-encoded personal_sign signature of the message.
+	Signature string `json:"signature" example:"0x6fd5a5..."`
+}
+
+// WalletsResponse lists the account's wallets, oldest first.
+type WalletsResponse struct {
+	Wallets []UserResponseWallet `json:"wallets"`
+}
+
+// AccountExport is everything we store about an account.
+type AccountExport struct {
+	// ExportedAt is when the export was produced.
+	ExportedAt time.Time `json:"exportedAt" example:"2021-12-01T09:00:00Z"`
+
+	Account AccountExportAccount `json:"account"`
+	// Email is the account's email, if it has one.
+	Email *AccountExportEmail `json:"email,omitempty"`
+	// PendingEmailChange is an email change that was started but not yet confirmed.
+	PendingEmailChange *AccountExportPendingEmailChange `json:"pendingEmailChange,omitempty"`
+	// Wallets lists all of the account's blockchain accounts, oldest first.
+	Wallets                  []UserResponseWallet     `json:"wallets"`
+	Referral                 AccountExportReferral    `json:"referral"`
+	CommunicationPreferences CommunicationPreferences `json:"communicationPreferences"`
+	// CommunicationPreferenceChanges lists every change to the communication
+	// preferences, oldest first.
+	CommunicationPreferenceChanges []AccountExportPreferenceChange `json:"communicationPreferenceChanges"`
+	// LegalAcceptances lists every version of our legal documents that the user
+	// agreed to, oldest first.
+	LegalAcceptances []AccountExportLegalAcceptance `json:"legalAcceptances"`
+}
+
+type AccountExportAccount struct {
+	ID string `json:"id" example:"2mD8CtraxOCAAwIeydt2Q4oCiAQ"`
+	// CountryCode, if present, is a valid ISO 3166-1 alpha-3 country code.
+	CountryCode *string   `json:"countryCode" swaggertype:"string" example:"USA"`
+	DisplayName *string   `json:"displayName" swaggertype:"string" example:"Kilgore Trout"`
+	Language    *string   `json:"language" swaggertype:"string" example:"en-US"`
+	TimeZone    *string   `json:"timeZone" swaggertype:"string" example:"America/New_York"`
+	Units       *string   `json:"units" swaggertype:"string" example:"imperial"`
+	CreatedAt   time.Time `json:"createdAt" example:"2021-12-01T09:00:00Z"`
+	UpdatedAt   time.Time `json:"updatedAt" example:"2021-12-01T09:00:00Z"`
+	// DeletedAt is when the user asked for the account to be deleted, if they have.
+	DeletedAt *time.Time `json:"deletedAt,omitempty" example:"2021-12-01T09:00:00Z"`
+	// PurgeAt is when the account will be deleted for good, unless it is restored.
+	PurgeAt *time.Time `json:"purgeAt,omitempty" example:"2021-12-31T09:00:00Z"`
+}
+
+type AccountExportEmail struct {
+	Address     string     `json:"address" example:"koblitz@dimo.zone"`
+	ConfirmedAt *time.Time `json:"confirmedAt" example:"2021-12-01T09:00:41Z"`
+	// CodeSentAt is when the most recent confirmation code was sent.
+	CodeSentAt *time.Time `json:"codeSentAt,omitempty" example:"2021-12-01T09:00:00Z"`
+	// CodeExpiresAt is when the most recent confirmation code stops working.
+	CodeExpiresAt *time.Time `json:"codeExpiresAt,omitempty" example:"2021-12-01T09:05:00Z"`
+}
+
+type AccountExportPendingEmailChange struct {
+	Address       string    `json:"address" example:"kilgore@kilgore.trout"`
+	CreatedAt     time.Time `json:"createdAt" example:"2021-12-01T09:00:00Z"`
+	CodeExpiresAt time.Time `json:"codeExpiresAt" example:"2021-12-01T09:05:00Z"`
+}
+
+type AccountExportReferral struct {
+	// Code is the account's referral code.
+	Code string `json:"code" example:"ANBJN5"`
+	// ReferredBy is the primary wallet of the account that referred this one. It's
+	// empty if the referrer has since deleted their account.
+	ReferredBy *string    `json:"referredBy,omitempty" example:"0x142e0C7A098622Ea98E5D67034251C4dFA746B5d"`
+	ReferredAt *time.Time `json:"referredAt,omitempty" example:"2021-12-01T09:00:00Z"`
+	// Campaign is the campaign code the account entered, if it entered one instead
+	// of another user's code.
+	Campaign *string `json:"campaign,omitempty" example:"SPRING-ROADTRIP"`
+	// RetiredCodes lists the account's earlier referral codes, oldest first.
+	RetiredCodes []AccountExportRetiredCode `json:"retiredCodes"`
+	// Referred lists the accounts that this one referred, oldest first.
+	Referred []AccountExportReferred `json:"referred"`
+}
+
+type AccountExportRetiredCode struct {
+	Code      string    `json:"code" example:"ANBJN5"`
+	RetiredAt time.Time `json:"retiredAt" example:"2021-12-01T09:00:00Z"`
+	// ExpiresAt is when the code stopped, or will stop, working.
+	ExpiresAt time.Time `json:"expiresAt" example:"2021-12-08T09:00:00Z"`
+}
+
+type AccountExportReferred struct {
+	// Wallet is the referred account's primary wallet.
+	Wallet     *string   `json:"wallet" example:"0x142e0C7A098622Ea98E5D67034251C4dFA746B5d"`
+	ReferredAt time.Time `json:"referredAt" example:"2021-12-01T09:00:00Z"`
+}
+
+type AccountExportLegalAcceptance struct {
+	// Kind is one of "tos", "privacy", or "data_sharing".
+	Kind       string    `json:"kind" example:"tos"`
+	Version    string    `json:"version" example:"1"`
+	AcceptedAt time.Time `json:"acceptedAt" example:"2021-12-01T09:00:41Z"`
+}
+
+type AccountExportPreferenceChange struct {
+	// Channel is one of "marketing_email", "product_updates", or "push_notifications".
+	Channel   string    `json:"channel" example:"marketing_email"`
+	Enabled   bool      `json:"enabled" example:"true"`
+	ChangedAt time.Time `json:"changedAt" example:"2021-12-01T09:00:00Z"`
+	// Source is what made the change. "user" is the user through the API.
+	Source string `json:"source" example:"user"`
+}
+
+// AcceptDocumentsRequest lists the legal documents that the user agrees to.
+type AcceptDocumentsRequest struct {
+	Documents []AcceptDocumentsRequestDocument `json:"documents"`
+}
+
+type AcceptDocumentsRequestDocument struct {
+	// Kind is one of "tos", "privacy", or "data_sharing".
+	Kind string `json:"kind" example:"privacy"`
+	// Version must be the version currently in force.
+	Version string `json:"version" example:"1"`
+}
+
 type CompleteEmailValidation struct {
 	// Code is the 6-digit number from the confirmation email
 	Code string `json:"code" example:"010990"`
 }
 
+// LinkEmailRes reports the result of linking an email. The email is linked even
+// if CodeSent is false, in which case the client should ask for a new code.
+type LinkEmailRes struct {
+	Message  string `json:"message" example:"Linked unconfirmed email kilgore@kilgore.trout to account. A confirmation code has been sent to it."`
+	CodeSent bool   `json:"codeSent" example:"true"`
+}
+
 type ErrorRes struct {
 	Code    int    `json:"code" example:"400"`
 	Message string `json:"message" example:"Malformed request body."`
 }
 
+// ValidationErrorRes is returned when some fields of a request are invalid.
+type ValidationErrorRes struct {
+	Code    int    `json:"code" example:"400"`
+	Message string `json:"message" example:"Some fields are invalid."`
+	// Fields lists the problems, ordered by field name.
+	Fields []FieldError `json:"fields"`
+}
+
+type FieldError struct {
+	Field   string `json:"field" example:"timeZone"`
+	Message string `json:"message" example:"Unrecognized time zone \"Mars/Olympus_Mons\"."`
+}
+
+// AccountPatchResponse is the result of a merge patch.
+type AccountPatchResponse struct {
+	// Changed lists the fields whose values actually changed.
+	Changed []string     `json:"changed" example:"displayName,units"`
+	Account UserResponse `json:"account"`
+}
+
 type StandardRes struct {
 	Message string `json:"message" example:"Operation succeeded."`
 }
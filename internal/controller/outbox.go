@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/accounts-api/internal/outbox"
+	"github.com/DIMO-Network/accounts-api/internal/preferences"
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Side effects of account changes go through the outbox so that they are only
+// sent for committed work, and are retried. Confirmation codes are the exception:
+// they're sent directly so that the caller learns of failures, and we don't want
+// the plain codes sitting in the database.
+const (
+	cioSetWalletKind  = "cio.setWallet"
+	cioSetEmailKind   = "cio.setEmail"
+	cioClearEmailKind = "cio.clearEmail"
+	cioSetPrefsKind   = "cio.setPreferences"
+	eventKind         = "kafka.event"
+	emailChangedKind  = "email.changedNotification"
+)
+
+type cioWalletPayload struct {
+	Wallet common.Address `json:"wallet"`
+}
+
+type cioEmailPayload struct {
+	Wallet common.Address `json:"wallet"`
+	Email  string         `json:"email"`
+}
+
+// cioPrefsPayload identifies the profile by wallet or, for accounts without one,
+// by email.
+type cioPrefsPayload struct {
+	Wallet      *common.Address `json:"wallet,omitempty"`
+	Email       string          `json:"email,omitempty"`
+	Preferences map[string]bool `json:"preferences"`
+}
+
+type emailChangedPayload struct {
+	OldEmail string `json:"oldEmail"`
+	NewEmail string `json:"newEmail"`
+}
+
+// OutboxHandlers returns the handlers for every kind of message the controller
+// enqueues.
+func (d *Controller) OutboxHandlers() map[string]outbox.Handler {
+	return map[string]outbox.Handler{
+		cioSetWalletKind: func(ctx context.Context, payload []byte) error {
+			var p cioWalletPayload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return err
+			}
+			return d.cioService.SetWallet(ctx, p.Wallet)
+		},
+		cioSetEmailKind: func(ctx context.Context, payload []byte) error {
+			var p cioEmailPayload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return err
+			}
+			return d.cioService.SetEmail(ctx, p.Wallet, p.Email)
+		},
+		cioClearEmailKind: func(ctx context.Context, payload []byte) error {
+			var p cioWalletPayload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return err
+			}
+			return d.cioService.ClearEmail(ctx, p.Wallet)
+		},
+		cioSetPrefsKind: func(ctx context.Context, payload []byte) error {
+			var p cioPrefsPayload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return err
+			}
+			if p.Wallet == nil {
+				return d.cioService.SetEmailPreferences(ctx, p.Email, p.Preferences)
+			}
+			return d.cioService.SetPreferences(ctx, *p.Wallet, p.Preferences)
+		},
+		eventKind: func(ctx context.Context, payload []byte) error {
+			var e events.Event
+			if err := json.Unmarshal(payload, &e); err != nil {
+				return err
+			}
+			return d.eventProducer.Emit(ctx, &e)
+		},
+		emailChangedKind: func(ctx context.Context, payload []byte) error {
+			var p emailChangedPayload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return err
+			}
+			return d.emailService.SendEmailChangedNotification(ctx, d.changedTemplate, p.OldEmail, p.NewEmail)
+		},
+	}
+}
+
+// cioKey orders the updates to a Customer.io profile, so that, say, clearing an
+// email can't overtake setting it.
+func cioKey(wallet common.Address) string {
+	return "cio:" + wallet.Hex()
+}
+
+// emitEvent queues an event about the account for Kafka. Events for an account
+// are delivered in order, matching the partitioning by account on the topic.
+func (d *Controller) emitEvent(ctx context.Context, exec boil.ContextExecutor, eventType, accountID string, data any) error {
+	return outbox.Enqueue(ctx, exec, eventKind, accountID, events.New(eventType, accountID, data))
+}
+
+// syncWallet queues creating or updating the wallet's Customer.io profile.
+func (d *Controller) syncWallet(ctx context.Context, exec boil.ContextExecutor, wallet common.Address) error {
+	return outbox.Enqueue(ctx, exec, cioSetWalletKind, cioKey(wallet), cioWalletPayload{Wallet: wallet})
+}
+
+// syncEmail queues setting the email trait on the wallet's Customer.io profile.
+func (d *Controller) syncEmail(ctx context.Context, exec boil.ContextExecutor, wallet common.Address, email string) error {
+	return outbox.Enqueue(ctx, exec, cioSetEmailKind, cioKey(wallet), cioEmailPayload{Wallet: wallet, Email: email})
+}
+
+// clearEmail queues removing the email trait from the wallet's Customer.io profile.
+func (d *Controller) clearEmail(ctx context.Context, exec boil.ContextExecutor, wallet common.Address) error {
+	return outbox.Enqueue(ctx, exec, cioClearEmailKind, cioKey(wallet), cioWalletPayload{Wallet: wallet})
+}
+
+// syncPreferences queues setting the communication preference traits on the
+// wallet's Customer.io profile.
+func (d *Controller) syncPreferences(ctx context.Context, exec boil.ContextExecutor, wallet common.Address, prefs []*models.CommunicationPreference) error {
+	return outbox.Enqueue(ctx, exec, cioSetPrefsKind, cioKey(wallet), cioPrefsPayload{Wallet: &wallet, Preferences: preferences.Enabled(prefs)})
+}
+
+// syncAccountPreferences queues setting the communication preference traits on
+// the account's Customer.io profile. That's the primary wallet's profile or, for
+// accounts without a wallet, the one for the email. Accounts with neither have
+// no profile to update.
+func (d *Controller) syncAccountPreferences(ctx context.Context, exec boil.ContextExecutor, acct *models.Account) error {
+	if wallet := primaryWallet(acct); wallet != nil {
+		return d.syncPreferences(ctx, exec, common.BytesToAddress(wallet.Address), acct.R.CommunicationPreferences)
+	}
+
+	if acct.R.Email == nil {
+		return nil
+	}
+
+	email := acct.R.Email.Address
+	return outbox.Enqueue(ctx, exec, cioSetPrefsKind, "cio:"+email, cioPrefsPayload{Email: email, Preferences: preferences.Enabled(acct.R.CommunicationPreferences)})
+}
+
+// notifyEmailChanged queues telling the old address that the account's email has
+// changed.
+func (d *Controller) notifyEmailChanged(ctx context.Context, exec boil.ContextExecutor, oldEmail, newEmail string) error {
+	return outbox.Enqueue(ctx, exec, emailChangedKind, "", emailChangedPayload{OldEmail: oldEmail, NewEmail: newEmail})
+}
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/null/v8"
+)
+
+// mergePatchContentType is the media type of RFC 7396 JSON merge patches.
+const mergePatchContentType = "application/merge-patch+json"
+
+// PatchAccount godoc
+// @Summary Modify attributes for the authenticated user using a JSON merge patch (RFC 7396). Fields set to null are cleared; omitted fields are left alone.
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param userUpdateRequest body controller.UserUpdateRequest true "Fields to set, or null to clear"
+// @Param If-Match header string false "Entity tag from an earlier response; the patch fails unless it's still current"
+// @Success 200 {object} controller.AccountPatchResponse
+// @Failure 400 {object} controller.ValidationErrorRes
+// @Failure 403 {object} controller.ErrorRes
+// @Failure 412 {object} controller.ErrorRes "Returned if the account has changed since the If-Match tag was issued."
+// @Failure 415 {object} controller.ErrorRes
+// @Security BearerAuth
+// @Router /v1/account [patch]
+func (d *Controller) PatchAccount(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	contentType := strings.ToLower(strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0]))
+	if contentType != mergePatchContentType && contentType != fiber.MIMEApplicationJSON {
+		return fiber.NewError(fiber.StatusUnsupportedMediaType, "Content type must be "+mergePatchContentType+".")
+	}
+
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(c.Body(), &patch); err != nil || patch == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Request body must be a JSON object.")
+	}
+
+	fields := d.editableFields()
+
+	// Validate every field so that the user sees all the problems at once.
+	updates := make(map[string]null.String, len(patch))
+	var fieldErrs []FieldError
+	for name, raw := range patch {
+		i := slices.IndexFunc(fields, func(f editableField) bool { return f.name == name })
+		if i == -1 {
+			fieldErrs = append(fieldErrs, FieldError{Field: name, Message: "Unrecognized field."})
+			continue
+		}
+
+		var value *string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			fieldErrs = append(fieldErrs, FieldError{Field: name, Message: "Must be a string or null."})
+			continue
+		}
+
+		if value == nil {
+			updates[name] = null.String{}
+			continue
+		}
+
+		v, err := fields[i].validate(*value)
+		if err != nil {
+			fieldErrs = append(fieldErrs, FieldError{Field: name, Message: err.Error()})
+			continue
+		}
+		updates[name] = null.StringFrom(v)
+	}
+
+	if len(fieldErrs) != 0 {
+		slices.SortFunc(fieldErrs, func(a, b FieldError) int { return strings.Compare(a.Field, b.Field) })
+		return c.Status(fiber.StatusBadRequest).JSON(ValidationErrorRes{
+			Code:    fiber.StatusBadRequest,
+			Message: "Some fields are invalid.",
+			Fields:  fieldErrs,
+		})
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccountForUpdate(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	if err := d.checkIfMatch(c, tx, acct); err != nil {
+		return err
+	}
+
+	changed, err := d.saveAccountFields(c.Context(), tx, acct, updates)
+	if err != nil {
+		return err
+	}
+
+	if len(changed) != 0 {
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		logger.Info().Strs("fields", changed).Msg("Patched account.")
+	}
+
+	userResp, err := d.formatUserAcctResponse(c.Context(), d.dbs.DBS().Reader, acct)
+	if err != nil {
+		return err
+	}
+
+	if changed == nil {
+		changed = []string{}
+	}
+
+	c.Set(fiber.HeaderETag, accountETag(userResp))
+
+	return c.JSON(AccountPatchResponse{Changed: changed, Account: *userResp})
+}
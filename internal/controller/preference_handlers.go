@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"github.com/DIMO-Network/accounts-api/internal/preferences"
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// GetPreferences godoc
+// @Summary Get the authenticated user's communication preferences
+// @Produce json
+// @Success 200 {object} controller.CommunicationPreferences
+// @Failure 403 {object} controller.ErrorRes
+// @Security BearerAuth
+// @Router /v1/account/preferences [get]
+func (d *Controller) GetPreferences(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, d.dbs.DBS().Reader)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(formatPreferences(acct.R.CommunicationPreferences))
+}
+
+// UpdatePreferences godoc
+// @Summary Change some of the authenticated user's communication preferences
+// @Accept json
+// @Produce json
+// @Param communicationPreferencesUpdate body controller.CommunicationPreferencesUpdate true "New values for the preferences to change"
+// @Success 200 {object} controller.CommunicationPreferences
+// @Failure 400 {object} controller.ErrorRes
+// @Failure 403 {object} controller.ErrorRes
+// @Security BearerAuth
+// @Router /v1/account/preferences [put]
+func (d *Controller) UpdatePreferences(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	var body CommunicationPreferencesUpdate
+	if err := c.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	changes := []struct {
+		channel string
+		enabled *bool
+	}{
+		{preferences.MarketingEmail, body.MarketingEmail},
+		{preferences.ProductUpdates, body.ProductUpdates},
+		{preferences.PushNotifications, body.PushNotifications},
+	}
+
+	var changed []string
+	for _, ch := range changes {
+		if ch.enabled == nil {
+			continue
+		}
+
+		pref := preferences.Find(acct.R.CommunicationPreferences, ch.channel)
+		if pref != nil && pref.Enabled == *ch.enabled {
+			continue
+		}
+
+		if pref == nil {
+			pref = &models.CommunicationPreference{AccountID: acct.ID, Channel: ch.channel}
+			acct.R.CommunicationPreferences = append(acct.R.CommunicationPreferences, pref)
+		}
+		pref.Enabled = *ch.enabled
+
+		if err := pref.Upsert(c.Context(), tx, true,
+			[]string{models.CommunicationPreferenceColumns.AccountID, models.CommunicationPreferenceColumns.Channel},
+			boil.Whitelist(models.CommunicationPreferenceColumns.Enabled, models.CommunicationPreferenceColumns.UpdatedAt),
+			boil.Infer(),
+		); err != nil {
+			return err
+		}
+
+		// Keep a record of the change as evidence of consent.
+		change := models.CommunicationPreferenceChange{
+			AccountID: acct.ID,
+			Channel:   ch.channel,
+			Enabled:   pref.Enabled,
+			ChangedAt: pref.UpdatedAt,
+			Source:    preferences.SourceUser,
+		}
+		if err := change.Insert(c.Context(), tx, boil.Infer()); err != nil {
+			return err
+		}
+
+		changed = append(changed, ch.channel)
+	}
+
+	if len(changed) != 0 {
+		if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.UpdatedAt)); err != nil {
+			return err
+		}
+
+		if err := d.syncAccountPreferences(c.Context(), tx, acct); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		logger.Info().Strs("channels", changed).Msg("Updated communication preferences.")
+	}
+
+	return c.JSON(formatPreferences(acct.R.CommunicationPreferences))
+}
+
+func formatPreferences(prefs []*models.CommunicationPreference) CommunicationPreferences {
+	format := func(channel string) CommunicationPreference {
+		pref := preferences.Find(prefs, channel)
+		if pref == nil {
+			return CommunicationPreference{}
+		}
+		return CommunicationPreference{Enabled: pref.Enabled, UpdatedAt: &pref.UpdatedAt}
+	}
+
+	return CommunicationPreferences{
+		MarketingEmail:    format(preferences.MarketingEmail),
+		ProductUpdates:    format(preferences.ProductUpdates),
+		PushNotifications: format(preferences.PushNotifications),
+	}
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata" // Don't depend on the image having a zoneinfo database.
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"golang.org/x/text/language"
+)
+
+const maxDisplayNameLength = 64
+
+// Preferred units of measure.
+const (
+	metricUnits   = "metric"
+	imperialUnits = "imperial"
+)
+
+// editableField is an account column that users can set or clear directly.
+type editableField struct {
+	// name is the field's name in requests and responses.
+	name     string
+	column   string
+	validate func(string) (string, error)
+	value    func(*models.Account) *null.String
+}
+
+func (d *Controller) editableFields() []editableField {
+	return []editableField{
+		{"countryCode", models.AccountColumns.CountryCode, d.validateCountryCode, func(a *models.Account) *null.String { return &a.CountryCode }},
+		{"displayName", models.AccountColumns.DisplayName, validateDisplayName, func(a *models.Account) *null.String { return &a.DisplayName }},
+		{"language", models.AccountColumns.Language, validateLanguage, func(a *models.Account) *null.String { return &a.Language }},
+		{"timeZone", models.AccountColumns.TimeZone, validateTimeZone, func(a *models.Account) *null.String { return &a.TimeZone }},
+		{"units", models.AccountColumns.Units, validateUnits, func(a *models.Account) *null.String { return &a.Units }},
+	}
+}
+
+// saveAccountFields applies validated updates, keyed by field name, to the
+// account. A null value clears the field. It returns the names of the fields that
+// actually changed, in the order of editableFields.
+func (d *Controller) saveAccountFields(ctx context.Context, tx *sql.Tx, acct *models.Account, updates map[string]null.String) ([]string, error) {
+	oldCountryCode := acct.CountryCode
+
+	var changed, columns []string
+	for _, f := range d.editableFields() {
+		update, ok := updates[f.name]
+		if !ok {
+			continue
+		}
+
+		current := f.value(acct)
+		if current.Valid == update.Valid && current.String == update.String {
+			continue
+		}
+
+		*current = update
+		changed = append(changed, f.name)
+		columns = append(columns, f.column)
+	}
+
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	if _, err := acct.Update(ctx, tx, boil.Whitelist(append(columns, models.AccountColumns.UpdatedAt)...)); err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(columns, models.AccountColumns.CountryCode) {
+		if err := d.emitEvent(ctx, tx, events.CountryChangedType, acct.ID, events.CountryChanged{
+			OldCountryCode: oldCountryCode.String,
+			CountryCode:    acct.CountryCode.String,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return changed, nil
+}
+
+// The validators below return the value to store, or an error whose message can
+// be shown to the user.
+
+var countryCodePattern = regexp.MustCompile("^[A-Z]{3}$")
+
+func (d *Controller) validateCountryCode(s string) (string, error) {
+	if !countryCodePattern.MatchString(s) {
+		return "", fmt.Errorf("Unrecognized country code %q. Country codes consist of three capital letters.", s)
+	}
+
+	if !slices.Contains(d.countryCodes, s) {
+		return "", fmt.Errorf("Unrecognized country code %q.", s)
+	}
+
+	return s, nil
+}
+
+func validateDisplayName(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", errors.New("Display name can't be blank.")
+	}
+
+	if n := utf8.RuneCountInString(s); n > maxDisplayNameLength {
+		return "", fmt.Errorf("Display name is %d characters long, but the limit is %d.", n, maxDisplayNameLength)
+	}
+
+	if strings.IndexFunc(s, unicode.IsControl) != -1 {
+		return "", errors.New("Display name can't contain control characters.")
+	}
+
+	return s, nil
+}
+
+// validateLanguage accepts BCP 47 language tags and returns them in canonical
+// form.
+func validateLanguage(s string) (string, error) {
+	tag, err := language.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("Unrecognized language tag %q. Use a BCP 47 tag such as \"en-US\".", s)
+	}
+	return tag.String(), nil
+}
+
+// validateTimeZone accepts IANA time zone names.
+func validateTimeZone(s string) (string, error) {
+	// LoadLocation treats these specially.
+	if s == "" || s == "Local" {
+		return "", fmt.Errorf("Unrecognized time zone %q.", s)
+	}
+
+	if _, err := time.LoadLocation(s); err != nil {
+		return "", fmt.Errorf("Unrecognized time zone %q. Use an IANA time zone name such as \"America/New_York\".", s)
+	}
+	return s, nil
+}
+
+func validateUnits(s string) (string, error) {
+	if s != metricUnits && s != imperialUnits {
+		return "", fmt.Errorf("Unrecognized units %q. Units must be %q or %q.", s, metricUnits, imperialUnits)
+	}
+	return s, nil
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/internal/outbox"
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// purgeBatchSize is the number of accounts deleted per transaction.
+const purgeBatchSize = 100
+
+// PurgeDeletedAccounts permanently deletes the accounts whose deletion grace
+// period has ended, and returns how many were deleted. The AccountDeletedType
+// events are left in the outbox for the server to deliver.
+func PurgeDeletedAccounts(ctx context.Context, dbs db.Store, logger *zerolog.Logger) (int, error) {
+	total := 0
+	for {
+		n, err := purgeBatch(ctx, dbs, logger)
+		if err != nil {
+			return total, err
+		}
+		total += n
+
+		if n < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func purgeBatch(ctx context.Context, dbs db.Store, logger *zerolog.Logger) (int, error) {
+	tx, err := dbs.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback() //nolint
+
+	now := time.Now()
+
+	accts, err := models.Accounts(
+		models.AccountWhere.PurgeAt.LTE(null.TimeFrom(now)),
+		qm.OrderBy(models.AccountColumns.PurgeAt),
+		qm.Limit(purgeBatchSize),
+		qm.For("UPDATE SKIP LOCKED"),
+	).All(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, acct := range accts {
+		// Keep the referee in the referrer's totals.
+		if acct.ReferredBy.Valid {
+			_, err := queries.Raw(fmt.Sprintf("UPDATE %[1]s SET %[2]s = %[2]s + 1 WHERE %[3]s = $1",
+				models.TableNames.Accounts,
+				models.AccountColumns.PurgedReferees,
+				models.AccountColumns.ID,
+			), acct.ReferredBy.String).ExecContext(ctx, tx)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		// Keep the account's code reserved once it's gone. Its earlier codes
+		// already are.
+		retired := models.RetiredReferralCode{
+			Code:      acct.ReferralCode,
+			RetiredAt: now,
+			ExpiresAt: now,
+		}
+		if err := retired.Insert(ctx, tx, boil.Infer()); err != nil {
+			return 0, err
+		}
+
+		if _, err := acct.Delete(ctx, tx); err != nil {
+			return 0, err
+		}
+
+		if err := outbox.Enqueue(ctx, tx, eventKind, acct.ID, events.New(events.AccountDeletedType, acct.ID, events.AccountDeleted{})); err != nil {
+			return 0, err
+		}
+
+		logger.Info().Str("account", acct.ID).Msgf("Purged account whose deletion was requested at %s.", acct.DeletedAt.Time.Format(time.RFC3339))
+	}
+
+	return len(accts), tx.Commit()
+}
@@ -3,11 +3,16 @@ package controller
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"math/rand/v2"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/DIMO-Network/accounts-api/internal/campaigns"
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
 	"github.com/DIMO-Network/accounts-api/models"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
@@ -29,7 +34,14 @@ func (d *Controller) GenerateReferralCode(ctx context.Context) (string, error) {
 
 		if exists, err := models.Accounts(models.AccountWhere.ReferralCode.EQ(code)).Exists(ctx, d.dbs.DBS().Reader); err != nil {
 			return "", err
-		} else if !exists {
+		} else if exists {
+			continue
+		}
+
+		// Never hand out a code that someone else used to have.
+		if retired, err := models.RetiredReferralCodeExists(ctx, d.dbs.DBS().Reader, code); err != nil {
+			return "", err
+		} else if !retired {
 			return code, nil
 		}
 	}
@@ -37,9 +49,10 @@ func (d *Controller) GenerateReferralCode(ctx context.Context) (string, error) {
 
 // SubmitReferralCode godoc
 // @Summary Takes the referral code, validates and stores it
-// @Param submitReferralCodeRequest body controller.SubmitReferralCodeRequest true "ReferralCode is the 6-digit, alphanumeric referral code from another user."
+// @Param submitReferralCodeRequest body controller.SubmitReferralCodeRequest true "Code is either the 6-digit, alphanumeric referral code from another user, or a campaign code."
 // @Success 200 {object} controller.StandardRes
 // @Failure 400 {object} controller.ErrorRes
+// @Failure 409 {object} controller.ErrorRes "Returned if the account's wallets changed while the code was being checked."
 // @Failure 500 {object} controller.ErrorRes
 // @Tags referral
 // @Router /v1/account/referral/submit [post]
@@ -50,13 +63,14 @@ func (d *Controller) SubmitReferralCode(c *fiber.Ctx) error {
 		return err
 	}
 
-	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
-	if err != nil {
-		return err
+	var body SubmitReferralCodeRequest
+	if err := c.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
 	}
-	defer tx.Rollback() //nolint
 
-	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	// Check everything that doesn't need locks first, so that nothing is held
+	// while we wait on identity-api.
+	acct, err := d.getUserAccount(c.Context(), userAccount, d.dbs.DBS().Reader)
 	if err != nil {
 		return err
 	}
@@ -68,50 +82,81 @@ func (d *Controller) SubmitReferralCode(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Already entered a referral code.")
 	}
 
-	var body SubmitReferralCodeRequest
-	if err := c.BodyParser(&body); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	if deadline := d.referralDeadline(acct); time.Now().After(deadline) {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Referral codes had to be entered by %s.", deadline.Format(time.RFC3339)))
 	}
 
 	logger.Info().Msgf("Got referral code %s.", body.Code)
 	referralCode := body.Code
-	if !referralCodeRegex.MatchString(referralCode) {
-		return fiber.NewError(fiber.StatusBadRequest, "Referral code must consist of 6 digits and upper-case letters.")
+
+	if primaryWallet(acct) == nil {
+		return fmt.Errorf("referred user %s has no wallet", acct.ID)
 	}
 
-	refAcct, err := models.Accounts(
-		models.AccountWhere.ReferralCode.EQ(referralCode),
-		qm.Load(models.AccountRels.Wallet),
-	).One(c.Context(), tx)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return fiber.NewError(fiber.StatusBadRequest, "No user with that referral code found.")
+	isUserCode := referralCodeRegex.MatchString(referralCode)
+	if !isUserCode {
+		referralCode = campaigns.NormalizeCode(referralCode)
+		if !campaigns.CodePattern.MatchString(referralCode) {
+			return fiber.NewError(fiber.StatusBadRequest, "Referral code must be another user's code, 6 digits and upper-case letters, or a campaign code.")
 		}
+	}
+
+	if err := d.checkNewOwner(c.Context(), acct.R.Wallets); err != nil {
 		return err
 	}
 
-	referrer := refAcct.R.Wallet
-	if referrer == nil {
-		return fmt.Errorf("referring user %s has no wallet", refAcct.ID)
+	checkedWallets := acct.R.Wallets
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback() //nolint
 
-	referree := acct.R.Wallet
-	if referree == nil {
-		return fmt.Errorf("referred user %s has no wallet", acct.ID)
+	acct, err = d.getUserAccountForUpdate(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
 	}
 
-	if common.BytesToAddress(referree.Address) == common.BytesToAddress(referrer.Address) {
-		return fiber.NewError(fiber.StatusBadRequest, "User and referrer have the same Ethereum address.")
+	if acct.ReferredAt.Valid {
+		return fiber.NewError(fiber.StatusBadRequest, "Already entered a referral code.")
 	}
 
-	// No circular referrals.
-	if refAcct.ReferredBy.Valid && refAcct.ReferredBy.String == acct.ID {
-		return fiber.NewError(fiber.StatusBadRequest, "Referrer was referred by this user.")
+	if !sameWallets(checkedWallets, acct.R.Wallets) {
+		return fiber.NewError(fiber.StatusConflict, "Account's wallets changed while the code was being checked. Try again.")
+	}
+
+	submitted := events.ReferralSubmitted{ReferralCode: referralCode}
+
+	if isUserCode {
+		refAcct, err := d.findReferrer(c.Context(), tx, acct, referralCode)
+		if err != nil {
+			return err
+		}
+
+		acct.ReferredBy = null.StringFrom(refAcct.ID)
+		submitted.ReferrerAccountID = refAcct.ID
+	} else {
+		campaign, err := findCampaign(c.Context(), tx, referralCode)
+		if err != nil {
+			return err
+		}
+
+		acct.ReferralCampaignID = null.StringFrom(campaign.ID)
+		submitted.CampaignID = campaign.ID
+
+		campaign.Uses++
+		if _, err := campaign.Update(c.Context(), tx, boil.Whitelist(models.ReferralCampaignColumns.Uses)); err != nil {
+			return err
+		}
 	}
 
-	acct.ReferredBy = null.StringFrom(refAcct.ID)
 	acct.ReferredAt = null.TimeFrom(time.Now())
-	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.ReferredBy, models.AccountColumns.ReferredAt, models.AccountColumns.UpdatedAt)); err != nil {
+	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.ReferredBy, models.AccountColumns.ReferralCampaignID, models.AccountColumns.ReferredAt, models.AccountColumns.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := d.emitEvent(c.Context(), tx, events.ReferralSubmittedType, acct.ID, submitted); err != nil {
 		return err
 	}
 
@@ -123,3 +168,452 @@ func (d *Controller) SubmitReferralCode(c *fiber.Ctx) error {
 		Message: "Referral code successfully submitted.",
 	})
 }
+
+// checkNewOwner fails with 400 if any of the wallets already owns a vehicle or an
+// aftermarket device. Referral bonuses are only for new owners.
+func (d *Controller) checkNewOwner(ctx context.Context, wallets []*models.Wallet) error {
+	for _, w := range wallets {
+		addr := common.BytesToAddress(w.Address)
+
+		if owns, err := d.identityService.VehiclesOwned(ctx, addr); err != nil {
+			return fmt.Errorf("failed to check vehicles owned by %s: %w", addr, err)
+		} else if owns {
+			return fiber.NewError(fiber.StatusBadRequest, "User already owns a vehicle.")
+		}
+
+		if owns, err := d.identityService.AftermarketDevicesOwned(ctx, addr); err != nil {
+			return fmt.Errorf("failed to check aftermarket devices owned by %s: %w", addr, err)
+		} else if owns {
+			return fiber.NewError(fiber.StatusBadRequest, "User already owns an aftermarket device.")
+		}
+	}
+	return nil
+}
+
+// sameWallets reports whether the two lists hold the same addresses.
+func sameWallets(a, b []*models.Wallet) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	seen := make(map[common.Address]struct{}, len(a))
+	for _, w := range a {
+		seen[common.BytesToAddress(w.Address)] = struct{}{}
+	}
+	for _, w := range b {
+		if _, ok := seen[common.BytesToAddress(w.Address)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// findReferrer returns the account, with its wallets, whose referral code this
+// is, after checking that it can refer acct.
+func (d *Controller) findReferrer(ctx context.Context, tx *sql.Tx, acct *models.Account, code string) (*models.Account, error) {
+	refAcct, err := models.Accounts(
+		models.AccountWhere.ReferralCode.EQ(code),
+		models.AccountWhere.DeletedAt.IsNull(),
+		qm.Load(models.AccountRels.Wallets),
+	).One(ctx, tx)
+	if errors.Is(err, sql.ErrNoRows) {
+		refAcct, err = findRetiredCodeOwner(ctx, tx, code)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "No user with that referral code found.")
+		}
+		return nil, err
+	}
+
+	referrer := primaryWallet(refAcct)
+	if referrer == nil {
+		return nil, fmt.Errorf("referring user %s has no wallet", refAcct.ID)
+	}
+
+	if common.BytesToAddress(primaryWallet(acct).Address) == common.BytesToAddress(referrer.Address) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "User and referrer have the same Ethereum address.")
+	}
+
+	if err := d.checkReferralChain(ctx, tx, acct.ID, refAcct.ID); err != nil {
+		return nil, err
+	}
+
+	return refAcct, nil
+}
+
+// findRetiredCodeOwner returns the account, with its wallets, that recently
+// replaced the code, as long as the code is still in its grace period.
+func findRetiredCodeOwner(ctx context.Context, tx *sql.Tx, code string) (*models.Account, error) {
+	retired, err := models.RetiredReferralCodes(
+		models.RetiredReferralCodeWhere.Code.EQ(code),
+		models.RetiredReferralCodeWhere.ExpiresAt.GT(time.Now()),
+		qm.Load(qm.Rels(models.RetiredReferralCodeRels.Account, models.AccountRels.Wallets)),
+	).One(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The account may have since been purged, or be on its way.
+	if retired.R.Account == nil || retired.R.Account.DeletedAt.Valid {
+		return nil, sql.ErrNoRows
+	}
+
+	return retired.R.Account, nil
+}
+
+// findCampaign returns the campaign with the normalized code, if it's accepting
+// submissions. The campaign row stays locked until the transaction ends, so that
+// concurrent submissions can't exceed its cap. That blocks every other
+// submission of the code, so make slow checks before calling it.
+func findCampaign(ctx context.Context, tx *sql.Tx, code string) (*models.ReferralCampaign, error) {
+	campaign, err := models.ReferralCampaigns(
+		models.ReferralCampaignWhere.Code.EQ(code),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "No campaign with that code found.")
+		}
+		return nil, err
+	}
+
+	switch err := campaigns.Available(campaign, time.Now()); {
+	case errors.Is(err, campaigns.ErrNotStarted):
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Campaign code %s can't be used until %s.", campaign.Code, campaign.StartsAt.Time.Format(time.RFC3339)))
+	case errors.Is(err, campaigns.ErrEnded):
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Campaign code %s expired at %s.", campaign.Code, campaign.EndsAt.Time.Format(time.RFC3339)))
+	case errors.Is(err, campaigns.ErrUsedUp):
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Campaign code %s has been used the maximum number of times.", campaign.Code))
+	case err != nil:
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// RegenerateReferralCode godoc
+// @Summary Replace the authenticated user's referral code with a new one. The old code keeps working for a grace period unless disableImmediately is set. Past referrals stay with the account.
+// @Accept json
+// @Produce json
+// @Param regenerateReferralCodeRequest body controller.RegenerateReferralCodeRequest false "Whether to stop accepting the old code right away"
+// @Success 200 {object} controller.RegenerateReferralCodeResponse
+// @Failure 400 {object} controller.ErrorRes
+// @Failure 403 {object} controller.ErrorRes
+// @Failure 429 {object} controller.ErrorRes "Returned if the code was replaced within the cooldown. Retry-After says when to try again."
+// @Security BearerAuth
+// @Tags referral
+// @Router /v1/account/referral/regenerate [post]
+func (d *Controller) RegenerateReferralCode(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	var body RegenerateReferralCodeRequest
+	if len(c.Body()) != 0 {
+		if err := c.BodyParser(&body); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+		}
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	// Lock the account so that concurrent requests don't both retire the same code.
+	acct, err := d.getUserAccountForUpdate(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	now := time.Now()
+
+	// Every regeneration reserves another code forever, so don't let it happen
+	// too often.
+	last, err := acct.RetiredReferralCodes(qm.OrderBy(models.RetiredReferralCodeColumns.RetiredAt+" DESC")).One(c.Context(), tx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if last != nil {
+		if wait := last.RetiredAt.Add(d.regenCooldown).Sub(now); wait > 0 {
+			setRetryAfter(c, wait)
+			return fiber.NewError(fiber.StatusTooManyRequests, "Referral code was replaced recently. Try again later.")
+		}
+	}
+
+	code, err := d.GenerateReferralCode(c.Context())
+	if err != nil {
+		return err
+	}
+
+	retired := models.RetiredReferralCode{
+		Code:      acct.ReferralCode,
+		AccountID: null.StringFrom(acct.ID),
+		RetiredAt: now,
+		ExpiresAt: now,
+	}
+	if !body.DisableImmediately {
+		retired.ExpiresAt = now.Add(d.retiredGrace)
+	}
+
+	if err := retired.Insert(c.Context(), tx, boil.Infer()); err != nil {
+		return err
+	}
+
+	acct.ReferralCode = code
+	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.ReferralCode, models.AccountColumns.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := d.emitEvent(c.Context(), tx, events.ReferralCodeChangedType, acct.ID, events.ReferralCodeChanged{
+		OldCode:          retired.Code,
+		Code:             code,
+		OldCodeExpiresAt: retired.ExpiresAt,
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Replaced referral code %s with %s.", retired.Code, code)
+	return c.JSON(RegenerateReferralCodeResponse{
+		Code:                 code,
+		RetiredCode:          retired.Code,
+		RetiredCodeExpiresAt: retired.ExpiresAt,
+	})
+}
+
+// referralDeadline returns the last moment at which the account can submit a
+// referral code. The window opens when the account's first wallet is linked,
+// since the code can't be submitted before then, and doesn't move if wallets are
+// later replaced. Until then it's counted from the account's creation.
+func (d *Controller) referralDeadline(acct *models.Account) time.Time {
+	opened := acct.CreatedAt
+	if acct.FirstWalletLinkedAt.Valid {
+		opened = acct.FirstWalletLinkedAt.Time
+	}
+	return opened.Add(d.referralWindow)
+}
+
+// referralLockSpace namespaces the per-account advisory locks taken while
+// submitting a referral code.
+const referralLockSpace = 0x72656672
+
+// checkReferralChain fails with 400 if having the account referred by the
+// referrer would create a cycle in the referral tree, or make the chain of
+// referrers above the account longer than the configured limit.
+//
+// A new referral can only close a cycle through the account at the top of the
+// referrer's chain, since that's the only one in the chain that can still be
+// referred. So rather than serializing every submission, the transaction takes
+// advisory locks on the submitting account and on that root until it ends. Two
+// submissions that together would close a loop each need the other's account,
+// and so can't pass the check at the same time.
+func (d *Controller) checkReferralChain(ctx context.Context, tx *sql.Tx, accountID, referrerID string) error {
+	locked := make(map[string]bool)
+
+	for {
+		root, err := d.walkReferralChain(ctx, tx, accountID, referrerID)
+		if err != nil {
+			return err
+		}
+
+		var need []string
+		for _, id := range []string{accountID, root} {
+			if !locked[id] {
+				need = append(need, id)
+			}
+		}
+
+		// The chain was read with both locks held, so it can't have moved.
+		if len(need) == 0 {
+			return nil
+		}
+
+		// Lock in a consistent order so that overlapping submissions don't
+		// deadlock.
+		slices.Sort(need)
+		for _, id := range need {
+			if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, referralLockSpace, id); err != nil {
+				return err
+			}
+			locked[id] = true
+		}
+	}
+}
+
+// walkReferralChain walks up from the referrer, failing with 400 if it reaches
+// the account or passes the depth limit, and returns the account at the top.
+func (d *Controller) walkReferralChain(ctx context.Context, tx *sql.Tx, accountID, referrerID string) (string, error) {
+	// The depth bound also ends the walk if the existing data already contains
+	// a cycle.
+	var cycle, tooDeep bool
+	var root string
+	err := tx.QueryRowContext(ctx, fmt.Sprintf(`
+		WITH RECURSIVE ancestors (id, referred_by, depth) AS (
+			SELECT %[2]s, %[3]s, 1
+			FROM %[1]s
+			WHERE %[2]s = $1
+		UNION ALL
+			SELECT a.%[2]s, a.%[3]s, p.depth + 1
+			FROM %[1]s a
+			JOIN ancestors p ON a.%[2]s = p.referred_by
+			WHERE p.depth < $3 AND p.id <> $2
+		)
+		SELECT coalesce(bool_or(id = $2), false),
+			coalesce(bool_or(depth = $3 AND referred_by IS NOT NULL), false),
+			(SELECT id FROM ancestors ORDER BY depth DESC LIMIT 1)
+		FROM ancestors`,
+		models.TableNames.Accounts,
+		models.AccountColumns.ID,
+		models.AccountColumns.ReferredBy,
+	),
+		referrerID, accountID, d.referralDepth,
+	).Scan(&cycle, &tooDeep, &root)
+	if err != nil {
+		return "", err
+	}
+
+	if cycle {
+		return "", fiber.NewError(fiber.StatusBadRequest, "Referrer was referred, directly or indirectly, by this user.")
+	}
+
+	if tooDeep {
+		return "", fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Referrer's chain of referrals is longer than the limit of %d.", d.referralDepth))
+	}
+
+	return root, nil
+}
+
+// Page sizes for the referee listing.
+const (
+	defaultRefereePageSize = 20
+	maxRefereePageSize     = 100
+)
+
+// GetReferrals godoc
+// @Summary List the accounts that used the authenticated user's referral code, most recent first
+// @Produce json
+// @Param limit query int false "Page size, at most 100" default(20)
+// @Param cursor query string false "The nextCursor from the previous page"
+// @Success 200 {object} controller.ReferralsResponse
+// @Failure 400 {object} controller.ErrorRes
+// @Failure 403 {object} controller.ErrorRes
+// @Security BearerAuth
+// @Tags referral
+// @Router /v1/account/referrals [get]
+func (d *Controller) GetReferrals(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	limit := c.QueryInt("limit", defaultRefereePageSize)
+	if limit < 1 || limit > maxRefereePageSize {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d.", maxRefereePageSize))
+	}
+
+	mods := []qm.QueryMod{
+		qm.Load(models.AccountRels.Wallets),
+		qm.OrderBy(models.AccountColumns.ReferredAt + " DESC, " + models.AccountColumns.ID + " DESC"),
+		qm.Limit(limit + 1),
+	}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		referredAt, id, err := parseRefereeCursor(cursor)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid cursor.")
+		}
+		mods = append(mods, qm.Where("("+models.AccountColumns.ReferredAt+", "+models.AccountColumns.ID+") < (?, ?)", referredAt, id))
+	}
+
+	// Read the counts and the page from one snapshot.
+	tx, err := d.dbs.DBS().Reader.BeginTx(c.Context(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	// Every referee still in the table, including those scheduled for deletion.
+	unpurged, err := acct.ReferredByAccounts().Count(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := acct.ReferredByAccounts(models.AccountWhere.DeletedAt.IsNotNull()).Count(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	referees, err := acct.ReferredByAccounts(mods...).All(c.Context(), tx)
+	if err != nil {
+		return err
+	}
+
+	out := ReferralsResponse{
+		Counts: ReferralCounts{
+			Total:   unpurged + int64(acct.PurgedReferees),
+			Deleted: deleted,
+			Purged:  int64(acct.PurgedReferees),
+		},
+		Referees: []Referee{},
+	}
+
+	if len(referees) > limit {
+		referees = referees[:limit]
+		last := referees[limit-1]
+		cursor := formatRefereeCursor(last.ReferredAt.Time, last.ID)
+		out.NextCursor = &cursor
+	}
+
+	for _, r := range referees {
+		out.Referees = append(out.Referees, Referee{
+			Wallet:     primaryWalletHex(r),
+			ReferredAt: r.ReferredAt.Time,
+			Deleted:    r.DeletedAt.Valid,
+		})
+	}
+
+	return c.JSON(out)
+}
+
+// Cursors for the referee listing identify the last referee on the previous page.
+// They're opaque to clients.
+
+func formatRefereeCursor(referredAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(referredAt.UTC().Format(time.RFC3339Nano) + "|" + id))
+}
+
+func parseRefereeCursor(cursor string) (time.Time, string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	rawTime, id, ok := strings.Cut(string(b), "|")
+	if !ok || id == "" {
+		return time.Time{}, "", errors.New("cursor missing account id")
+	}
+
+	referredAt, err := time.Parse(time.RFC3339Nano, rawTime)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	return referredAt, id, nil
+}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/internal/siwe"
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// siweNonceDuration is how long a nonce may be used after it is issued.
+const siweNonceDuration = 10 * time.Minute
+
+// GetSIWENonce godoc
+// @Summary Issue a single-use nonce for a Sign-In with Ethereum message that will link a wallet to the account.
+// @Success 200 {object} controller.SIWENonceResponse
+// @Failure 400 {object} controller.ErrorRes
+// @Tags wallet
+// @Router /v1/account/link/wallet/siwe/nonce [get]
+func (d *Controller) GetSIWENonce(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	now := time.Now()
+
+	// Clean up after abandoned attempts.
+	if _, err := models.SiweNonces(
+		models.SiweNonceWhere.AccountID.EQ(acct.ID),
+		models.SiweNonceWhere.ExpiresAt.LT(now),
+	).DeleteAll(c.Context(), tx); err != nil {
+		return err
+	}
+
+	nonce := models.SiweNonce{
+		Nonce:     ksuid.New().String(),
+		AccountID: acct.ID,
+		ExpiresAt: now.Add(siweNonceDuration),
+	}
+
+	if err := nonce.Insert(c.Context(), tx, boil.Infer()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return c.JSON(SIWENonceResponse{
+		Nonce:     nonce.Nonce,
+		ExpiresAt: nonce.ExpiresAt,
+	})
+}
+
+// LinkWalletSIWE godoc
+// @Summary Link a wallet to the account using a Sign-In with Ethereum (EIP-4361) message signed by the wallet. The message must use a nonce from the nonce endpoint. The first wallet on an account becomes its primary wallet.
+// @Param linkWalletRequest body controller.LinkWalletSIWERequest true "Signed SIWE message."
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Tags wallet
+// @Router /v1/account/link/wallet/siwe [post]
+func (d *Controller) LinkWalletSIWE(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	var body LinkWalletSIWERequest
+	if err := c.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	msg, err := siwe.Parse(body.Message)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Invalid SIWE message: %s.", err))
+	}
+
+	if !slices.Contains(d.siweDomains, msg.Domain) {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Domain %s is not allowed.", msg.Domain))
+	}
+
+	if msg.ChainID != d.chainID {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Chain ID must be %d.", d.chainID))
+	}
+
+	now := time.Now()
+
+	if err := msg.Valid(now); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Invalid SIWE message: %s.", err))
+	}
+
+	if err := msg.VerifySignature(body.Message, body.Signature); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Invalid signature: %s.", err))
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	nonce, err := models.SiweNonces(
+		models.SiweNonceWhere.Nonce.EQ(msg.Nonce),
+		models.SiweNonceWhere.AccountID.EQ(acct.ID),
+		qm.For("UPDATE"),
+	).One(c.Context(), tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Nonce was not issued to this account or has already been used.")
+		}
+		return err
+	}
+
+	if now.After(nonce.ExpiresAt) {
+		return fiber.NewError(fiber.StatusBadRequest, "Nonce has expired.")
+	}
+
+	// Burn the nonce whatever happens next.
+	if _, err := nonce.Delete(c.Context(), tx); err != nil {
+		return err
+	}
+
+	if _, err := d.addWallet(c.Context(), tx, acct, msg.Address); err != nil {
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			// Still burn the nonce.
+			if err := tx.Commit(); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Linked wallet %s using SIWE.", msg.Address)
+
+	return c.JSON(StandardRes{
+		Message: fmt.Sprintf("Linked wallet %s.", msg.Address),
+	})
+}
@@ -1,23 +1,30 @@
 package controller
 
 import (
+	"bytes"
+	"context"
+	"database/sql"
 	_ "embed"
 	"fmt"
 
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
 	"github.com/DIMO-Network/accounts-api/models"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
 // LinkWalletToken godoc
-// @Summary Link a wallet to an existing account.
+// @Summary Link a wallet to an existing account. The first wallet on an account becomes its primary wallet.
 // @Param linkWalletRequest body controller.TokenBody true "JWT with an ethereum_address claim."
 // @Success 200 {object} controller.StandardRes
 // @Failure 400 {object} controller.ErrorRes
 // @Tags wallet
 // @Router /v1/account/link/wallet/token [post]
+// @Router /v1/account/wallets [post]
 func (d *Controller) LinkWalletToken(c *fiber.Ctx) error {
 	userAccount, err := getUserAccountClaims(c)
 	if err != nil {
@@ -39,14 +46,6 @@ func (d *Controller) LinkWalletToken(c *fiber.Ctx) error {
 	logger := d.log.With().Str("account", acct.ID).Logger()
 	c.Locals("logger", &logger)
 
-	if acct.R.Wallet != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Account already has a linked wallet, %s.", acct.R.Wallet.Address))
-	}
-
-	if acct.R.Email == nil {
-		return fmt.Errorf("no email or wallet associated with account")
-	}
-
 	var tb TokenBody
 	if err := c.BodyParser(&tb); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body.")
@@ -61,29 +60,420 @@ func (d *Controller) LinkWalletToken(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Token in the body has no ethereum_address claim.")
 	}
 
+	if _, err := d.addWallet(c.Context(), tx, acct, *infos.EthereumAddress); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Linked wallet %s.", *infos.EthereumAddress)
+
+	return c.JSON(StandardRes{
+		Message: fmt.Sprintf("Linked wallet %s.", *infos.EthereumAddress),
+	})
+}
+
+// addWallet links the wallet to the account, making it primary if the account
+// has no other wallets. The account's wallets must have been loaded.
+func (d *Controller) addWallet(ctx context.Context, tx *sql.Tx, acct *models.Account, address common.Address) (*models.Wallet, error) {
+	if inUse, err := models.WalletExists(ctx, tx, address.Bytes()); err != nil {
+		return nil, err
+	} else if inUse {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Wallet %s is already linked to an account.", address))
+	}
+
 	wallet := &models.Wallet{
 		AccountID: acct.ID,
-		Address:   infos.EthereumAddress.Bytes(),
+		Address:   address.Bytes(),
+		IsPrimary: primaryWallet(acct) == nil,
 	}
 
-	if err := wallet.Insert(c.Context(), tx, boil.Infer()); err != nil {
+	if err := wallet.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	// Only the first wallet opens the referral window.
+	if !acct.FirstWalletLinkedAt.Valid {
+		acct.FirstWalletLinkedAt = null.TimeFrom(wallet.CreatedAt)
+	}
+
+	if _, err := acct.Update(ctx, tx, boil.Whitelist(models.AccountColumns.FirstWalletLinkedAt, models.AccountColumns.UpdatedAt)); err != nil {
+		return nil, err
+	}
+
+	if wallet.IsPrimary {
+		if err := d.primaryWalletChanged(ctx, tx, acct, wallet); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := d.emitEvent(ctx, tx, events.WalletLinkedType, acct.ID, events.WalletLinked{Wallet: address, Primary: wallet.IsPrimary}); err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
+
+// removeWallet unlinks the wallet from the account. If it was the primary wallet
+// then the oldest remaining wallet, if any, is promoted. Refuses to remove the
+// account's last way to log in.
+func (d *Controller) removeWallet(ctx context.Context, tx *sql.Tx, acct *models.Account, wallet *models.Wallet) error {
+	var next *models.Wallet
+	for _, w := range acct.R.Wallets {
+		if !bytes.Equal(w.Address, wallet.Address) {
+			next = w
+			break
+		}
+	}
+
+	if next == nil && (acct.R.Email == nil || !acct.R.Email.ConfirmedAt.Valid) {
+		return fiber.NewError(fiber.StatusBadRequest, "Can't remove the only way to log in to the account. Link and confirm an email first.")
+	}
+
+	if _, err := wallet.Delete(ctx, tx); err != nil {
+		return err
+	}
+
+	if wallet.IsPrimary && next != nil {
+		next.IsPrimary = true
+		if _, err := next.Update(ctx, tx, boil.Whitelist(models.WalletColumns.IsPrimary)); err != nil {
+			return err
+		}
+		if err := d.primaryWalletChanged(ctx, tx, acct, next); err != nil {
+			return err
+		}
+	}
+
+	_, err := acct.Update(ctx, tx, boil.Whitelist(models.AccountColumns.UpdatedAt))
+	return err
+}
+
+// findWallet returns the account's wallet with the given address, or a 404 error.
+func findWallet(acct *models.Account, address common.Address) (*models.Wallet, error) {
+	for _, w := range acct.R.Wallets {
+		if bytes.Equal(w.Address, address.Bytes()) {
+			return w, nil
+		}
+	}
+	return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Wallet %s is not linked to the account.", address))
+}
+
+// primaryWalletChanged queues syncing a new primary wallet to Customer.io.
+func (d *Controller) primaryWalletChanged(ctx context.Context, tx *sql.Tx, acct *models.Account, wallet *models.Wallet) error {
+	address := common.BytesToAddress(wallet.Address)
+
+	if err := d.syncWallet(ctx, tx, address); err != nil {
 		return err
 	}
 
-	_, err = acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.UpdatedAt))
+	if acct.R.Email != nil {
+		if err := d.syncEmail(ctx, tx, address, acct.R.Email.Address); err != nil {
+			return err
+		}
+	}
+
+	if len(acct.R.CommunicationPreferences) != 0 {
+		return d.syncPreferences(ctx, tx, address, acct.R.CommunicationPreferences)
+	}
+
+	return nil
+}
+
+func parseAddressParam(c *fiber.Ctx) (common.Address, error) {
+	raw := c.Params("address")
+	if !common.IsHexAddress(raw) {
+		return common.Address{}, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Invalid wallet address %q.", raw))
+	}
+	return common.HexToAddress(raw), nil
+}
+
+// parseWalletToken validates a JWT from the auth server and returns the wallet in
+// its ethereum_address claim.
+func (d *Controller) parseWalletToken(token string) (common.Address, error) {
+	var infos AccountClaims
+	if _, err := jwt.ParseWithClaims(token, &infos, d.jwkResource.Keyfunc); err != nil {
+		return common.Address{}, fiber.NewError(fiber.StatusBadRequest, "Invalid wallet token.")
+	}
+
+	if infos.EthereumAddress == nil {
+		return common.Address{}, fiber.NewError(fiber.StatusBadRequest, "Token has no ethereum_address claim.")
+	}
+
+	return *infos.EthereumAddress, nil
+}
+
+// ListWallets godoc
+// @Summary List the account's wallets, oldest first.
+// @Success 200 {object} controller.WalletsResponse
+// @Failure 400 {object} controller.ErrorRes
+// @Tags wallet
+// @Router /v1/account/wallets [get]
+func (d *Controller) ListWallets(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, d.dbs.DBS().Reader)
+	if err != nil {
+		return err
+	}
+
+	out := WalletsResponse{Wallets: make([]UserResponseWallet, len(acct.R.Wallets))}
+	for i, w := range acct.R.Wallets {
+		out.Wallets[i] = formatWallet(w)
+	}
+
+	return c.JSON(out)
+}
+
+// RemoveWallet godoc
+// @Summary Remove a wallet from the account. If it was the primary wallet then the oldest remaining wallet becomes primary. The account must keep a wallet or a confirmed email to log in with.
+// @Param address path string true "Wallet address"
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Failure 404 {object} controller.ErrorRes
+// @Tags wallet
+// @Router /v1/account/wallets/{address} [delete]
+func (d *Controller) RemoveWallet(c *fiber.Ctx) error {
+	address, err := parseAddressParam(c)
+	if err != nil {
+		return err
+	}
+
+	return d.unlinkWallet(c, func(acct *models.Account) (*models.Wallet, error) {
+		return findWallet(acct, address)
+	})
+}
+
+// UnlinkWallet godoc
+// @Summary Remove the primary wallet from the account. The oldest remaining wallet becomes primary. The account must keep a wallet or a confirmed email to log in with.
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Tags wallet
+// @Router /v1/account/link/wallet [delete]
+func (d *Controller) UnlinkWallet(c *fiber.Ctx) error {
+	return d.unlinkWallet(c, func(acct *models.Account) (*models.Wallet, error) {
+		if wallet := primaryWallet(acct); wallet != nil {
+			return wallet, nil
+		}
+		return nil, fiber.NewError(fiber.StatusBadRequest, "No wallet linked to account.")
+	})
+}
+
+func (d *Controller) unlinkWallet(c *fiber.Ctx, choose func(*models.Account) (*models.Wallet, error)) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
 	if err != nil {
 		return err
 	}
 
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	wallet, err := choose(acct)
+	if err != nil {
+		return err
+	}
+
+	if err := d.removeWallet(c.Context(), tx, acct, wallet); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
 
-	if err := d.cioService.SetWallet(c.Context(), *infos.EthereumAddress); err != nil {
-		logger.Err(err).Msg("Failed to send wallet to Customer.io.")
+	address := common.BytesToAddress(wallet.Address)
+	logger.Info().Msgf("Unlinked wallet %s.", address)
+
+	return c.JSON(StandardRes{
+		Message: fmt.Sprintf("Unlinked wallet %s.", address),
+	})
+}
+
+// SetPrimaryWallet godoc
+// @Summary Make one of the account's wallets its primary wallet.
+// @Param address path string true "Wallet address"
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Failure 404 {object} controller.ErrorRes
+// @Tags wallet
+// @Router /v1/account/wallets/{address}/primary [put]
+func (d *Controller) SetPrimaryWallet(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	address, err := parseAddressParam(c)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	wallet, err := findWallet(acct, address)
+	if err != nil {
+		return err
+	}
+
+	if wallet.IsPrimary {
+		return c.JSON(StandardRes{Message: fmt.Sprintf("Wallet %s is already primary.", address)})
+	}
+
+	// Demote first, there can only be one primary wallet at a time.
+	if old := primaryWallet(acct); old != nil {
+		old.IsPrimary = false
+		if _, err := old.Update(c.Context(), tx, boil.Whitelist(models.WalletColumns.IsPrimary)); err != nil {
+			return err
+		}
+	}
+
+	wallet.IsPrimary = true
+	if _, err := wallet.Update(c.Context(), tx, boil.Whitelist(models.WalletColumns.IsPrimary)); err != nil {
+		return err
+	}
+
+	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := d.primaryWalletChanged(c.Context(), tx, acct, wallet); err != nil {
+		return err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Made wallet %s primary.", address)
+
 	return c.JSON(StandardRes{
-		Message: fmt.Sprintf("Linked wallet %s.", *infos.EthereumAddress),
+		Message: fmt.Sprintf("Made wallet %s primary.", address),
+	})
+}
+
+// ReplaceWallet godoc
+// @Summary Replace one of the account's wallets with a new one. Both wallets must be proven with JWTs from the auth server. The new wallet takes over the old one's primary status. Referrals stay with the account.
+// @Param replaceWalletRequest body controller.ReplaceWalletRequest true "JWTs for the current and new wallets."
+// @Success 200 {object} controller.StandardRes
+// @Failure 400 {object} controller.ErrorRes
+// @Tags wallet
+// @Router /v1/account/link/wallet/replace [post]
+func (d *Controller) ReplaceWallet(c *fiber.Ctx) error {
+	userAccount, err := getUserAccountClaims(c)
+	if err != nil {
+		d.log.Err(err).Msg("failed to parse user")
+		return err
+	}
+
+	var body ReplaceWalletRequest
+	if err := c.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	oldAddr, err := d.parseWalletToken(body.OldToken)
+	if err != nil {
+		return err
+	}
+
+	newAddr, err := d.parseWalletToken(body.NewToken)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.dbs.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	acct, err := d.getUserAccount(c.Context(), userAccount, tx)
+	if err != nil {
+		return err
+	}
+
+	logger := d.log.With().Str("account", acct.ID).Logger()
+	c.Locals("logger", &logger)
+
+	oldWallet, err := findWallet(acct, oldAddr)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Old token is for wallet %s, which is not linked to the account.", oldAddr))
+	}
+
+	if oldAddr == newAddr {
+		return fiber.NewError(fiber.StatusBadRequest, "Old and new wallets are the same.")
+	}
+
+	if inUse, err := models.WalletExists(c.Context(), tx, newAddr.Bytes()); err != nil {
+		return err
+	} else if inUse {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Wallet %s is already linked to an account.", newAddr))
+	}
+
+	if _, err := oldWallet.Delete(c.Context(), tx); err != nil {
+		return err
+	}
+
+	wallet := &models.Wallet{
+		AccountID: acct.ID,
+		Address:   newAddr.Bytes(),
+		IsPrimary: oldWallet.IsPrimary,
+	}
+
+	if err := wallet.Insert(c.Context(), tx, boil.Infer()); err != nil {
+		return err
+	}
+
+	if _, err := acct.Update(c.Context(), tx, boil.Whitelist(models.AccountColumns.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if wallet.IsPrimary {
+		if err := d.primaryWalletChanged(c.Context(), tx, acct, wallet); err != nil {
+			return err
+		}
+	}
+
+	if err := d.emitEvent(c.Context(), tx, events.WalletLinkedType, acct.ID, events.WalletLinked{Wallet: newAddr, Primary: wallet.IsPrimary}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Replaced wallet %s with %s.", oldAddr, newAddr)
+
+	return c.JSON(StandardRes{
+		Message: fmt.Sprintf("Replaced wallet %s with %s.", oldAddr, newAddr),
 	})
 }
@@ -0,0 +1,72 @@
+// Package legal works out which versions of our legal documents are in force and
+// which of them an account still has to accept.
+package legal
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Document kinds. These match the check constraint on legal_documents.kind.
+const (
+	KindTOS         = "tos"
+	KindPrivacy     = "privacy"
+	KindDataSharing = "data_sharing"
+)
+
+// Current returns the version of each kind of document that is in force, ordered
+// by kind.
+func Current(ctx context.Context, exec boil.ContextExecutor) ([]*models.LegalDocument, error) {
+	docs, err := models.LegalDocuments(
+		models.LegalDocumentWhere.EffectiveAt.LTE(time.Now()),
+		qm.OrderBy(models.LegalDocumentColumns.Kind+", "+models.LegalDocumentColumns.EffectiveAt+" DESC"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*models.LegalDocument
+	for _, doc := range docs {
+		if len(out) == 0 || out[len(out)-1].Kind != doc.Kind {
+			out = append(out, doc)
+		}
+	}
+
+	return out, nil
+}
+
+// CurrentOf returns the version of the given kind of document that is in force,
+// or nil if there isn't one.
+func CurrentOf(current []*models.LegalDocument, kind string) *models.LegalDocument {
+	for _, doc := range current {
+		if doc.Kind == kind {
+			return doc
+		}
+	}
+	return nil
+}
+
+// Outstanding returns the documents in current that have no matching acceptance.
+func Outstanding(current []*models.LegalDocument, accepted []*models.LegalAcceptance) []*models.LegalDocument {
+	var out []*models.LegalDocument
+	for _, doc := range current {
+		if !Accepted(doc, accepted) {
+			out = append(out, doc)
+		}
+	}
+	return out
+}
+
+// Accepted reports whether one of the acceptances is for the document.
+func Accepted(doc *models.LegalDocument, accepted []*models.LegalAcceptance) bool {
+	for _, a := range accepted {
+		if a.Kind == doc.Kind && a.Version == doc.Version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,244 @@
+// Package outbox delivers side effects, such as Customer.io updates and Kafka
+// events, for database changes. Messages are written in the same transaction as
+// the change they describe and delivered by a Dispatcher after commit, so work
+// that rolls back never leaks out and committed work is retried until it lands.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/internal/config"
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+const (
+	// defaultMaxAttempts is used when OUTBOX_MAX_ATTEMPTS is unset.
+	defaultMaxAttempts = 10
+	// defaultPollInterval is used when OUTBOX_POLL_INTERVAL is unset.
+	defaultPollInterval = 5 * time.Second
+	// batchSize is the most messages claimed in one pass.
+	batchSize = 50
+	// lease is how long claimed messages are reserved for the dispatcher that
+	// claimed them, and deliveryTimeout bounds each handler call within it.
+	lease           = 5 * time.Minute
+	deliveryTimeout = 30 * time.Second
+	// Backoff between attempts starts at minBackoff and doubles up to maxBackoff.
+	minBackoff = 10 * time.Second
+	maxBackoff = time.Hour
+)
+
+// Handler delivers one kind of message. The payload is the JSON that was
+// enqueued.
+type Handler func(ctx context.Context, payload []byte) error
+
+// Enqueue records a message for delivery. The executor should be the transaction
+// making the change that the message describes. Messages with the same non-empty
+// key are delivered in the order they were enqueued; an empty key means the
+// message can go out whenever.
+func Enqueue(ctx context.Context, exec boil.ContextExecutor, kind, key string, payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to serialize %s outbox message: %w", kind, err)
+	}
+
+	msg := models.OutboxMessage{
+		ID:          ksuid.New().String(),
+		Kind:        kind,
+		Payload:     b,
+		OrderingKey: null.NewString(key, key != ""),
+	}
+
+	return msg.Insert(ctx, exec, boil.Infer())
+}
+
+// Dispatcher delivers pending messages. Failed deliveries are retried with
+// exponential backoff; after the configured number of attempts, or immediately if
+// there is no handler for the kind, the message is dead-lettered and left in the
+// table for inspection.
+type Dispatcher struct {
+	dbs          db.Store
+	handlers     map[string]Handler
+	maxAttempts  int
+	pollInterval time.Duration
+	log          *zerolog.Logger
+}
+
+func NewDispatcher(dbs db.Store, handlers map[string]Handler, settings *config.Settings, logger *zerolog.Logger) (*Dispatcher, error) {
+	maxAttempts := settings.OutboxMaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = defaultMaxAttempts
+	} else if maxAttempts < 0 {
+		return nil, fmt.Errorf("outbox attempt limit %d is negative", maxAttempts)
+	}
+
+	pollInterval := defaultPollInterval
+	if settings.OutboxPollInterval != "" {
+		var err error
+		pollInterval, err = time.ParseDuration(settings.OutboxPollInterval)
+		if err != nil {
+			return nil, err
+		} else if pollInterval <= 0 {
+			return nil, fmt.Errorf("outbox poll interval %s is non-positive", pollInterval)
+		}
+	}
+
+	return &Dispatcher{
+		dbs:          dbs,
+		handlers:     handlers,
+		maxAttempts:  maxAttempts,
+		pollInterval: pollInterval,
+		log:          logger,
+	}, nil
+}
+
+// Run dispatches messages until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while there's a backlog. A pass only takes the head of each
+		// key's queue, so a short batch doesn't mean we've caught up.
+		for {
+			n, err := d.DispatchOnce(ctx)
+			if err != nil {
+				d.log.Err(err).Msg("Failed to dispatch outbox messages.")
+			}
+			if err != nil || n == 0 {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce attempts delivery of a batch of due messages and returns how many
+// it claimed. Claiming leases the messages by pushing their next attempt past the
+// delivery window, so several replicas can run dispatchers at once and nothing
+// is held locked while handlers make network calls. If a dispatcher dies
+// mid-batch, its unfinished messages are picked up again when the lease runs
+// out.
+//
+// Only the oldest pending message for each ordering key is claimed, so messages
+// that share a key are delivered one at a time and in order. A message waiting
+// out a retry holds back the ones behind it; once it is dead-lettered, they go
+// ahead.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	var msgs models.OutboxMessageSlice
+	err := queries.Raw(fmt.Sprintf(`
+		UPDATE %[1]s SET %[2]s = now() + make_interval(secs => $1)
+		WHERE %[3]s IN (
+			SELECT m.%[3]s FROM %[1]s m
+			WHERE m.%[4]s IS NULL AND m.%[2]s <= now()
+				AND NOT EXISTS (
+					SELECT 1 FROM %[1]s e
+					WHERE e.%[5]s = m.%[5]s AND e.%[6]s < m.%[6]s AND e.%[4]s IS NULL
+				)
+			ORDER BY m.%[6]s
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		models.TableNames.OutboxMessages,
+		models.OutboxMessageColumns.NextAttemptAt,
+		models.OutboxMessageColumns.ID,
+		models.OutboxMessageColumns.DeadLetteredAt,
+		models.OutboxMessageColumns.OrderingKey,
+		models.OutboxMessageColumns.Seq,
+	), lease.Seconds(), batchSize).Bind(ctx, d.dbs.DBS().Writer, &msgs)
+	if err != nil {
+		return 0, err
+	}
+
+	claimedAt := time.Now()
+
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Seq < msgs[j].Seq })
+
+	for i, msg := range msgs {
+		// Leave the rest to be reclaimed rather than risk delivering after
+		// another dispatcher has taken them over.
+		if time.Since(claimedAt)+deliveryTimeout > lease {
+			d.log.Warn().Msgf("Ran out of lease with %d outbox messages left in the batch.", len(msgs)-i)
+			break
+		}
+		if err := d.deliver(ctx, msg); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(msgs), nil
+}
+
+// deliver hands the message to its handler, then deletes it or records the
+// failure. Only database errors are returned.
+func (d *Dispatcher) deliver(ctx context.Context, msg *models.OutboxMessage) error {
+	logger := d.log.With().Str("outboxMessage", msg.ID).Str("kind", msg.Kind).Logger()
+
+	var deliverErr error
+	if handler, ok := d.handlers[msg.Kind]; ok {
+		hctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+		deliverErr = handler(hctx, msg.Payload)
+		cancel()
+	} else {
+		deliverErr = errNoHandler
+	}
+
+	exec := d.dbs.DBS().Writer
+
+	if deliverErr == nil {
+		_, err := msg.Delete(ctx, exec)
+		return err
+	}
+
+	now := time.Now()
+
+	msg.Attempts++
+	msg.LastError = null.StringFrom(deliverErr.Error())
+
+	if msg.Attempts >= d.maxAttempts || errors.Is(deliverErr, errNoHandler) {
+		msg.DeadLetteredAt = null.TimeFrom(now)
+		logger.Error().Err(deliverErr).Int("attempts", msg.Attempts).Msg("Dead-lettered outbox message.")
+	} else {
+		msg.NextAttemptAt = now.Add(backoff(msg.Attempts))
+		logger.Warn().Err(deliverErr).Int("attempts", msg.Attempts).Msgf("Failed to deliver outbox message, will retry at %s.", msg.NextAttemptAt)
+	}
+
+	_, err := msg.Update(ctx, exec, boil.Whitelist(
+		models.OutboxMessageColumns.Attempts,
+		models.OutboxMessageColumns.LastError,
+		models.OutboxMessageColumns.NextAttemptAt,
+		models.OutboxMessageColumns.DeadLetteredAt,
+	))
+	return err
+}
+
+var errNoHandler = errors.New("no handler for message kind")
+
+// backoff returns the wait before the next try, after the given number of failed
+// attempts.
+func backoff(attempts int) time.Duration {
+	wait := minBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return wait
+}
@@ -0,0 +1,214 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/internal/config"
+	"github.com/DIMO-Network/accounts-api/internal/test"
+	"github.com/DIMO-Network/accounts-api/models"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const migrationsDirRelPath = "../../migrations"
+
+type OutboxTestSuite struct {
+	suite.Suite
+	pdb         db.Store
+	pgContainer testcontainers.Container
+	ctx         context.Context
+}
+
+func (s *OutboxTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.pdb, s.pgContainer = test.StartContainerDatabase(s.ctx, s.T(), migrationsDirRelPath)
+}
+
+func (s *OutboxTestSuite) TearDownSuite() {
+	if err := s.pgContainer.Terminate(s.ctx); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+func (s *OutboxTestSuite) TearDownTest() {
+	s.Require().NoError(test.DeleteAll(s.pdb.DBS().Writer))
+}
+
+func TestOutboxTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxTestSuite))
+}
+
+type testPayload struct {
+	Value string `json:"value"`
+}
+
+func (s *OutboxTestSuite) Test_DeliverAndRetry() {
+	var delivered []string
+	fail := true
+
+	handlers := map[string]Handler{
+		"test.kind": func(ctx context.Context, payload []byte) error {
+			if fail {
+				return errors.New("downstream is unavailable")
+			}
+			delivered = append(delivered, string(payload))
+			return nil
+		},
+	}
+
+	dispatcher, err := NewDispatcher(s.pdb, handlers, &config.Settings{OutboxMaxAttempts: 3}, test.Logger())
+	s.Require().NoError(err)
+
+	s.Require().NoError(Enqueue(s.ctx, s.pdb.DBS().Writer, "test.kind", "", testPayload{Value: "hello"}))
+
+	n, err := dispatcher.DispatchOnce(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(1, n)
+
+	msg, err := models.OutboxMessages().One(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Equal(1, msg.Attempts)
+	s.Equal("downstream is unavailable", msg.LastError.String)
+	s.False(msg.DeadLetteredAt.Valid)
+	s.True(msg.NextAttemptAt.After(time.Now()))
+
+	// Not due yet.
+	n, err = dispatcher.DispatchOnce(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(n)
+
+	fail = false
+	msg.NextAttemptAt = time.Now().Add(-time.Second)
+	_, err = msg.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.OutboxMessageColumns.NextAttemptAt))
+	s.Require().NoError(err)
+
+	n, err = dispatcher.DispatchOnce(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(1, n)
+	s.Equal([]string{`{"value":"hello"}`}, delivered)
+
+	count, err := models.OutboxMessages().Count(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Zero(count)
+}
+
+func (s *OutboxTestSuite) Test_DeadLetter() {
+	handlers := map[string]Handler{
+		"test.kind": func(ctx context.Context, payload []byte) error {
+			return errors.New("downstream rejected the message")
+		},
+	}
+
+	dispatcher, err := NewDispatcher(s.pdb, handlers, &config.Settings{OutboxMaxAttempts: 2}, test.Logger())
+	s.Require().NoError(err)
+
+	s.Require().NoError(Enqueue(s.ctx, s.pdb.DBS().Writer, "test.kind", "", testPayload{Value: "hello"}))
+	s.Require().NoError(Enqueue(s.ctx, s.pdb.DBS().Writer, "test.unknown", "", testPayload{Value: "hello"}))
+
+	for i := 0; i < 2; i++ {
+		_, err := models.OutboxMessages().UpdateAll(s.ctx, s.pdb.DBS().Writer, models.M{models.OutboxMessageColumns.NextAttemptAt: time.Now().Add(-time.Second)})
+		s.Require().NoError(err)
+
+		_, err = dispatcher.DispatchOnce(s.ctx)
+		s.Require().NoError(err)
+	}
+
+	msgs, err := models.OutboxMessages().All(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Require().Len(msgs, 2)
+
+	for _, msg := range msgs {
+		s.True(msg.DeadLetteredAt.Valid, "message %s of kind %s should be dead-lettered", msg.ID, msg.Kind)
+		if msg.Kind == "test.unknown" {
+			// No point in retrying these.
+			s.Equal(1, msg.Attempts)
+		} else {
+			s.Equal(2, msg.Attempts)
+		}
+	}
+
+	// Dead letters are never picked up again.
+	_, err = models.OutboxMessages().UpdateAll(s.ctx, s.pdb.DBS().Writer, models.M{models.OutboxMessageColumns.NextAttemptAt: time.Now().Add(-time.Second)})
+	s.Require().NoError(err)
+
+	n, err := dispatcher.DispatchOnce(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(n)
+}
+
+func (s *OutboxTestSuite) Test_Ordering() {
+	var delivered []string
+	failFirst := true
+
+	handlers := map[string]Handler{
+		"test.kind": func(ctx context.Context, payload []byte) error {
+			if failFirst && string(payload) == `{"value":"first"}` {
+				return errors.New("downstream is unavailable")
+			}
+			delivered = append(delivered, string(payload))
+			return nil
+		},
+	}
+
+	dispatcher, err := NewDispatcher(s.pdb, handlers, &config.Settings{OutboxMaxAttempts: 3}, test.Logger())
+	s.Require().NoError(err)
+
+	s.Require().NoError(Enqueue(s.ctx, s.pdb.DBS().Writer, "test.kind", "account", testPayload{Value: "first"}))
+	s.Require().NoError(Enqueue(s.ctx, s.pdb.DBS().Writer, "test.kind", "account", testPayload{Value: "second"}))
+	s.Require().NoError(Enqueue(s.ctx, s.pdb.DBS().Writer, "test.kind", "", testPayload{Value: "unordered"}))
+
+	// Only the head of the keyed queue goes out, and its failure holds back the
+	// message behind it.
+	n, err := dispatcher.DispatchOnce(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(2, n)
+	s.Equal([]string{`{"value":"unordered"}`}, delivered)
+
+	n, err = dispatcher.DispatchOnce(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(n)
+
+	failFirst = false
+	_, err = models.OutboxMessages().UpdateAll(s.ctx, s.pdb.DBS().Writer, models.M{models.OutboxMessageColumns.NextAttemptAt: time.Now().Add(-time.Second)})
+	s.Require().NoError(err)
+
+	for i := 0; i < 2; i++ {
+		n, err = dispatcher.DispatchOnce(s.ctx)
+		s.Require().NoError(err)
+		s.Equal(1, n)
+	}
+
+	s.Equal([]string{`{"value":"unordered"}`, `{"value":"first"}`, `{"value":"second"}`}, delivered)
+}
+
+func (s *OutboxTestSuite) Test_Lease() {
+	handlers := map[string]Handler{
+		"test.kind": func(ctx context.Context, payload []byte) error {
+			return nil
+		},
+	}
+
+	dispatcher, err := NewDispatcher(s.pdb, handlers, &config.Settings{}, test.Logger())
+	s.Require().NoError(err)
+
+	s.Require().NoError(Enqueue(s.ctx, s.pdb.DBS().Writer, "test.kind", "", testPayload{Value: "hello"}))
+
+	// Simulate a dispatcher that claimed the message and is still working on it.
+	_, err = models.OutboxMessages().UpdateAll(s.ctx, s.pdb.DBS().Writer, models.M{models.OutboxMessageColumns.NextAttemptAt: time.Now().Add(lease)})
+	s.Require().NoError(err)
+
+	n, err := dispatcher.DispatchOnce(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(n)
+}
+
+func (s *OutboxTestSuite) Test_Backoff() {
+	s.Equal(minBackoff, backoff(1))
+	s.Equal(2*minBackoff, backoff(2))
+	s.Equal(maxBackoff, backoff(30))
+}
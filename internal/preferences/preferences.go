@@ -0,0 +1,46 @@
+// Package preferences lists the channels over which users can agree to be
+// contacted.
+package preferences
+
+import "github.com/DIMO-Network/accounts-api/models"
+
+// Channels. These match the check constraint on communication_preferences.channel,
+// and double as the names of the Customer.io traits.
+const (
+	MarketingEmail    = "marketing_email"
+	ProductUpdates    = "product_updates"
+	PushNotifications = "push_notifications"
+)
+
+// Sources of preference changes, recorded in communication_preference_changes.
+const (
+	// SourceUser is the user changing their own preferences through the API.
+	SourceUser = "user"
+)
+
+// Channels lists every channel.
+var Channels = []string{MarketingEmail, ProductUpdates, PushNotifications}
+
+// Find returns the preference for the channel, or nil if the user has never set
+// it.
+func Find(prefs []*models.CommunicationPreference, channel string) *models.CommunicationPreference {
+	for _, p := range prefs {
+		if p.Channel == channel {
+			return p
+		}
+	}
+	return nil
+}
+
+// Enabled returns the state of every channel. Channels that were never set are
+// off.
+func Enabled(prefs []*models.CommunicationPreference) map[string]bool {
+	out := make(map[string]bool, len(Channels))
+	for _, ch := range Channels {
+		out[ch] = false
+	}
+	for _, p := range prefs {
+		out[p.Channel] = p.Enabled
+	}
+	return out
+}
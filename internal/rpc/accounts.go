@@ -8,6 +8,8 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/DIMO-Network/accounts-api/internal/legal"
+	"github.com/DIMO-Network/accounts-api/internal/preferences"
 	"github.com/DIMO-Network/accounts-api/models"
 	pb "github.com/DIMO-Network/accounts-api/pkg/grpc"
 	"github.com/DIMO-Network/shared/db"
@@ -25,17 +27,30 @@ type Server struct {
 }
 
 var emailJoin = fmt.Sprintf("%s ON %s = %s", models.TableNames.Emails, models.EmailTableColumns.AccountID, models.AccountTableColumns.ID)
-var walletJoin = fmt.Sprintf("%s ON %s = %s", models.TableNames.Wallets, models.WalletTableColumns.AccountID, models.AccountTableColumns.ID)
+
+// Accounts can have several wallets, so filter through a subquery instead of a join
+// that would repeat rows.
+var walletExists = fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE %s = %s AND %%s)", models.TableNames.Wallets, models.WalletTableColumns.AccountID, models.AccountTableColumns.ID)
 
 var emailHas = fmt.Sprintf("position(? in %s) > 0", models.EmailTableColumns.Address)
-var walletHas = fmt.Sprintf("position(? in %s) > 0", models.WalletTableColumns.Address)
+var walletHas = fmt.Sprintf(walletExists, fmt.Sprintf("position(? in %s) > 0", models.WalletTableColumns.Address))
+var walletIs = fmt.Sprintf(walletExists, models.WalletTableColumns.Address+" = ?")
+
+// Codes that accounts have replaced keep resolving to them until they expire.
+var referralCodeIs = fmt.Sprintf("(%s = ? OR EXISTS (SELECT 1 FROM %s WHERE %s = %s AND %s = ? AND %s > now()))",
+	models.AccountTableColumns.ReferralCode,
+	models.TableNames.RetiredReferralCodes, models.RetiredReferralCodeTableColumns.AccountID, models.AccountTableColumns.ID,
+	models.RetiredReferralCodeTableColumns.Code, models.RetiredReferralCodeTableColumns.ExpiresAt,
+)
 
 func (s *Server) ListAccounts(ctx context.Context, in *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
 	var mods = []qm.QueryMod{
 		qm.Load(models.AccountRels.Email),
-		qm.Load(models.AccountRels.Wallet),
+		qm.Load(models.AccountRels.Wallets, qm.OrderBy(models.WalletColumns.CreatedAt)),
+		qm.Load(models.AccountRels.LegalAcceptances),
+		qm.Load(models.AccountRels.CommunicationPreferences),
 		qm.LeftOuterJoin(emailJoin), // TODO(elffjs): This seems a bit wasteful.
-		qm.LeftOuterJoin(walletJoin),
+		models.AccountWhere.DeletedAt.IsNull(),
 		qm.OrderBy(models.AccountColumns.CreatedAt + " DESC"),
 		qm.Limit(100), // TODO(elffjs): Revisit.
 	}
@@ -55,12 +70,17 @@ func (s *Server) ListAccounts(ctx context.Context, in *pb.ListAccountsRequest) (
 		return nil, err
 	}
 
+	current, err := legal.Current(ctx, s.DBS.DBS().Reader)
+	if err != nil {
+		return nil, err
+	}
+
 	out := &pb.ListAccountsResponse{
 		Accounts: make([]*pb.Account, len(accs)),
 	}
 
 	for i, a := range accs {
-		out.Accounts[i] = dbToRPC(a)
+		out.Accounts[i] = dbToRPC(a, current)
 	}
 
 	return out, nil
@@ -78,9 +98,11 @@ var referralCodeRegex = regexp.MustCompile(`^[A-Z0-9]{6}$`)
 func (s *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.Account, error) {
 	var mods = []qm.QueryMod{
 		qm.Load(models.AccountRels.Email),
-		qm.Load(models.AccountRels.Wallet),
+		qm.Load(models.AccountRels.Wallets, qm.OrderBy(models.WalletColumns.CreatedAt)),
+		qm.Load(models.AccountRels.LegalAcceptances),
+		qm.Load(models.AccountRels.CommunicationPreferences),
 		qm.LeftOuterJoin(emailJoin),
-		qm.LeftOuterJoin(walletJoin),
+		models.AccountWhere.DeletedAt.IsNull(),
 	}
 
 	initLen := len(mods)
@@ -104,13 +126,13 @@ func (s *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb
 		if len(req.WalletAddress) != common.AddressLength {
 			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("The provided address has length %d, not %d.", len(req.WalletAddress), common.AddressLength))
 		}
-		mods = append(mods, models.WalletWhere.Address.EQ(req.WalletAddress))
+		mods = append(mods, qm.Where(walletIs, req.WalletAddress))
 	}
 	if req.ReferralCode != "" {
 		if !referralCodeRegex.MatchString(req.ReferralCode) {
 			return nil, status.Error(codes.InvalidArgument, "Referral codes are 6 upper-case alphanumeric characters.")
 		}
-		mods = append(mods, models.AccountWhere.ReferralCode.EQ(req.ReferralCode))
+		mods = append(mods, qm.Where(referralCodeIs, req.ReferralCode, req.ReferralCode))
 	}
 
 	if provided := len(mods) - initLen; provided != 1 {
@@ -125,7 +147,12 @@ func (s *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb
 		return nil, err
 	}
 
-	return dbToRPC(acc), nil
+	current, err := legal.Current(ctx, s.DBS.DBS().Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbToRPC(acc, current), nil
 }
 
 func (s *Server) TempReferral(ctx context.Context, req *pb.TempReferralRequest) (*pb.TempReferralResponse, error) {
@@ -135,7 +162,7 @@ func (s *Server) TempReferral(ctx context.Context, req *pb.TempReferralRequest)
 
 	wallet, err := models.Wallets(
 		models.WalletWhere.Address.EQ(req.WalletAddress),
-		qm.Load(qm.Rels(models.WalletRels.Account, models.AccountRels.ReferredByAccount, models.AccountRels.Wallet)),
+		qm.Load(qm.Rels(models.WalletRels.Account, models.AccountRels.ReferredByAccount, models.AccountRels.Wallets)),
 	).One(ctx, s.DBS.DBS().Reader)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -144,20 +171,39 @@ func (s *Server) TempReferral(ctx context.Context, req *pb.TempReferralRequest)
 		return nil, err
 	}
 
+	if wallet.R.Account.DeletedAt.Valid {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("No account found with wallet %s.", common.BytesToAddress(req.WalletAddress)))
+	}
+
 	out := &pb.TempReferralResponse{
 		AccountId:   wallet.R.Account.ID,
 		WasReferred: wallet.R.Account.ReferredAt.Valid,
 	}
 
-	if wallet.R.Account.R.ReferredByAccount != nil && wallet.R.Account.R.ReferredByAccount.R.Wallet != nil {
-		out.ReferrerAccountId = wallet.R.Account.R.ReferredByAccount.ID
-		out.ReferrerWalletAddress = wallet.R.Account.R.ReferredByAccount.R.Wallet.Address
+	out.CampaignId = wallet.R.Account.ReferralCampaignID.String
+
+	if referrer := wallet.R.Account.R.ReferredByAccount; referrer != nil {
+		if refWallet := primaryWallet(referrer); refWallet != nil {
+			out.ReferrerAccountId = referrer.ID
+			out.ReferrerWalletAddress = refWallet.Address
+		}
 	}
 
 	return out, nil
 }
 
-func dbToRPC(acc *models.Account) *pb.Account {
+func preferenceToRPC(prefs []*models.CommunicationPreference, channel string) *pb.CommunicationPreference {
+	pref := preferences.Find(prefs, channel)
+	if pref == nil {
+		return &pb.CommunicationPreference{}
+	}
+	return &pb.CommunicationPreference{
+		Enabled:   pref.Enabled,
+		UpdatedAt: timestamppb.New(pref.UpdatedAt),
+	}
+}
+
+func dbToRPC(acc *models.Account, current []*models.LegalDocument) *pb.Account {
 	out := &pb.Account{
 		Id:        acc.ID,
 		CreatedAt: timestamppb.New(acc.CreatedAt),
@@ -170,17 +216,41 @@ func dbToRPC(acc *models.Account) *pb.Account {
 		out.CountryCode = acc.CountryCode.String
 	}
 
+	out.DisplayName = acc.DisplayName.String
+	out.Language = acc.Language.String
+	out.TimeZone = acc.TimeZone.String
+	out.Units = acc.Units.String
+
 	if acc.R.Email != nil {
 		out.Email = &pb.Email{
 			Address: acc.R.Email.Address,
 		}
 	}
-	if acc.R.Wallet != nil {
-		out.Wallet = &pb.Wallet{
-			Address: acc.R.Wallet.Address,
+	for _, w := range acc.R.Wallets {
+		pw := &pb.Wallet{
+			Address: w.Address,
+			Primary: w.IsPrimary,
+		}
+		out.Wallets = append(out.Wallets, pw)
+		if w.IsPrimary {
+			out.Wallet = pw
 		}
 	}
 
+	for _, doc := range legal.Outstanding(current, acc.R.LegalAcceptances) {
+		out.OutstandingDocuments = append(out.OutstandingDocuments, &pb.LegalDocument{
+			Kind:        doc.Kind,
+			Version:     doc.Version,
+			EffectiveAt: timestamppb.New(doc.EffectiveAt),
+		})
+	}
+
+	out.CommunicationPreferences = &pb.CommunicationPreferences{
+		MarketingEmail:    preferenceToRPC(acc.R.CommunicationPreferences, preferences.MarketingEmail),
+		ProductUpdates:    preferenceToRPC(acc.R.CommunicationPreferences, preferences.ProductUpdates),
+		PushNotifications: preferenceToRPC(acc.R.CommunicationPreferences, preferences.PushNotifications),
+	}
+
 	if acc.ReferredAt.Valid {
 		out.Referral.ReferredAt = timestamppb.New(acc.ReferredAt.Time)
 	}
@@ -188,6 +258,18 @@ func dbToRPC(acc *models.Account) *pb.Account {
 		// Could skip the check and always make this assignment. Preferring explicitness.
 		out.Referral.ReferredBy = acc.ReferredBy.String
 	}
+	if acc.ReferralCampaignID.Valid {
+		out.Referral.CampaignId = acc.ReferralCampaignID.String
+	}
 
 	return out
 }
+
+func primaryWallet(acc *models.Account) *models.Wallet {
+	for _, w := range acc.R.Wallets {
+		if w.IsPrimary {
+			return w
+		}
+	}
+	return nil
+}
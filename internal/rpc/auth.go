@@ -0,0 +1,28 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AdminAuth returns an interceptor that rejects calls unless they carry the token
+// in an "authorization: Bearer <token>" header.
+func AdminAuth(token string) grpc.UnaryServerInterceptor {
+	want := []byte("Bearer " + token)
+
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, v := range md.Get("authorization") {
+			if subtle.ConstantTimeCompare([]byte(v), want) == 1 {
+				return handler(ctx, req)
+			}
+		}
+
+		return nil, status.Error(codes.Unauthenticated, "Missing or incorrect admin token.")
+	}
+}
@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminAuth(t *testing.T) {
+	intercept := AdminAuth("s3cret")
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	cases := []struct {
+		name   string
+		header string
+		code   codes.Code
+	}{
+		{"no header", "", codes.Unauthenticated},
+		{"wrong token", "Bearer nope", codes.Unauthenticated},
+		{"missing scheme", "s3cret", codes.Unauthenticated},
+		{"right token", "Bearer s3cret", codes.OK},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", c.header))
+			}
+
+			_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+			assert.Equal(t, c.code, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/internal/campaigns"
+	"github.com/DIMO-Network/accounts-api/models"
+	pb "github.com/DIMO-Network/accounts-api/pkg/grpc"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CampaignServer struct {
+	pb.UnimplementedReferralCampaignsServer
+	DBS db.Store
+}
+
+func (s *CampaignServer) CreateCampaign(ctx context.Context, req *pb.CreateCampaignRequest) (*pb.Campaign, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Campaigns need a name.")
+	}
+
+	code := campaigns.NormalizeCode(req.Code)
+	if !campaigns.CodePattern.MatchString(code) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Campaign code %q must be 7 to 32 letters, digits, and hyphens, starting and ending with a letter or digit.", req.Code))
+	}
+
+	if req.MaxUses < 0 {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Maximum uses %d is negative.", req.MaxUses))
+	}
+
+	camp := models.ReferralCampaign{
+		ID:   ksuid.New().String(),
+		Name: req.Name,
+		Code: code,
+	}
+
+	if req.StartsAt != nil {
+		camp.StartsAt = null.TimeFrom(req.StartsAt.AsTime())
+	}
+	if req.EndsAt != nil {
+		camp.EndsAt = null.TimeFrom(req.EndsAt.AsTime())
+	}
+	if camp.StartsAt.Valid && camp.EndsAt.Valid && !camp.StartsAt.Time.Before(camp.EndsAt.Time) {
+		return nil, status.Error(codes.InvalidArgument, "Campaign must start before it ends.")
+	}
+
+	if req.MaxUses != 0 {
+		camp.MaxUses = null.IntFrom(int(req.MaxUses))
+	}
+
+	if exists, err := models.ReferralCampaigns(models.ReferralCampaignWhere.Code.EQ(code)).Exists(ctx, s.DBS.DBS().Writer); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("Campaign code %s is taken.", code))
+	}
+
+	if err := camp.Insert(ctx, s.DBS.DBS().Writer, boil.Infer()); err != nil {
+		return nil, err
+	}
+
+	return campaignToRPC(&camp), nil
+}
+
+func (s *CampaignServer) GetCampaign(ctx context.Context, req *pb.GetCampaignRequest) (*pb.Campaign, error) {
+	var mod qm.QueryMod
+	switch {
+	case req.Id != "" && req.Code == "":
+		mod = models.ReferralCampaignWhere.ID.EQ(req.Id)
+	case req.Code != "" && req.Id == "":
+		mod = models.ReferralCampaignWhere.Code.EQ(campaigns.NormalizeCode(req.Code))
+	default:
+		return nil, status.Error(codes.InvalidArgument, "We require exactly one of id and code.")
+	}
+
+	camp, err := models.ReferralCampaigns(mod).One(ctx, s.DBS.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "No campaign found.")
+		}
+		return nil, err
+	}
+
+	return campaignToRPC(camp), nil
+}
+
+func (s *CampaignServer) ListCampaigns(ctx context.Context, _ *pb.ListCampaignsRequest) (*pb.ListCampaignsResponse, error) {
+	camps, err := models.ReferralCampaigns(
+		qm.OrderBy(models.ReferralCampaignColumns.CreatedAt+" DESC"),
+	).All(ctx, s.DBS.DBS().Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pb.ListCampaignsResponse{
+		Campaigns: make([]*pb.Campaign, len(camps)),
+	}
+
+	for i, c := range camps {
+		out.Campaigns[i] = campaignToRPC(c)
+	}
+
+	return out, nil
+}
+
+func (s *CampaignServer) UpdateCampaign(ctx context.Context, req *pb.UpdateCampaignRequest) (*pb.Campaign, error) {
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "The update mask lists no fields to change.")
+	}
+
+	tx, err := s.DBS.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	camp, err := findCampaignForUpdate(ctx, tx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range req.UpdateMask.Paths {
+		switch path {
+		case "name":
+			if req.Name == "" {
+				return nil, status.Error(codes.InvalidArgument, "Campaigns need a name.")
+			}
+			camp.Name = req.Name
+		case "starts_at":
+			camp.StartsAt = null.Time{}
+			if req.StartsAt != nil {
+				camp.StartsAt = null.TimeFrom(req.StartsAt.AsTime())
+			}
+		case "ends_at":
+			camp.EndsAt = null.Time{}
+			if req.EndsAt != nil {
+				camp.EndsAt = null.TimeFrom(req.EndsAt.AsTime())
+			}
+		case "max_uses":
+			if req.MaxUses < 0 {
+				return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Maximum uses %d is negative.", req.MaxUses))
+			}
+			camp.MaxUses = null.Int{}
+			if req.MaxUses != 0 {
+				if int(req.MaxUses) < camp.Uses {
+					return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Campaign has already been used %d times. End it instead of lowering the maximum.", camp.Uses))
+				}
+				camp.MaxUses = null.IntFrom(int(req.MaxUses))
+			}
+		default:
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Field %q can't be updated.", path))
+		}
+	}
+
+	if camp.StartsAt.Valid && camp.EndsAt.Valid && !camp.StartsAt.Time.Before(camp.EndsAt.Time) {
+		return nil, status.Error(codes.InvalidArgument, "Campaign must start before it ends.")
+	}
+
+	if _, err := camp.Update(ctx, tx, boil.Whitelist(
+		models.ReferralCampaignColumns.Name,
+		models.ReferralCampaignColumns.StartsAt,
+		models.ReferralCampaignColumns.EndsAt,
+		models.ReferralCampaignColumns.MaxUses,
+	)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return campaignToRPC(camp), nil
+}
+
+func (s *CampaignServer) EndCampaign(ctx context.Context, req *pb.EndCampaignRequest) (*pb.Campaign, error) {
+	tx, err := s.DBS.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	camp, err := findCampaignForUpdate(ctx, tx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if camp.EndsAt.Valid && !now.Before(camp.EndsAt.Time) {
+		return campaignToRPC(camp), nil
+	}
+
+	camp.EndsAt = null.TimeFrom(now)
+	// A campaign that never started never ran, and its start would otherwise
+	// fall after its end.
+	if camp.StartsAt.Valid && !camp.StartsAt.Time.Before(now) {
+		camp.StartsAt = null.Time{}
+	}
+
+	if _, err := camp.Update(ctx, tx, boil.Whitelist(models.ReferralCampaignColumns.StartsAt, models.ReferralCampaignColumns.EndsAt)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return campaignToRPC(camp), nil
+}
+
+// findCampaignForUpdate loads the campaign and locks it until the transaction
+// ends, so that it can't change under a concurrent submission of its code.
+func findCampaignForUpdate(ctx context.Context, tx *sql.Tx, id string) (*models.ReferralCampaign, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "We require the campaign id.")
+	}
+
+	camp, err := models.ReferralCampaigns(
+		models.ReferralCampaignWhere.ID.EQ(id),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "No campaign found.")
+		}
+		return nil, err
+	}
+
+	return camp, nil
+}
+
+func campaignToRPC(c *models.ReferralCampaign) *pb.Campaign {
+	out := &pb.Campaign{
+		Id:        c.ID,
+		Name:      c.Name,
+		Code:      c.Code,
+		MaxUses:   int32(c.MaxUses.Int),
+		Uses:      int32(c.Uses),
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+
+	if c.StartsAt.Valid {
+		out.StartsAt = timestamppb.New(c.StartsAt.Time)
+	}
+	if c.EndsAt.Valid {
+		out.EndsAt = timestamppb.New(c.EndsAt.Time)
+	}
+
+	return out
+}
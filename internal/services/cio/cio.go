@@ -9,7 +9,10 @@ import (
 	"github.com/rs/zerolog"
 )
 
-const walletTrait = "wallet"
+const (
+	walletTrait = "wallet"
+	emailTrait  = "email"
+)
 
 // Need to rename this package.
 type Client struct {
@@ -36,6 +39,42 @@ func (c *Client) SetEmail(ctx context.Context, wallet common.Address, email stri
 	})
 }
 
+// ClearEmail removes the email trait from the wallet's profile.
+func (c *Client) ClearEmail(ctx context.Context, wallet common.Address) error {
+	return c.client.Enqueue(analytics.Identify{
+		UserId: wallet.Hex(),
+		Traits: analytics.NewTraits().Set(emailTrait, nil),
+	})
+}
+
+// SetPreferences sets a boolean trait for each communication channel.
+func (c *Client) SetPreferences(ctx context.Context, wallet common.Address, prefs map[string]bool) error {
+	traits := analytics.NewTraits()
+	for channel, enabled := range prefs {
+		traits.Set(channel, enabled)
+	}
+
+	return c.client.Enqueue(analytics.Identify{
+		UserId: wallet.Hex(),
+		Traits: traits,
+	})
+}
+
+// SetEmailPreferences sets a boolean trait for each communication channel on the
+// profile for an account that has an email but no wallet. The profile is
+// identified by the address.
+func (c *Client) SetEmailPreferences(ctx context.Context, email string, prefs map[string]bool) error {
+	traits := analytics.NewTraits().SetEmail(email)
+	for channel, enabled := range prefs {
+		traits.Set(channel, enabled)
+	}
+
+	return c.client.Enqueue(analytics.Identify{
+		UserId: email,
+		Traits: traits,
+	})
+}
+
 func (c *Client) SetWallet(ctx context.Context, wallet common.Address) error {
 	return c.client.Enqueue(analytics.Identify{
 		UserId: wallet.Hex(),
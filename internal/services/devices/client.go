@@ -0,0 +1,62 @@
+package devices
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+)
+
+// countTimeout bounds all of the lookups made by CountUserDevices.
+const countTimeout = 5 * time.Second
+
+// Client asks devices-api about the devices that belong to an account.
+type Client struct {
+	client UserDeviceServiceClient
+}
+
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{client: NewUserDeviceServiceClient(cc)}
+}
+
+// CountUserDevices returns the number of distinct devices that belong to the auth
+// server user or to any of the wallets. The lookups run concurrently and fail
+// together if any one fails or they don't finish in time.
+func (c *Client) CountUserDevices(ctx context.Context, userID string, wallets []common.Address) (int, error) {
+	reqs := []*ListUserDevicesForUserRequest{{UserId: userID}}
+	for _, w := range wallets {
+		reqs = append(reqs, &ListUserDevicesForUserRequest{UserId: userID, EthereumAddress: w.Hex()})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, countTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	seen := make(map[string]struct{})
+
+	group, gctx := errgroup.WithContext(ctx)
+	for _, req := range reqs {
+		group.Go(func() error {
+			resp, err := c.client.ListUserDevicesForUser(gctx, req)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, ud := range resp.UserDevices {
+				seen[ud.Id] = struct{}{}
+			}
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return 0, err
+	}
+
+	return len(seen), nil
+}
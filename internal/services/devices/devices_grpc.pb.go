@@ -0,0 +1,124 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.1
+// source: internal/services/devices/devices.proto
+
+// The subset of devices-api's UserDeviceService that we call. Names and field
+// numbers must stay in sync with devices-api.
+
+package devices
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UserDeviceService_ListUserDevicesForUser_FullMethodName = "/devices.UserDeviceService/ListUserDevicesForUser"
+)
+
+// UserDeviceServiceClient is the client API for UserDeviceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserDeviceServiceClient interface {
+	ListUserDevicesForUser(ctx context.Context, in *ListUserDevicesForUserRequest, opts ...grpc.CallOption) (*ListUserDevicesForUserResponse, error)
+}
+
+type userDeviceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserDeviceServiceClient(cc grpc.ClientConnInterface) UserDeviceServiceClient {
+	return &userDeviceServiceClient{cc}
+}
+
+func (c *userDeviceServiceClient) ListUserDevicesForUser(ctx context.Context, in *ListUserDevicesForUserRequest, opts ...grpc.CallOption) (*ListUserDevicesForUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserDevicesForUserResponse)
+	err := c.cc.Invoke(ctx, UserDeviceService_ListUserDevicesForUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserDeviceServiceServer is the server API for UserDeviceService service.
+// All implementations must embed UnimplementedUserDeviceServiceServer
+// for forward compatibility.
+type UserDeviceServiceServer interface {
+	ListUserDevicesForUser(context.Context, *ListUserDevicesForUserRequest) (*ListUserDevicesForUserResponse, error)
+	mustEmbedUnimplementedUserDeviceServiceServer()
+}
+
+// UnimplementedUserDeviceServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserDeviceServiceServer struct{}
+
+func (UnimplementedUserDeviceServiceServer) ListUserDevicesForUser(context.Context, *ListUserDevicesForUserRequest) (*ListUserDevicesForUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserDevicesForUser not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) mustEmbedUnimplementedUserDeviceServiceServer() {}
+func (UnimplementedUserDeviceServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeUserDeviceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserDeviceServiceServer will
+// result in compilation errors.
+type UnsafeUserDeviceServiceServer interface {
+	mustEmbedUnimplementedUserDeviceServiceServer()
+}
+
+func RegisterUserDeviceServiceServer(s grpc.ServiceRegistrar, srv UserDeviceServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUserDeviceServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserDeviceService_ServiceDesc, srv)
+}
+
+func _UserDeviceService_ListUserDevicesForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserDevicesForUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).ListUserDevicesForUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_ListUserDevicesForUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).ListUserDevicesForUser(ctx, req.(*ListUserDevicesForUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserDeviceService_ServiceDesc is the grpc.ServiceDesc for UserDeviceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserDeviceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "devices.UserDeviceService",
+	HandlerType: (*UserDeviceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUserDevicesForUser",
+			Handler:    _UserDeviceService_ListUserDevicesForUser_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/services/devices/devices.proto",
+}
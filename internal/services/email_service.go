@@ -15,7 +15,12 @@ import (
 )
 
 type EmailService interface {
-	SendConfirmationEmail(ctx context.Context, emailTemplate *template.Template, userEmail, confCode string) error
+	// SendConfirmationEmail mails the confirmation code to the user. If confLink is
+	// non-empty then the email also carries a link that confirms the address.
+	SendConfirmationEmail(ctx context.Context, emailTemplate *template.Template, userEmail, confCode, confLink string) error
+	// SendEmailChangedNotification tells the owner of the old address that the
+	// account's email has been changed to the new one.
+	SendEmailChangedNotification(ctx context.Context, emailTemplate *template.Template, oldEmail, newEmail string) error
 }
 
 type emailSvc struct {
@@ -25,7 +30,6 @@ type emailSvc struct {
 	emailPassword string
 	emailHost     string
 	emailPort     string
-	emailTemplate *template.Template
 }
 
 func NewEmailService(settings *config.Settings) EmailService {
@@ -39,7 +43,25 @@ func NewEmailService(settings *config.Settings) EmailService {
 	}
 }
 
-func (e *emailSvc) SendConfirmationEmail(ctx context.Context, emailTemplate *template.Template, userEmail, confCode string) error {
+func (e *emailSvc) SendConfirmationEmail(ctx context.Context, emailTemplate *template.Template, userEmail, confCode, confLink string) error {
+	text := "Hi,\r\n\r\nYour email verification code is: " + confCode + "\r\n"
+	if confLink != "" {
+		text += "\r\nYou can also verify your email by opening this link: " + confLink + "\r\n"
+	}
+
+	return e.send(userEmail, "[DIMO] Verification Code", text, emailTemplate, struct{ Code, Link string }{confCode, confLink})
+}
+
+func (e *emailSvc) SendEmailChangedNotification(ctx context.Context, emailTemplate *template.Template, oldEmail, newEmail string) error {
+	text := "Hi,\r\n\r\nThe email address on your DIMO account has been changed to " + newEmail + ".\r\n" +
+		"\r\nIf you did not make this change, please contact DIMO support.\r\n"
+
+	return e.send(oldEmail, "[DIMO] Email Address Changed", text, emailTemplate, struct{ NewEmail string }{newEmail})
+}
+
+// send mails a multipart message with the given plain text and the HTML produced
+// by executing the template with data.
+func (e *emailSvc) send(userEmail, subject, text string, emailTemplate *template.Template, data any) error {
 	auth := smtp.PlainAuth("", e.emailUsername, e.emailPassword, e.emailHost)
 	addr := fmt.Sprintf("%s:%s", e.emailHost, e.emailPort)
 
@@ -53,7 +75,7 @@ func (e *emailSvc) SendConfirmationEmail(ctx context.Context, emailTemplate *tem
 	}
 
 	pw := quotedprintable.NewWriter(p)
-	if _, err := pw.Write([]byte("Hi,\r\n\r\nYour email verification code is: " + confCode + "\r\n")); err != nil {
+	if _, err := pw.Write([]byte(text)); err != nil {
 		return err
 	}
 	pw.Close()
@@ -64,7 +86,7 @@ func (e *emailSvc) SendConfirmationEmail(ctx context.Context, emailTemplate *tem
 	}
 
 	hw := quotedprintable.NewWriter(h)
-	if err := e.emailTemplate.Execute(hw, struct{ Code string }{confCode}); err != nil {
+	if err := emailTemplate.Execute(hw, data); err != nil {
 		return err
 	}
 	hw.Close()
@@ -72,7 +94,7 @@ func (e *emailSvc) SendConfirmationEmail(ctx context.Context, emailTemplate *tem
 	var buffer bytes.Buffer
 	buffer.WriteString("From: DIMO <" + e.emailFrom + ">\r\n" +
 		"To: " + userEmail + "\r\n" +
-		"Subject: [DIMO] Verification Code\r\n" +
+		"Subject: " + subject + "\r\n" +
 		"Content-Type: multipart/alternative; boundary=\"" + w.Boundary() + "\"\r\n" +
 		"\r\n")
 	if _, err := partsBuffer.WriteTo(&buffer); err != nil {
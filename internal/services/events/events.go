@@ -0,0 +1,128 @@
+// Package events describes the account lifecycle events that we publish to Kafka
+// and contains the producer that sends them.
+package events
+
+import (
+	"time"
+
+	"github.com/DIMO-Network/shared"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+)
+
+// Source is the CloudEvents source for everything this service produces.
+const Source = "dimo/accounts-api"
+
+// Event types. The subject of each event is the account ID.
+const (
+	AccountCreatedType           = "zone.dimo.account.created"
+	AccountDeletionScheduledType = "zone.dimo.account.deletion.scheduled"
+	AccountRestoredType          = "zone.dimo.account.restored"
+	AccountDeletedType           = "zone.dimo.account.deleted"
+	EmailLinkedType              = "zone.dimo.account.email.linked"
+	EmailConfirmedType           = "zone.dimo.account.email.confirmed"
+	WalletLinkedType             = "zone.dimo.account.wallet.linked"
+	TOSAcceptedType              = "zone.dimo.account.tos.accepted"
+	LegalDocumentAcceptedType    = "zone.dimo.account.legal.accepted"
+	CountryChangedType           = "zone.dimo.account.country.changed"
+	ReferralSubmittedType        = "zone.dimo.account.referral.submitted"
+	ReferralCodeChangedType      = "zone.dimo.account.referral.code.changed"
+)
+
+// Event is an account lifecycle event. Data is one of the structs below, matching
+// the type.
+type Event = shared.CloudEvent[any]
+
+// New creates an event of the given type about the account.
+func New(eventType, accountID string, data any) *Event {
+	return &Event{
+		ID:              ksuid.New().String(),
+		Source:          Source,
+		SpecVersion:     "1.0",
+		Subject:         accountID,
+		Time:            time.Now(),
+		Type:            eventType,
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// AccountCreated is the data for AccountCreatedType. Exactly one of Email and
+// Wallet is set, depending on how the account was created.
+type AccountCreated struct {
+	Email  *string         `json:"email,omitempty"`
+	Wallet *common.Address `json:"wallet,omitempty"`
+}
+
+// AccountDeletionScheduled is the data for AccountDeletionScheduledType. The
+// account is unusable from now on, and will be deleted at PurgeAt unless it is
+// restored.
+type AccountDeletionScheduled struct {
+	PurgeAt time.Time `json:"purgeAt"`
+}
+
+// AccountRestored is the data for AccountRestoredType. It cancels an earlier
+// AccountDeletionScheduledType.
+type AccountRestored struct{}
+
+// AccountDeleted is the data for AccountDeletedType. This is sent when the
+// account is finally purged.
+type AccountDeleted struct{}
+
+// EmailLinked is the data for EmailLinkedType. This is sent when an email is
+// added to an account or replaces its old one. Emails that arrive confirmed won't
+// get a separate EmailConfirmedType event.
+type EmailLinked struct {
+	Email     string `json:"email"`
+	Confirmed bool   `json:"confirmed"`
+}
+
+// EmailConfirmed is the data for EmailConfirmedType.
+type EmailConfirmed struct {
+	Email string `json:"email"`
+}
+
+// WalletLinked is the data for WalletLinkedType.
+type WalletLinked struct {
+	Wallet  common.Address `json:"wallet"`
+	Primary bool           `json:"primary"`
+}
+
+// TOSAccepted is the data for TOSAcceptedType. It is sent alongside
+// LegalDocumentAcceptedType when the document is the terms of service.
+type TOSAccepted struct {
+	Version    string    `json:"version"`
+	AcceptedAt time.Time `json:"acceptedAt"`
+}
+
+// LegalDocumentAccepted is the data for LegalDocumentAcceptedType.
+type LegalDocumentAccepted struct {
+	Kind       string    `json:"kind"`
+	Version    string    `json:"version"`
+	AcceptedAt time.Time `json:"acceptedAt"`
+}
+
+// CountryChanged is the data for CountryChangedType. OldCountryCode is empty if
+// the account had no country before, and CountryCode is empty if the country was
+// removed.
+type CountryChanged struct {
+	OldCountryCode string `json:"oldCountryCode,omitempty"`
+	CountryCode    string `json:"countryCode"`
+}
+
+// ReferralSubmitted is the data for ReferralSubmittedType. Exactly one of
+// ReferrerAccountID and CampaignID is set, depending on whether the code belonged
+// to another user or to a campaign.
+type ReferralSubmitted struct {
+	ReferralCode      string `json:"referralCode"`
+	ReferrerAccountID string `json:"referrerAccountId,omitempty"`
+	CampaignID        string `json:"campaignId,omitempty"`
+}
+
+// ReferralCodeChanged is the data for ReferralCodeChangedType. Until
+// OldCodeExpiresAt, the old code still refers users to the account.
+type ReferralCodeChanged struct {
+	OldCode          string    `json:"oldCode"`
+	Code             string    `json:"code"`
+	OldCodeExpiresAt time.Time `json:"oldCodeExpiresAt"`
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+	"strings"
+
+	"github.com/DIMO-Network/accounts-api/internal/config"
+	"github.com/IBM/sarama"
+	"github.com/goccy/go-json"
+)
+
+// Producer publishes events to the topic EVENTS_TOPIC, keyed by account ID so that
+// each account's events stay in order.
+type Producer struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func NewProducer(settings *config.Settings) (*Producer, error) {
+	kconf := sarama.NewConfig()
+	kconf.Version = sarama.V3_6_0_0
+	kconf.Producer.Return.Successes = true
+	kconf.Producer.RequiredAcks = sarama.WaitForAll
+
+	producer, err := sarama.NewSyncProducer(strings.Split(settings.KafkaBrokers, ","), kconf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Producer{
+		producer: producer,
+		topic:    settings.EventsTopic,
+	}, nil
+}
+
+func (p *Producer) Emit(ctx context.Context, event *Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: p.topic,
+		Key:   sarama.StringEncoder(event.Subject),
+		Value: sarama.ByteEncoder(b),
+	})
+	return err
+}
+
+func (p *Producer) Close() error {
+	return p.producer.Close()
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DIMO-Network/accounts-api/internal/config"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type IdentityService interface {
+	// VehiclesOwned reports whether the address owns any vehicle NFTs.
+	VehiclesOwned(ctx context.Context, ethAddr common.Address) (bool, error)
+	// AftermarketDevicesOwned reports whether the address owns any aftermarket
+	// device NFTs.
+	AftermarketDevicesOwned(ctx context.Context, ethAddr common.Address) (bool, error)
+}
+
+const (
+	vehiclesOwnedQuery = `query ($owner: Address!) {
+  vehicles(first: 1, filterBy: {owner: $owner}) {
+    totalCount
+  }
+}`
+	aftermarketDevicesOwnedQuery = `query ($owner: Address!) {
+  aftermarketDevices(first: 1, filterBy: {owner: $owner}) {
+    totalCount
+  }
+}`
+)
+
+type identitySvc struct {
+	url    string
+	client *http.Client
+}
+
+func NewIdentityService(settings *config.Settings) IdentityService {
+	return &identitySvc{
+		url:    settings.IdentityAPIURL,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Data struct {
+		Vehicles struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"vehicles"`
+		AftermarketDevices struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"aftermarketDevices"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (i *identitySvc) VehiclesOwned(ctx context.Context, ethAddr common.Address) (bool, error) {
+	resp, err := i.query(ctx, vehiclesOwnedQuery, ethAddr)
+	if err != nil {
+		return false, err
+	}
+
+	return resp.Data.Vehicles.TotalCount != 0, nil
+}
+
+func (i *identitySvc) AftermarketDevicesOwned(ctx context.Context, ethAddr common.Address) (bool, error) {
+	resp, err := i.query(ctx, aftermarketDevicesOwnedQuery, ethAddr)
+	if err != nil {
+		return false, err
+	}
+
+	return resp.Data.AftermarketDevices.TotalCount != 0, nil
+}
+
+func (i *identitySvc) query(ctx context.Context, query string, owner common.Address) (*graphQLResponse, error) {
+	reqBody, err := json.Marshal(graphQLRequest{
+		Query:     query,
+		Variables: map[string]any{"owner": owner.Hex()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.url, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := i.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close() //nolint
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("identity API returned status %d", res.StatusCode)
+	}
+
+	var out graphQLResponse
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+
+	if len(out.Errors) != 0 {
+		return nil, errors.New(out.Errors[0].Message)
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,253 @@
+// Package siwe parses and verifies Sign-In with Ethereum messages, as described
+// in EIP-4361.
+package siwe
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	headerSuffix   = " wants you to sign in with your Ethereum account:"
+	uriTag         = "URI: "
+	versionTag     = "Version: "
+	chainIDTag     = "Chain ID: "
+	nonceTag       = "Nonce: "
+	issuedAtTag    = "Issued At: "
+	expirationTag  = "Expiration Time: "
+	notBeforeTag   = "Not Before: "
+	requestIDTag   = "Request ID: "
+	resourcesTag   = "Resources:"
+	resourcePrefix = "- "
+)
+
+var noncePattern = regexp.MustCompile(`^[a-zA-Z0-9]{8,}$`)
+
+// Message is a parsed EIP-4361 message. Optional fields are nil when absent.
+type Message struct {
+	Domain         string
+	Address        common.Address
+	Statement      *string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      *string
+	Resources      []string
+}
+
+// Parse parses the message text that the user signed. The address must be
+// EIP-55 checksummed.
+func Parse(raw string) (*Message, error) {
+	lines := strings.Split(raw, "\n")
+	p := parser{lines: lines}
+
+	header, ok := p.next()
+	if !ok || !strings.HasSuffix(header, headerSuffix) {
+		return nil, errors.New("missing header")
+	}
+
+	m := &Message{Domain: strings.TrimSuffix(header, headerSuffix)}
+	if scheme, rest, found := strings.Cut(m.Domain, "://"); found {
+		if scheme == "" {
+			return nil, errors.New("empty scheme")
+		}
+		m.Domain = rest
+	}
+	if m.Domain == "" {
+		return nil, errors.New("empty domain")
+	}
+
+	addr, ok := p.next()
+	if !ok || !common.IsHexAddress(addr) {
+		return nil, errors.New("invalid address")
+	}
+	m.Address = common.HexToAddress(addr)
+	if m.Address.Hex() != addr {
+		return nil, errors.New("address is not EIP-55 checksummed")
+	}
+
+	if blank, ok := p.next(); !ok || blank != "" {
+		return nil, errors.New("expected blank line after address")
+	}
+
+	line, ok := p.next()
+	if !ok {
+		return nil, errors.New("message ends early")
+	}
+	if line != "" {
+		if strings.HasPrefix(line, uriTag) {
+			return nil, errors.New("expected blank line before URI")
+		}
+		statement := line
+		m.Statement = &statement
+		if blank, ok := p.next(); !ok || blank != "" {
+			return nil, errors.New("expected blank line after statement")
+		}
+	}
+
+	var err error
+
+	if m.URI, err = p.required(uriTag); err != nil {
+		return nil, err
+	}
+
+	if m.Version, err = p.required(versionTag); err != nil {
+		return nil, err
+	}
+	if m.Version != "1" {
+		return nil, fmt.Errorf("unsupported version %q", m.Version)
+	}
+
+	chainID, err := p.required(chainIDTag)
+	if err != nil {
+		return nil, err
+	}
+	if m.ChainID, err = strconv.ParseInt(chainID, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid chain ID %q", chainID)
+	}
+
+	if m.Nonce, err = p.required(nonceTag); err != nil {
+		return nil, err
+	}
+	if !noncePattern.MatchString(m.Nonce) {
+		return nil, errors.New("nonce must be at least 8 alphanumeric characters")
+	}
+
+	issuedAt, err := p.required(issuedAtTag)
+	if err != nil {
+		return nil, err
+	}
+	if m.IssuedAt, err = time.Parse(time.RFC3339, issuedAt); err != nil {
+		return nil, fmt.Errorf("invalid issued at time %q", issuedAt)
+	}
+
+	if s, ok := p.optional(expirationTag); ok {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiration time %q", s)
+		}
+		m.ExpirationTime = &t
+	}
+
+	if s, ok := p.optional(notBeforeTag); ok {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid not before time %q", s)
+		}
+		m.NotBefore = &t
+	}
+
+	if s, ok := p.optional(requestIDTag); ok {
+		m.RequestID = &s
+	}
+
+	if line, ok := p.peek(); ok && line == resourcesTag {
+		p.next()
+		for {
+			line, ok := p.peek()
+			if !ok || !strings.HasPrefix(line, resourcePrefix) {
+				break
+			}
+			p.next()
+			m.Resources = append(m.Resources, strings.TrimPrefix(line, resourcePrefix))
+		}
+	}
+
+	// Tolerate a single trailing newline.
+	if line, ok := p.next(); ok && (line != "" || p.pos != len(p.lines)) {
+		return nil, fmt.Errorf("unexpected line %q", line)
+	}
+
+	return m, nil
+}
+
+// Valid reports whether the message may be used at time t, based on its
+// expiration and not-before times.
+func (m *Message) Valid(t time.Time) error {
+	if m.ExpirationTime != nil && !t.Before(*m.ExpirationTime) {
+		return errors.New("message has expired")
+	}
+	if m.NotBefore != nil && t.Before(*m.NotBefore) {
+		return errors.New("message is not yet valid")
+	}
+	return nil
+}
+
+// VerifySignature checks that the hex-encoded personal_sign signature of raw was
+// made by the message's address. Only externally owned accounts are supported.
+func (m *Message) VerifySignature(raw, signature string) error {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	if len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("signature has length %d, expected %d", len(sig), crypto.SignatureLength)
+	}
+
+	// Wallets produce a recovery ID of 27 or 28, go-ethereum expects 0 or 1.
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(raw)), sig)
+	if err != nil {
+		return fmt.Errorf("couldn't recover signer: %w", err)
+	}
+
+	if signer := crypto.PubkeyToAddress(*pub); signer != m.Address {
+		return fmt.Errorf("message signed by %s, not %s", signer, m.Address)
+	}
+
+	return nil
+}
+
+type parser struct {
+	lines []string
+	pos   int
+}
+
+func (p *parser) peek() (string, bool) {
+	if p.pos >= len(p.lines) {
+		return "", false
+	}
+	return p.lines[p.pos], true
+}
+
+func (p *parser) next() (string, bool) {
+	line, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return line, ok
+}
+
+func (p *parser) required(tag string) (string, error) {
+	value, ok := p.optional(tag)
+	if !ok {
+		return "", fmt.Errorf("missing %q", strings.TrimSuffix(tag, ": "))
+	}
+	return value, nil
+}
+
+func (p *parser) optional(tag string) (string, bool) {
+	line, ok := p.peek()
+	if !ok || !strings.HasPrefix(line, tag) {
+		return "", false
+	}
+	p.next()
+	return strings.TrimPrefix(line, tag), true
+}
@@ -0,0 +1,135 @@
+package siwe
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fullMessage = `https://service.invalid wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+I accept the ServiceOrg Terms of Service: https://service.invalid/tos
+
+URI: https://service.invalid/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Expiration Time: 2021-10-01T16:25:24Z
+Not Before: 2021-09-30T16:00:00Z
+Request ID: some-request
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+const minimalMessage = `service.invalid wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+
+URI: https://service.invalid/login
+Version: 1
+Chain ID: 80002
+Nonce: abcdefgh12
+Issued At: 2021-09-30T16:25:24.000Z`
+
+func TestParseFull(t *testing.T) {
+	m, err := Parse(fullMessage)
+	require.NoError(t, err)
+
+	assert.Equal(t, "service.invalid", m.Domain)
+	assert.Equal(t, common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"), m.Address)
+	require.NotNil(t, m.Statement)
+	assert.Equal(t, "I accept the ServiceOrg Terms of Service: https://service.invalid/tos", *m.Statement)
+	assert.Equal(t, "https://service.invalid/login", m.URI)
+	assert.Equal(t, "1", m.Version)
+	assert.Equal(t, int64(1), m.ChainID)
+	assert.Equal(t, "32891756", m.Nonce)
+	assert.Equal(t, time.Date(2021, 9, 30, 16, 25, 24, 0, time.UTC), m.IssuedAt.UTC())
+	require.NotNil(t, m.ExpirationTime)
+	assert.Equal(t, time.Date(2021, 10, 1, 16, 25, 24, 0, time.UTC), m.ExpirationTime.UTC())
+	require.NotNil(t, m.NotBefore)
+	require.NotNil(t, m.RequestID)
+	assert.Equal(t, "some-request", *m.RequestID)
+	assert.Len(t, m.Resources, 2)
+}
+
+func TestParseMinimal(t *testing.T) {
+	m, err := Parse(minimalMessage)
+	require.NoError(t, err)
+
+	assert.Equal(t, "service.invalid", m.Domain)
+	assert.Nil(t, m.Statement)
+	assert.Equal(t, int64(80002), m.ChainID)
+	assert.Nil(t, m.ExpirationTime)
+	assert.Nil(t, m.NotBefore)
+	assert.Nil(t, m.RequestID)
+	assert.Empty(t, m.Resources)
+
+	_, err = Parse(minimalMessage + "\n")
+	assert.NoError(t, err)
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{"empty", ""},
+		{"unchecksummed address", strings.Replace(minimalMessage, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", 1)},
+		{"missing blank before URI", strings.Replace(minimalMessage, "\n\n\nURI", "\n\nURI", 1)},
+		{"wrong version", strings.Replace(minimalMessage, "Version: 1", "Version: 2", 1)},
+		{"bad chain ID", strings.Replace(minimalMessage, "Chain ID: 80002", "Chain ID: polygon", 1)},
+		{"short nonce", strings.Replace(minimalMessage, "Nonce: abcdefgh12", "Nonce: abc", 1)},
+		{"missing nonce", strings.Replace(minimalMessage, "Nonce: abcdefgh12\n", "", 1)},
+		{"bad issued at", strings.Replace(minimalMessage, "2021-09-30T16:25:24.000Z", "yesterday", 1)},
+		{"trailing junk", minimalMessage + "\nExtra: line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.message)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	m, err := Parse(fullMessage)
+	require.NoError(t, err)
+
+	assert.Error(t, m.Valid(time.Date(2021, 9, 30, 15, 0, 0, 0, time.UTC)))
+	assert.NoError(t, m.Valid(time.Date(2021, 9, 30, 17, 0, 0, 0, time.UTC)))
+	assert.Error(t, m.Valid(time.Date(2021, 10, 2, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	raw := strings.Replace(minimalMessage, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", addr.Hex(), 1)
+	m, err := Parse(raw)
+	require.NoError(t, err)
+
+	sig, err := crypto.Sign(accounts.TextHash([]byte(raw)), key)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27
+
+	assert.NoError(t, m.VerifySignature(raw, hexutil.Encode(sig)))
+	assert.Error(t, m.VerifySignature(raw+" ", hexutil.Encode(sig)))
+	assert.Error(t, m.VerifySignature(raw, "0x1234"))
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherSig, err := crypto.Sign(accounts.TextHash([]byte(raw)), other)
+	require.NoError(t, err)
+	assert.Error(t, m.VerifySignature(raw, hexutil.Encode(otherSig)))
+}
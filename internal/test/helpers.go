@@ -4,18 +4,23 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"database/sql"
+	"errors"
 	"fmt"
 	"html/template"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/DIMO-Network/accounts-api/internal/config"
 	"github.com/DIMO-Network/accounts-api/internal/services"
+	"github.com/DIMO-Network/accounts-api/internal/services/devices"
+	"github.com/DIMO-Network/accounts-api/internal/services/events"
 	"github.com/DIMO-Network/accounts-api/models"
 
 	"github.com/DIMO-Network/shared/db"
@@ -28,8 +33,14 @@ import (
 	"github.com/segmentio/ksuid"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 const testDbName = "accounts_api"
@@ -266,44 +277,216 @@ func GenerateWallet() (*ecdsa.PrivateKey, *common.Address, error) {
 	return privateKey, &userAddr, nil
 }
 
-type identityService struct {
+// IdentityService answers every ownership question with IdentityServiceResponse.
+type IdentityService struct {
 }
 
+var _ services.IdentityService = (*IdentityService)(nil)
+
 var IdentityServiceResponse bool = true
 
-func (i *identityService) VehiclesOwned(ctx context.Context, ethAddr common.Address) (bool, error) {
+func (i *IdentityService) VehiclesOwned(ctx context.Context, ethAddr common.Address) (bool, error) {
 	return IdentityServiceResponse, nil
 }
 
-func (i *identityService) AftermarketDevicesOwned(ctx context.Context, ethAddr common.Address) (bool, error) {
+func (i *IdentityService) AftermarketDevicesOwned(ctx context.Context, ethAddr common.Address) (bool, error) {
 	return IdentityServiceResponse, nil
 }
 
-type emailService struct {
+// EmailService records the confirmation codes and links it is asked to send
+// instead of mailing them.
+type EmailService struct {
+	mu      sync.Mutex
+	codes   map[string]string
+	links   map[string]string
+	notices map[string]string
+	failing bool
+}
+
+var _ services.EmailService = (*EmailService)(nil)
+
+func NewEmailService() *EmailService {
+	return &EmailService{codes: make(map[string]string), links: make(map[string]string), notices: make(map[string]string)}
+}
+
+func (e *EmailService) SendConfirmationEmail(ctx context.Context, emailTemplate *template.Template, userEmail, confCode, confLink string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.failing {
+		return errors.New("email service unavailable")
+	}
+	e.codes[userEmail] = confCode
+	e.links[userEmail] = confLink
+	return nil
+}
+
+func (e *EmailService) SendEmailChangedNotification(ctx context.Context, emailTemplate *template.Template, oldEmail, newEmail string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.notices[oldEmail] = newEmail
+	return nil
+}
+
+// SetFailing makes confirmation emails fail to send until it's called again with
+// false.
+func (e *EmailService) SetFailing(failing bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failing = failing
+}
+
+// LastCode returns the most recent confirmation code sent to the given address.
+func (e *EmailService) LastCode(userEmail string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.codes[userEmail]
+}
+
+// LastLink returns the most recent confirmation link sent to the given address.
+func (e *EmailService) LastLink(userEmail string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.links[userEmail]
 }
 
-func NewEmailService() services.EmailService {
-	return &emailService{}
+// ChangeNotice returns the new address from the most recent notification sent
+// to the old address about an email change.
+func (e *EmailService) ChangeNotice(oldEmail string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.notices[oldEmail]
 }
 
-func (e *emailService) SendConfirmationEmail(ctx context.Context, emailTemplate *template.Template, userEmail, confCode string) error {
+// EventProducer records the events it is asked to publish.
+type EventProducer struct {
+	mu     sync.Mutex
+	events []*events.Event
+}
+
+func (e *EventProducer) Emit(ctx context.Context, event *events.Event) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, event)
 	return nil
 }
 
+// Types returns the types of the events published about the account, oldest
+// first.
+func (e *EventProducer) Types(accountID string) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var out []string
+	for _, ev := range e.events {
+		if ev.Subject == accountID {
+			out = append(out, ev.Type)
+		}
+	}
+	return out
+}
+
+// DevicesServer is a fake devices-api. Devices are assigned to auth server user
+// IDs or to wallets with SetDevices.
+type DevicesServer struct {
+	devices.UnimplementedUserDeviceServiceServer
+	mu      sync.Mutex
+	byOwner map[string][]string
+	failing bool
+}
+
+// StartDevicesServer runs a DevicesServer in-process and returns it along with a
+// connection to it. Both are shut down when the test ends.
+func StartDevicesServer(t *testing.T) (*DevicesServer, *grpc.ClientConn) {
+	lis := bufconn.Listen(1024 * 1024)
+
+	fake := &DevicesServer{byOwner: make(map[string][]string)}
+
+	serv := grpc.NewServer()
+	devices.RegisterUserDeviceServiceServer(serv, fake)
+	go func() {
+		if err := serv.Serve(lis); err != nil {
+			t.Logf("Fake devices-api stopped: %v", err)
+		}
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		serv.Stop()
+	})
+
+	return fake, conn
+}
+
+// SetDevices replaces the devices belonging to the owner, either a user ID or a
+// hex wallet address.
+func (d *DevicesServer) SetDevices(owner string, deviceIDs ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if common.IsHexAddress(owner) {
+		owner = common.HexToAddress(owner).Hex()
+	}
+	d.byOwner[owner] = deviceIDs
+}
+
+// SetFailing makes device lookups fail as if devices-api were down until it's
+// called again with false.
+func (d *DevicesServer) SetFailing(failing bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.failing = failing
+}
+
+func (d *DevicesServer) ListUserDevicesForUser(ctx context.Context, req *devices.ListUserDevicesForUserRequest) (*devices.ListUserDevicesForUserResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.failing {
+		return nil, status.Error(codes.Unavailable, "devices-api is down")
+	}
+
+	owner := req.UserId
+	if req.EthereumAddress != "" {
+		owner = common.HexToAddress(req.EthereumAddress).Hex()
+	}
+
+	out := &devices.ListUserDevicesForUserResponse{}
+	for _, id := range d.byOwner[owner] {
+		out.UserDevices = append(out.UserDevices, &devices.UserDevice{Id: id})
+	}
+	return out, nil
+}
+
+// NewAccount inserts an account with a random email, wallet, and referral code.
 func NewAccount(exec boil.ContextExecutor) (*models.Account, error) {
+	id := ksuid.New().String()
+
+	_, addr, err := GenerateWallet()
+	if err != nil {
+		return nil, err
+	}
+
 	acct := models.Account{
-		ID:           ksuid.New().String(),
-		ReferralCode: "GBI56X",
+		ID:                  id,
+		ReferralCode:        strings.ToUpper(id[len(id)-6:]),
+		FirstWalletLinkedAt: null.TimeFrom(time.Now()),
 	}
 
 	eml := models.Email{
 		AccountID: acct.ID,
-		Address:   "testemail@gmail.com",
+		Address:   strings.ToLower(id) + "@example.com",
 	}
 
 	wallet := models.Wallet{
 		AccountID: acct.ID,
-		Address:   common.Hex2Bytes("5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"),
+		Address:   addr.Bytes(),
+		IsPrimary: true,
 	}
 
 	if err := acct.Insert(context.Background(), exec, boil.Infer()); err != nil {
@@ -320,12 +503,12 @@ func NewAccount(exec boil.ContextExecutor) (*models.Account, error) {
 
 	return models.Accounts(
 		models.AccountWhere.ID.EQ(acct.ID),
-		qm.Load(models.AccountRels.Wallet),
+		qm.Load(models.AccountRels.Wallets),
 		qm.Load(models.AccountRels.Email),
 	).One(context.Background(), exec)
 }
 
 func DeleteAll(exec boil.ContextExecutor) error {
-	_, err := exec.Exec(`TRUNCATE TABLE accounts_api.accounts CASCADE;`)
+	_, err := exec.Exec(`TRUNCATE TABLE accounts_api.accounts, accounts_api.outbox_messages, accounts_api.referral_campaigns CASCADE;`)
 	return err
 }
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE emails
+    ADD COLUMN confirmation_code text CONSTRAINT emails_confirmation_code_check CHECK (confirmation_code ~ '^[0-9]{6}$'),
+    ADD COLUMN code_expires_at timestamptz,
+    ADD CONSTRAINT emails_confirmation_code_code_expires_at_check CHECK (confirmation_code IS NULL OR code_expires_at IS NOT NULL);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE emails
+    DROP COLUMN code_expires_at,
+    DROP COLUMN confirmation_code;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Outstanding plain-text codes can't be migrated, so those users will need a new code.
+ALTER TABLE emails
+    DROP CONSTRAINT emails_confirmation_code_code_expires_at_check,
+    DROP COLUMN confirmation_code,
+    ADD COLUMN confirmation_code_hash text,
+    ADD COLUMN confirmation_attempts integer NOT NULL DEFAULT 0,
+    ADD CONSTRAINT emails_confirmation_code_hash_code_expires_at_check CHECK (confirmation_code_hash IS NULL OR code_expires_at IS NOT NULL);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE emails
+    DROP CONSTRAINT emails_confirmation_code_hash_code_expires_at_check,
+    DROP COLUMN confirmation_attempts,
+    DROP COLUMN confirmation_code_hash,
+    ADD COLUMN confirmation_code text CONSTRAINT emails_confirmation_code_check CHECK (confirmation_code ~ '^[0-9]{6}$'),
+    ADD CONSTRAINT emails_confirmation_code_code_expires_at_check CHECK (confirmation_code IS NULL OR code_expires_at IS NOT NULL);
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE emails
+    ADD COLUMN code_sent_at timestamptz,
+    ADD COLUMN send_window_started_at timestamptz,
+    ADD COLUMN send_window_count integer NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE emails
+    DROP COLUMN send_window_count,
+    DROP COLUMN send_window_started_at,
+    DROP COLUMN code_sent_at;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Identifies the only confirmation link that is still usable. Cleared once used or replaced.
+ALTER TABLE emails
+    ADD COLUMN link_token_id text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE emails
+    DROP COLUMN link_token_id;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE pending_email_changes(
+    account_id text CONSTRAINT pending_email_changes_pkey PRIMARY KEY CONSTRAINT pending_email_changes_account_id_fkey REFERENCES accounts (id) ON DELETE CASCADE,
+    address text NOT NULL,
+    confirmation_code_hash text NOT NULL,
+    code_expires_at timestamptz NOT NULL,
+    confirmation_attempts integer NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE pending_email_changes;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE siwe_nonces(
+    nonce text CONSTRAINT siwe_nonces_pkey PRIMARY KEY,
+    account_id text NOT NULL CONSTRAINT siwe_nonces_account_id_fkey REFERENCES accounts (id) ON DELETE CASCADE,
+    expires_at timestamptz NOT NULL
+);
+
+CREATE INDEX siwe_nonces_account_id_idx ON siwe_nonces (account_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE siwe_nonces;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE wallets
+    DROP CONSTRAINT wallets_account_id_key,
+    ADD COLUMN is_primary boolean NOT NULL DEFAULT false,
+    ADD COLUMN created_at timestamptz NOT NULL DEFAULT now();
+
+-- Every existing wallet is the only one on its account.
+UPDATE wallets SET is_primary = true;
+
+CREATE INDEX wallets_account_id_idx ON wallets (account_id);
+
+-- At most one primary wallet per account.
+CREATE UNIQUE INDEX wallets_account_id_is_primary_key ON wallets (account_id, is_primary) WHERE is_primary;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM wallets WHERE NOT is_primary;
+
+DROP INDEX wallets_account_id_is_primary_key;
+DROP INDEX wallets_account_id_idx;
+
+ALTER TABLE wallets
+    DROP COLUMN created_at,
+    DROP COLUMN is_primary,
+    ADD CONSTRAINT wallets_account_id_key UNIQUE (account_id);
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE outbox_messages(
+    id text CONSTRAINT outbox_messages_pkey PRIMARY KEY,
+    kind text NOT NULL,
+    payload jsonb NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    last_error text,
+    dead_lettered_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX outbox_messages_pending_idx ON outbox_messages (next_attempt_at) WHERE dead_lettered_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE outbox_messages;
+-- +goose StatementEnd
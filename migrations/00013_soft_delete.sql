@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE accounts
+    ADD COLUMN deleted_at timestamptz,
+    ADD COLUMN purge_at timestamptz,
+    ADD CONSTRAINT accounts_deleted_at_purge_at_check CHECK ((deleted_at IS NULL) = (purge_at IS NULL));
+
+CREATE INDEX accounts_purge_at_idx ON accounts (purge_at) WHERE purge_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX accounts_purge_at_idx;
+
+ALTER TABLE accounts
+    DROP CONSTRAINT accounts_deleted_at_purge_at_check,
+    DROP COLUMN purge_at,
+    DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- New versions of documents are published by adding rows here, in later migrations.
+CREATE TABLE legal_documents(
+    kind text CONSTRAINT legal_documents_kind_check CHECK (kind IN ('tos', 'privacy', 'data_sharing')),
+    version text,
+    effective_at timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT legal_documents_pkey PRIMARY KEY (kind, version)
+);
+
+CREATE TABLE legal_acceptances(
+    account_id text CONSTRAINT legal_acceptances_account_id_fkey REFERENCES accounts (id) ON DELETE CASCADE,
+    kind text,
+    version text,
+    accepted_at timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT legal_acceptances_pkey PRIMARY KEY (account_id, kind, version),
+    CONSTRAINT legal_acceptances_kind_version_fkey FOREIGN KEY (kind, version) REFERENCES legal_documents (kind, version)
+);
+
+INSERT INTO legal_documents (kind, version, effective_at) VALUES
+    ('tos', '1', '2021-12-01T00:00:00Z'),
+    ('privacy', '1', '2021-12-01T00:00:00Z'),
+    ('data_sharing', '1', '2021-12-01T00:00:00Z');
+
+-- Everyone who accepted the terms so far accepted the first version of each
+-- document, since the old single agreement covered all three.
+INSERT INTO legal_acceptances (account_id, kind, version, accepted_at)
+    SELECT a.id, d.kind, '1', a.accepted_tos_at
+    FROM accounts a CROSS JOIN (VALUES ('tos'), ('privacy'), ('data_sharing')) AS d (kind)
+    WHERE a.accepted_tos_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE legal_acceptances;
+DROP TABLE legal_documents;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A missing row means the user never opted in.
+CREATE TABLE communication_preferences(
+    account_id text CONSTRAINT communication_preferences_account_id_fkey REFERENCES accounts (id) ON DELETE CASCADE,
+    channel text CONSTRAINT communication_preferences_channel_check CHECK (channel IN ('marketing_email', 'product_updates', 'push_notifications')),
+    enabled boolean NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT communication_preferences_pkey PRIMARY KEY (account_id, channel)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE communication_preferences;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE accounts
+    ADD COLUMN display_name text CONSTRAINT accounts_display_name_check CHECK (char_length(display_name) BETWEEN 1 AND 64),
+    ADD COLUMN language text,
+    ADD COLUMN time_zone text,
+    ADD COLUMN units text CONSTRAINT accounts_units_check CHECK (units IN ('metric', 'imperial'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE accounts
+    DROP COLUMN units,
+    DROP COLUMN time_zone,
+    DROP COLUMN language,
+    DROP COLUMN display_name;
+-- +goose StatementEnd
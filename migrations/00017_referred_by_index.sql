@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Backs the referee listing, which pages through these newest first.
+CREATE INDEX accounts_referred_by_idx ON accounts (referred_by, referred_at DESC, id DESC) WHERE referred_by IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX accounts_referred_by_idx;
+-- +goose StatementEnd
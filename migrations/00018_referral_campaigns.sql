@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Campaign codes are longer than the six-character account codes, so the two
+-- never collide. They're stored upper-case and matched case-insensitively.
+CREATE TABLE referral_campaigns(
+    id text CONSTRAINT referral_campaigns_pkey PRIMARY KEY,
+    name text NOT NULL,
+    code text NOT NULL
+        CONSTRAINT referral_campaigns_code_key UNIQUE
+        CONSTRAINT referral_campaigns_code_check CHECK (code ~ '^[A-Z0-9][A-Z0-9-]{5,30}[A-Z0-9]$'),
+    starts_at timestamptz,
+    ends_at timestamptz,
+    max_uses integer CONSTRAINT referral_campaigns_max_uses_check CHECK (max_uses > 0),
+    -- Counts every submission, so that purging accounts doesn't free up uses.
+    uses integer NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT referral_campaigns_dates_check CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at),
+    CONSTRAINT referral_campaigns_uses_check CHECK (uses >= 0 AND (max_uses IS NULL OR uses <= max_uses))
+);
+
+ALTER TABLE accounts
+    ADD COLUMN referral_campaign_id text CONSTRAINT accounts_referral_campaign_id_fkey REFERENCES referral_campaigns (id),
+    ADD CONSTRAINT accounts_referral_campaign_id_check CHECK (referral_campaign_id IS NULL OR (referred_at IS NOT NULL AND referred_by IS NULL));
+
+CREATE INDEX accounts_referral_campaign_id_idx ON accounts (referral_campaign_id) WHERE referral_campaign_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE accounts
+    DROP COLUMN referral_campaign_id;
+
+DROP TABLE referral_campaigns;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Codes that accounts have replaced. They're kept after they stop working so
+-- that they're never handed out again.
+CREATE TABLE retired_referral_codes(
+    code text CONSTRAINT retired_referral_codes_pkey PRIMARY KEY,
+    account_id text NOT NULL CONSTRAINT retired_referral_codes_account_id_fkey REFERENCES accounts (id) ON DELETE CASCADE,
+    retired_at timestamptz NOT NULL DEFAULT now(),
+    -- Until this time, submitting the code still refers the user to the account.
+    expires_at timestamptz NOT NULL,
+
+    CONSTRAINT retired_referral_codes_expires_at_check CHECK (expires_at >= retired_at)
+);
+
+CREATE INDEX retired_referral_codes_account_id_idx ON retired_referral_codes (account_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE retired_referral_codes;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Messages that share an ordering key are delivered one at a time, in seq order.
+ALTER TABLE outbox_messages
+    ADD COLUMN ordering_key text,
+    ADD COLUMN seq bigserial NOT NULL;
+
+CREATE INDEX outbox_messages_ordering_key_idx ON outbox_messages (ordering_key, seq) WHERE dead_lettered_at IS NULL AND ordering_key IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox_messages
+    DROP COLUMN seq,
+    DROP COLUMN ordering_key;
+-- +goose StatementEnd
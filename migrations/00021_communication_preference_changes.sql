@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every change to a communication preference, kept as evidence of consent.
+-- Rows are only ever inserted.
+CREATE TABLE communication_preference_changes(
+    id bigserial CONSTRAINT communication_preference_changes_pkey PRIMARY KEY,
+    account_id text NOT NULL CONSTRAINT communication_preference_changes_account_id_fkey REFERENCES accounts (id) ON DELETE CASCADE,
+    channel text NOT NULL CONSTRAINT communication_preference_changes_channel_check CHECK (channel IN ('marketing_email', 'product_updates', 'push_notifications')),
+    enabled boolean NOT NULL,
+    changed_at timestamptz NOT NULL DEFAULT now(),
+    -- What made the change, such as the user through the API.
+    source text NOT NULL
+);
+
+CREATE INDEX communication_preference_changes_account_id_idx ON communication_preference_changes (account_id, changed_at);
+
+CREATE FUNCTION communication_preference_changes_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'communication_preference_changes is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER communication_preference_changes_no_update BEFORE UPDATE ON communication_preference_changes
+    FOR EACH ROW EXECUTE FUNCTION communication_preference_changes_immutable();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE communication_preference_changes;
+DROP FUNCTION communication_preference_changes_immutable;
+-- +goose StatementEnd
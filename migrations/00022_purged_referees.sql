@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Referees whose accounts have been purged. Their rows are gone, so without this
+-- they would silently drop out of the referrer's totals. Purges before this
+-- migration weren't counted.
+ALTER TABLE accounts ADD COLUMN purged_referees integer NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE accounts DROP COLUMN purged_referees;
+-- +goose StatementEnd
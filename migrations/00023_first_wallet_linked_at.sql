@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- When the account first had a wallet, which opens its window for entering a
+-- referral code. Unlike wallets.created_at, it never moves once set.
+ALTER TABLE accounts ADD COLUMN first_wallet_linked_at timestamptz;
+
+-- Wallet timestamps can't tell us when existing accounts got their first
+-- wallet, since replacing or re-adding a wallet reset them. The account can't
+-- have had one before it was created, so start their windows then.
+UPDATE accounts SET first_wallet_linked_at = accounts.created_at
+WHERE EXISTS (SELECT 1 FROM wallets WHERE wallets.account_id = accounts.id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE accounts DROP COLUMN first_wallet_linked_at;
+-- +goose StatementEnd
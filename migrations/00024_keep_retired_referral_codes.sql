@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Retired codes outlive the account that used them, so that a purged account's
+-- codes are never handed out again.
+ALTER TABLE retired_referral_codes
+    ALTER COLUMN account_id DROP NOT NULL,
+    DROP CONSTRAINT retired_referral_codes_account_id_fkey,
+    ADD CONSTRAINT retired_referral_codes_account_id_fkey FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM retired_referral_codes WHERE account_id IS NULL;
+
+ALTER TABLE retired_referral_codes
+    DROP CONSTRAINT retired_referral_codes_account_id_fkey,
+    ADD CONSTRAINT retired_referral_codes_account_id_fkey FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE,
+    ALTER COLUMN account_id SET NOT NULL;
+-- +goose StatementEnd
@@ -1,4 +1,4 @@
-// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
 
 package models
@@ -24,73 +24,122 @@ import (
 
 // Account is an object representing the database table.
 type Account struct {
-	ID            string      `boil:"id" json:"id" toml:"id" yaml:"id"`
-	CountryCode   null.String `boil:"country_code" json:"country_code,omitempty" toml:"country_code" yaml:"country_code,omitempty"`
-	ReferralCode  string      `boil:"referral_code" json:"referral_code" toml:"referral_code" yaml:"referral_code"`
-	ReferredBy    null.String `boil:"referred_by" json:"referred_by,omitempty" toml:"referred_by" yaml:"referred_by,omitempty"`
-	ReferredAt    null.Time   `boil:"referred_at" json:"referred_at,omitempty" toml:"referred_at" yaml:"referred_at,omitempty"`
-	AcceptedTosAt null.Time   `boil:"accepted_tos_at" json:"accepted_tos_at,omitempty" toml:"accepted_tos_at" yaml:"accepted_tos_at,omitempty"`
-	CreatedAt     time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt     time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID                  string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	CountryCode         null.String `boil:"country_code" json:"country_code,omitempty" toml:"country_code" yaml:"country_code,omitempty"`
+	ReferralCode        string      `boil:"referral_code" json:"referral_code" toml:"referral_code" yaml:"referral_code"`
+	ReferredBy          null.String `boil:"referred_by" json:"referred_by,omitempty" toml:"referred_by" yaml:"referred_by,omitempty"`
+	ReferredAt          null.Time   `boil:"referred_at" json:"referred_at,omitempty" toml:"referred_at" yaml:"referred_at,omitempty"`
+	AcceptedTosAt       null.Time   `boil:"accepted_tos_at" json:"accepted_tos_at,omitempty" toml:"accepted_tos_at" yaml:"accepted_tos_at,omitempty"`
+	CreatedAt           time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt           time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt           null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	PurgeAt             null.Time   `boil:"purge_at" json:"purge_at,omitempty" toml:"purge_at" yaml:"purge_at,omitempty"`
+	DisplayName         null.String `boil:"display_name" json:"display_name,omitempty" toml:"display_name" yaml:"display_name,omitempty"`
+	Language            null.String `boil:"language" json:"language,omitempty" toml:"language" yaml:"language,omitempty"`
+	TimeZone            null.String `boil:"time_zone" json:"time_zone,omitempty" toml:"time_zone" yaml:"time_zone,omitempty"`
+	Units               null.String `boil:"units" json:"units,omitempty" toml:"units" yaml:"units,omitempty"`
+	ReferralCampaignID  null.String `boil:"referral_campaign_id" json:"referral_campaign_id,omitempty" toml:"referral_campaign_id" yaml:"referral_campaign_id,omitempty"`
+	PurgedReferees      int         `boil:"purged_referees" json:"purged_referees" toml:"purged_referees" yaml:"purged_referees"`
+	FirstWalletLinkedAt null.Time   `boil:"first_wallet_linked_at" json:"first_wallet_linked_at,omitempty" toml:"first_wallet_linked_at" yaml:"first_wallet_linked_at,omitempty"`
 
 	R *accountR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L accountL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var AccountColumns = struct {
-	ID            string
-	CountryCode   string
-	ReferralCode  string
-	ReferredBy    string
-	ReferredAt    string
-	AcceptedTosAt string
-	CreatedAt     string
-	UpdatedAt     string
+	ID                  string
+	CountryCode         string
+	ReferralCode        string
+	ReferredBy          string
+	ReferredAt          string
+	AcceptedTosAt       string
+	CreatedAt           string
+	UpdatedAt           string
+	DeletedAt           string
+	PurgeAt             string
+	DisplayName         string
+	Language            string
+	TimeZone            string
+	Units               string
+	ReferralCampaignID  string
+	PurgedReferees      string
+	FirstWalletLinkedAt string
 }{
-	ID:            "id",
-	CountryCode:   "country_code",
-	ReferralCode:  "referral_code",
-	ReferredBy:    "referred_by",
-	ReferredAt:    "referred_at",
-	AcceptedTosAt: "accepted_tos_at",
-	CreatedAt:     "created_at",
-	UpdatedAt:     "updated_at",
+	ID:                  "id",
+	CountryCode:         "country_code",
+	ReferralCode:        "referral_code",
+	ReferredBy:          "referred_by",
+	ReferredAt:          "referred_at",
+	AcceptedTosAt:       "accepted_tos_at",
+	CreatedAt:           "created_at",
+	UpdatedAt:           "updated_at",
+	DeletedAt:           "deleted_at",
+	PurgeAt:             "purge_at",
+	DisplayName:         "display_name",
+	Language:            "language",
+	TimeZone:            "time_zone",
+	Units:               "units",
+	ReferralCampaignID:  "referral_campaign_id",
+	PurgedReferees:      "purged_referees",
+	FirstWalletLinkedAt: "first_wallet_linked_at",
 }
 
 var AccountTableColumns = struct {
-	ID            string
-	CountryCode   string
-	ReferralCode  string
-	ReferredBy    string
-	ReferredAt    string
-	AcceptedTosAt string
-	CreatedAt     string
-	UpdatedAt     string
+	ID                  string
+	CountryCode         string
+	ReferralCode        string
+	ReferredBy          string
+	ReferredAt          string
+	AcceptedTosAt       string
+	CreatedAt           string
+	UpdatedAt           string
+	DeletedAt           string
+	PurgeAt             string
+	DisplayName         string
+	Language            string
+	TimeZone            string
+	Units               string
+	ReferralCampaignID  string
+	PurgedReferees      string
+	FirstWalletLinkedAt string
 }{
-	ID:            "accounts.id",
-	CountryCode:   "accounts.country_code",
-	ReferralCode:  "accounts.referral_code",
-	ReferredBy:    "accounts.referred_by",
-	ReferredAt:    "accounts.referred_at",
-	AcceptedTosAt: "accounts.accepted_tos_at",
-	CreatedAt:     "accounts.created_at",
-	UpdatedAt:     "accounts.updated_at",
+	ID:                  "accounts.id",
+	CountryCode:         "accounts.country_code",
+	ReferralCode:        "accounts.referral_code",
+	ReferredBy:          "accounts.referred_by",
+	ReferredAt:          "accounts.referred_at",
+	AcceptedTosAt:       "accounts.accepted_tos_at",
+	CreatedAt:           "accounts.created_at",
+	UpdatedAt:           "accounts.updated_at",
+	DeletedAt:           "accounts.deleted_at",
+	PurgeAt:             "accounts.purge_at",
+	DisplayName:         "accounts.display_name",
+	Language:            "accounts.language",
+	TimeZone:            "accounts.time_zone",
+	Units:               "accounts.units",
+	ReferralCampaignID:  "accounts.referral_campaign_id",
+	PurgedReferees:      "accounts.purged_referees",
+	FirstWalletLinkedAt: "accounts.first_wallet_linked_at",
 }
 
 // Generated where
 
 type whereHelperstring struct{ field string }
 
-func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
-func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
-func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
-func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
+func (w whereHelperstring) EQ(x string) qm.QueryMod      { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod      { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod      { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperstring) LIKE(x string) qm.QueryMod    { return qm.Where(w.field+" LIKE ?", x) }
+func (w whereHelperstring) NLIKE(x string) qm.QueryMod   { return qm.Where(w.field+" NOT LIKE ?", x) }
+func (w whereHelperstring) ILIKE(x string) qm.QueryMod   { return qm.Where(w.field+" ILIKE ?", x) }
+func (w whereHelperstring) NILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT ILIKE ?", x) }
+func (w whereHelperstring) SIMILAR(x string) qm.QueryMod { return qm.Where(w.field+" SIMILAR TO ?", x) }
+func (w whereHelperstring) NSIMILAR(x string) qm.QueryMod {
+	return qm.Where(w.field+" NOT SIMILAR TO ?", x)
+}
 func (w whereHelperstring) IN(slice []string) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
@@ -138,6 +187,12 @@ func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
 func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
 	return qm.Where(w.field+" NOT ILIKE ?", x)
 }
+func (w whereHelpernull_String) SIMILAR(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" SIMILAR TO ?", x)
+}
+func (w whereHelpernull_String) NSIMILAR(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" NOT SIMILAR TO ?", x)
+}
 func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
@@ -201,45 +256,107 @@ func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+type whereHelperint struct{ field string }
+
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
 var AccountWhere = struct {
-	ID            whereHelperstring
-	CountryCode   whereHelpernull_String
-	ReferralCode  whereHelperstring
-	ReferredBy    whereHelpernull_String
-	ReferredAt    whereHelpernull_Time
-	AcceptedTosAt whereHelpernull_Time
-	CreatedAt     whereHelpertime_Time
-	UpdatedAt     whereHelpertime_Time
+	ID                  whereHelperstring
+	CountryCode         whereHelpernull_String
+	ReferralCode        whereHelperstring
+	ReferredBy          whereHelpernull_String
+	ReferredAt          whereHelpernull_Time
+	AcceptedTosAt       whereHelpernull_Time
+	CreatedAt           whereHelpertime_Time
+	UpdatedAt           whereHelpertime_Time
+	DeletedAt           whereHelpernull_Time
+	PurgeAt             whereHelpernull_Time
+	DisplayName         whereHelpernull_String
+	Language            whereHelpernull_String
+	TimeZone            whereHelpernull_String
+	Units               whereHelpernull_String
+	ReferralCampaignID  whereHelpernull_String
+	PurgedReferees      whereHelperint
+	FirstWalletLinkedAt whereHelpernull_Time
 }{
-	ID:            whereHelperstring{field: "\"accounts_api\".\"accounts\".\"id\""},
-	CountryCode:   whereHelpernull_String{field: "\"accounts_api\".\"accounts\".\"country_code\""},
-	ReferralCode:  whereHelperstring{field: "\"accounts_api\".\"accounts\".\"referral_code\""},
-	ReferredBy:    whereHelpernull_String{field: "\"accounts_api\".\"accounts\".\"referred_by\""},
-	ReferredAt:    whereHelpernull_Time{field: "\"accounts_api\".\"accounts\".\"referred_at\""},
-	AcceptedTosAt: whereHelpernull_Time{field: "\"accounts_api\".\"accounts\".\"accepted_tos_at\""},
-	CreatedAt:     whereHelpertime_Time{field: "\"accounts_api\".\"accounts\".\"created_at\""},
-	UpdatedAt:     whereHelpertime_Time{field: "\"accounts_api\".\"accounts\".\"updated_at\""},
+	ID:                  whereHelperstring{field: "\"accounts_api\".\"accounts\".\"id\""},
+	CountryCode:         whereHelpernull_String{field: "\"accounts_api\".\"accounts\".\"country_code\""},
+	ReferralCode:        whereHelperstring{field: "\"accounts_api\".\"accounts\".\"referral_code\""},
+	ReferredBy:          whereHelpernull_String{field: "\"accounts_api\".\"accounts\".\"referred_by\""},
+	ReferredAt:          whereHelpernull_Time{field: "\"accounts_api\".\"accounts\".\"referred_at\""},
+	AcceptedTosAt:       whereHelpernull_Time{field: "\"accounts_api\".\"accounts\".\"accepted_tos_at\""},
+	CreatedAt:           whereHelpertime_Time{field: "\"accounts_api\".\"accounts\".\"created_at\""},
+	UpdatedAt:           whereHelpertime_Time{field: "\"accounts_api\".\"accounts\".\"updated_at\""},
+	DeletedAt:           whereHelpernull_Time{field: "\"accounts_api\".\"accounts\".\"deleted_at\""},
+	PurgeAt:             whereHelpernull_Time{field: "\"accounts_api\".\"accounts\".\"purge_at\""},
+	DisplayName:         whereHelpernull_String{field: "\"accounts_api\".\"accounts\".\"display_name\""},
+	Language:            whereHelpernull_String{field: "\"accounts_api\".\"accounts\".\"language\""},
+	TimeZone:            whereHelpernull_String{field: "\"accounts_api\".\"accounts\".\"time_zone\""},
+	Units:               whereHelpernull_String{field: "\"accounts_api\".\"accounts\".\"units\""},
+	ReferralCampaignID:  whereHelpernull_String{field: "\"accounts_api\".\"accounts\".\"referral_campaign_id\""},
+	PurgedReferees:      whereHelperint{field: "\"accounts_api\".\"accounts\".\"purged_referees\""},
+	FirstWalletLinkedAt: whereHelpernull_Time{field: "\"accounts_api\".\"accounts\".\"first_wallet_linked_at\""},
 }
 
 // AccountRels is where relationship names are stored.
 var AccountRels = struct {
-	ReferredByAccount  string
-	Email              string
-	Wallet             string
-	ReferredByAccounts string
+	ReferredByAccount              string
+	ReferralCampaign               string
+	Email                          string
+	PendingEmailChange             string
+	ReferredByAccounts             string
+	CommunicationPreferenceChanges string
+	CommunicationPreferences       string
+	LegalAcceptances               string
+	RetiredReferralCodes           string
+	SiweNonces                     string
+	Wallets                        string
 }{
-	ReferredByAccount:  "ReferredByAccount",
-	Email:              "Email",
-	Wallet:             "Wallet",
-	ReferredByAccounts: "ReferredByAccounts",
+	ReferredByAccount:              "ReferredByAccount",
+	ReferralCampaign:               "ReferralCampaign",
+	Email:                          "Email",
+	PendingEmailChange:             "PendingEmailChange",
+	ReferredByAccounts:             "ReferredByAccounts",
+	CommunicationPreferenceChanges: "CommunicationPreferenceChanges",
+	CommunicationPreferences:       "CommunicationPreferences",
+	LegalAcceptances:               "LegalAcceptances",
+	RetiredReferralCodes:           "RetiredReferralCodes",
+	SiweNonces:                     "SiweNonces",
+	Wallets:                        "Wallets",
 }
 
 // accountR is where relationships are stored.
 type accountR struct {
-	ReferredByAccount  *Account     `boil:"ReferredByAccount" json:"ReferredByAccount" toml:"ReferredByAccount" yaml:"ReferredByAccount"`
-	Email              *Email       `boil:"Email" json:"Email" toml:"Email" yaml:"Email"`
-	Wallet             *Wallet      `boil:"Wallet" json:"Wallet" toml:"Wallet" yaml:"Wallet"`
-	ReferredByAccounts AccountSlice `boil:"ReferredByAccounts" json:"ReferredByAccounts" toml:"ReferredByAccounts" yaml:"ReferredByAccounts"`
+	ReferredByAccount              *Account                           `boil:"ReferredByAccount" json:"ReferredByAccount" toml:"ReferredByAccount" yaml:"ReferredByAccount"`
+	ReferralCampaign               *ReferralCampaign                  `boil:"ReferralCampaign" json:"ReferralCampaign" toml:"ReferralCampaign" yaml:"ReferralCampaign"`
+	Email                          *Email                             `boil:"Email" json:"Email" toml:"Email" yaml:"Email"`
+	PendingEmailChange             *PendingEmailChange                `boil:"PendingEmailChange" json:"PendingEmailChange" toml:"PendingEmailChange" yaml:"PendingEmailChange"`
+	ReferredByAccounts             AccountSlice                       `boil:"ReferredByAccounts" json:"ReferredByAccounts" toml:"ReferredByAccounts" yaml:"ReferredByAccounts"`
+	CommunicationPreferenceChanges CommunicationPreferenceChangeSlice `boil:"CommunicationPreferenceChanges" json:"CommunicationPreferenceChanges" toml:"CommunicationPreferenceChanges" yaml:"CommunicationPreferenceChanges"`
+	CommunicationPreferences       CommunicationPreferenceSlice       `boil:"CommunicationPreferences" json:"CommunicationPreferences" toml:"CommunicationPreferences" yaml:"CommunicationPreferences"`
+	LegalAcceptances               LegalAcceptanceSlice               `boil:"LegalAcceptances" json:"LegalAcceptances" toml:"LegalAcceptances" yaml:"LegalAcceptances"`
+	RetiredReferralCodes           RetiredReferralCodeSlice           `boil:"RetiredReferralCodes" json:"RetiredReferralCodes" toml:"RetiredReferralCodes" yaml:"RetiredReferralCodes"`
+	SiweNonces                     SiweNonceSlice                     `boil:"SiweNonces" json:"SiweNonces" toml:"SiweNonces" yaml:"SiweNonces"`
+	Wallets                        WalletSlice                        `boil:"Wallets" json:"Wallets" toml:"Wallets" yaml:"Wallets"`
 }
 
 // NewStruct creates a new relationship struct
@@ -254,6 +371,13 @@ func (r *accountR) GetReferredByAccount() *Account {
 	return r.ReferredByAccount
 }
 
+func (r *accountR) GetReferralCampaign() *ReferralCampaign {
+	if r == nil {
+		return nil
+	}
+	return r.ReferralCampaign
+}
+
 func (r *accountR) GetEmail() *Email {
 	if r == nil {
 		return nil
@@ -261,11 +385,11 @@ func (r *accountR) GetEmail() *Email {
 	return r.Email
 }
 
-func (r *accountR) GetWallet() *Wallet {
+func (r *accountR) GetPendingEmailChange() *PendingEmailChange {
 	if r == nil {
 		return nil
 	}
-	return r.Wallet
+	return r.PendingEmailChange
 }
 
 func (r *accountR) GetReferredByAccounts() AccountSlice {
@@ -275,13 +399,55 @@ func (r *accountR) GetReferredByAccounts() AccountSlice {
 	return r.ReferredByAccounts
 }
 
+func (r *accountR) GetCommunicationPreferenceChanges() CommunicationPreferenceChangeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.CommunicationPreferenceChanges
+}
+
+func (r *accountR) GetCommunicationPreferences() CommunicationPreferenceSlice {
+	if r == nil {
+		return nil
+	}
+	return r.CommunicationPreferences
+}
+
+func (r *accountR) GetLegalAcceptances() LegalAcceptanceSlice {
+	if r == nil {
+		return nil
+	}
+	return r.LegalAcceptances
+}
+
+func (r *accountR) GetRetiredReferralCodes() RetiredReferralCodeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.RetiredReferralCodes
+}
+
+func (r *accountR) GetSiweNonces() SiweNonceSlice {
+	if r == nil {
+		return nil
+	}
+	return r.SiweNonces
+}
+
+func (r *accountR) GetWallets() WalletSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Wallets
+}
+
 // accountL is where Load methods for each relationship are stored.
 type accountL struct{}
 
 var (
-	accountAllColumns            = []string{"id", "country_code", "referral_code", "referred_by", "referred_at", "accepted_tos_at", "created_at", "updated_at"}
+	accountAllColumns            = []string{"id", "country_code", "referral_code", "referred_by", "referred_at", "accepted_tos_at", "created_at", "updated_at", "deleted_at", "purge_at", "display_name", "language", "time_zone", "units", "referral_campaign_id", "purged_referees", "first_wallet_linked_at"}
 	accountColumnsWithoutDefault = []string{"id", "referral_code"}
-	accountColumnsWithDefault    = []string{"country_code", "referred_by", "referred_at", "accepted_tos_at", "created_at", "updated_at"}
+	accountColumnsWithDefault    = []string{"country_code", "referred_by", "referred_at", "accepted_tos_at", "created_at", "updated_at", "deleted_at", "purge_at", "display_name", "language", "time_zone", "units", "referral_campaign_id", "purged_referees", "first_wallet_linked_at"}
 	accountPrimaryKeyColumns     = []string{"id"}
 	accountGeneratedColumns      = []string{}
 )
@@ -602,6 +768,17 @@ func (o *Account) ReferredByAccount(mods ...qm.QueryMod) accountQuery {
 	return Accounts(queryMods...)
 }
 
+// ReferralCampaign pointed to by the foreign key.
+func (o *Account) ReferralCampaign(mods ...qm.QueryMod) referralCampaignQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ReferralCampaignID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return ReferralCampaigns(queryMods...)
+}
+
 // Email pointed to by the foreign key.
 func (o *Account) Email(mods ...qm.QueryMod) emailQuery {
 	queryMods := []qm.QueryMod{
@@ -613,15 +790,15 @@ func (o *Account) Email(mods ...qm.QueryMod) emailQuery {
 	return Emails(queryMods...)
 }
 
-// Wallet pointed to by the foreign key.
-func (o *Account) Wallet(mods ...qm.QueryMod) walletQuery {
+// PendingEmailChange pointed to by the foreign key.
+func (o *Account) PendingEmailChange(mods ...qm.QueryMod) pendingEmailChangeQuery {
 	queryMods := []qm.QueryMod{
 		qm.Where("\"account_id\" = ?", o.ID),
 	}
 
 	queryMods = append(queryMods, mods...)
 
-	return Wallets(queryMods...)
+	return PendingEmailChanges(queryMods...)
 }
 
 // ReferredByAccounts retrieves all the account's Accounts with an executor via referred_by column.
@@ -638,6 +815,90 @@ func (o *Account) ReferredByAccounts(mods ...qm.QueryMod) accountQuery {
 	return Accounts(queryMods...)
 }
 
+// CommunicationPreferenceChanges retrieves all the communication_preference_change's CommunicationPreferenceChanges with an executor.
+func (o *Account) CommunicationPreferenceChanges(mods ...qm.QueryMod) communicationPreferenceChangeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"accounts_api\".\"communication_preference_changes\".\"account_id\"=?", o.ID),
+	)
+
+	return CommunicationPreferenceChanges(queryMods...)
+}
+
+// CommunicationPreferences retrieves all the communication_preference's CommunicationPreferences with an executor.
+func (o *Account) CommunicationPreferences(mods ...qm.QueryMod) communicationPreferenceQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"accounts_api\".\"communication_preferences\".\"account_id\"=?", o.ID),
+	)
+
+	return CommunicationPreferences(queryMods...)
+}
+
+// LegalAcceptances retrieves all the legal_acceptance's LegalAcceptances with an executor.
+func (o *Account) LegalAcceptances(mods ...qm.QueryMod) legalAcceptanceQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"accounts_api\".\"legal_acceptances\".\"account_id\"=?", o.ID),
+	)
+
+	return LegalAcceptances(queryMods...)
+}
+
+// RetiredReferralCodes retrieves all the retired_referral_code's RetiredReferralCodes with an executor.
+func (o *Account) RetiredReferralCodes(mods ...qm.QueryMod) retiredReferralCodeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"accounts_api\".\"retired_referral_codes\".\"account_id\"=?", o.ID),
+	)
+
+	return RetiredReferralCodes(queryMods...)
+}
+
+// SiweNonces retrieves all the siwe_nonce's SiweNonces with an executor.
+func (o *Account) SiweNonces(mods ...qm.QueryMod) siweNonceQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"accounts_api\".\"siwe_nonces\".\"account_id\"=?", o.ID),
+	)
+
+	return SiweNonces(queryMods...)
+}
+
+// Wallets retrieves all the wallet's Wallets with an executor.
+func (o *Account) Wallets(mods ...qm.QueryMod) walletQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"accounts_api\".\"wallets\".\"account_id\"=?", o.ID),
+	)
+
+	return Wallets(queryMods...)
+}
+
 // LoadReferredByAccount allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (accountL) LoadReferredByAccount(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAccount interface{}, mods queries.Applicator) error {
@@ -762,6 +1023,130 @@ func (accountL) LoadReferredByAccount(ctx context.Context, e boil.ContextExecuto
 	return nil
 }
 
+// LoadReferralCampaign allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (accountL) LoadReferralCampaign(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAccount interface{}, mods queries.Applicator) error {
+	var slice []*Account
+	var object *Account
+
+	if singular {
+		var ok bool
+		object, ok = maybeAccount.(*Account)
+		if !ok {
+			object = new(Account)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAccount))
+			}
+		}
+	} else {
+		s, ok := maybeAccount.(*[]*Account)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAccount))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &accountR{}
+		}
+		if !queries.IsNil(object.ReferralCampaignID) {
+			args[object.ReferralCampaignID] = struct{}{}
+		}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &accountR{}
+			}
+
+			if !queries.IsNil(obj.ReferralCampaignID) {
+				args[obj.ReferralCampaignID] = struct{}{}
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.referral_campaigns`),
+		qm.WhereIn(`accounts_api.referral_campaigns.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load ReferralCampaign")
+	}
+
+	var resultSlice []*ReferralCampaign
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice ReferralCampaign")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for referral_campaigns")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for referral_campaigns")
+	}
+
+	if len(referralCampaignAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.ReferralCampaign = foreign
+		if foreign.R == nil {
+			foreign.R = &referralCampaignR{}
+		}
+		foreign.R.Accounts = append(foreign.R.Accounts, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.ReferralCampaignID, foreign.ID) {
+				local.R.ReferralCampaign = foreign
+				if foreign.R == nil {
+					foreign.R = &referralCampaignR{}
+				}
+				foreign.R.Accounts = append(foreign.R.Accounts, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadEmail allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
 func (accountL) LoadEmail(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAccount interface{}, mods queries.Applicator) error {
@@ -879,9 +1264,9 @@ func (accountL) LoadEmail(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
-// LoadWallet allows an eager lookup of values, cached into the
+// LoadPendingEmailChange allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
-func (accountL) LoadWallet(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAccount interface{}, mods queries.Applicator) error {
+func (accountL) LoadPendingEmailChange(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAccount interface{}, mods queries.Applicator) error {
 	var slice []*Account
 	var object *Account
 
@@ -935,8 +1320,8 @@ func (accountL) LoadWallet(ctx context.Context, e boil.ContextExecutor, singular
 	}
 
 	query := NewQuery(
-		qm.From(`accounts_api.wallets`),
-		qm.WhereIn(`accounts_api.wallets.account_id in ?`, argsSlice...),
+		qm.From(`accounts_api.pending_email_changes`),
+		qm.WhereIn(`accounts_api.pending_email_changes.account_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -944,22 +1329,22 @@ func (accountL) LoadWallet(ctx context.Context, e boil.ContextExecutor, singular
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load Wallet")
+		return errors.Wrap(err, "failed to eager load PendingEmailChange")
 	}
 
-	var resultSlice []*Wallet
+	var resultSlice []*PendingEmailChange
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice Wallet")
+		return errors.Wrap(err, "failed to bind eager loaded slice PendingEmailChange")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results of eager load for wallets")
+		return errors.Wrap(err, "failed to close results of eager load for pending_email_changes")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for wallets")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for pending_email_changes")
 	}
 
-	if len(walletAfterSelectHooks) != 0 {
+	if len(pendingEmailChangeAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -973,9 +1358,9 @@ func (accountL) LoadWallet(ctx context.Context, e boil.ContextExecutor, singular
 
 	if singular {
 		foreign := resultSlice[0]
-		object.R.Wallet = foreign
+		object.R.PendingEmailChange = foreign
 		if foreign.R == nil {
-			foreign.R = &walletR{}
+			foreign.R = &pendingEmailChangeR{}
 		}
 		foreign.R.Account = object
 	}
@@ -983,9 +1368,9 @@ func (accountL) LoadWallet(ctx context.Context, e boil.ContextExecutor, singular
 	for _, local := range slice {
 		for _, foreign := range resultSlice {
 			if local.ID == foreign.AccountID {
-				local.R.Wallet = foreign
+				local.R.PendingEmailChange = foreign
 				if foreign.R == nil {
-					foreign.R = &walletR{}
+					foreign.R = &pendingEmailChangeR{}
 				}
 				foreign.R.Account = local
 				break
@@ -1109,205 +1494,1249 @@ func (accountL) LoadReferredByAccounts(ctx context.Context, e boil.ContextExecut
 	return nil
 }
 
-// SetReferredByAccount of the account to the related item.
-// Sets o.R.ReferredByAccount to related.
-// Adds o to related.R.ReferredByAccounts.
-func (o *Account) SetReferredByAccount(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Account) error {
-	var err error
-	if insert {
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
-		}
-	}
-
-	updateQuery := fmt.Sprintf(
-		"UPDATE \"accounts_api\".\"accounts\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, []string{"referred_by"}),
-		strmangle.WhereClause("\"", "\"", 2, accountPrimaryKeyColumns),
-	)
-	values := []interface{}{related.ID, o.ID}
+// LoadCommunicationPreferenceChanges allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (accountL) LoadCommunicationPreferenceChanges(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAccount interface{}, mods queries.Applicator) error {
+	var slice []*Account
+	var object *Account
 
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
-	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+	if singular {
+		var ok bool
+		object, ok = maybeAccount.(*Account)
+		if !ok {
+			object = new(Account)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAccount))
+			}
+		}
+	} else {
+		s, ok := maybeAccount.(*[]*Account)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAccount))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &accountR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &accountR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.communication_preference_changes`),
+		qm.WhereIn(`accounts_api.communication_preference_changes.account_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load communication_preference_changes")
+	}
+
+	var resultSlice []*CommunicationPreferenceChange
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice communication_preference_changes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on communication_preference_changes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for communication_preference_changes")
+	}
+
+	if len(communicationPreferenceChangeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.CommunicationPreferenceChanges = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &communicationPreferenceChangeR{}
+			}
+			foreign.R.Account = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.AccountID {
+				local.R.CommunicationPreferenceChanges = append(local.R.CommunicationPreferenceChanges, foreign)
+				if foreign.R == nil {
+					foreign.R = &communicationPreferenceChangeR{}
+				}
+				foreign.R.Account = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadCommunicationPreferences allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (accountL) LoadCommunicationPreferences(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAccount interface{}, mods queries.Applicator) error {
+	var slice []*Account
+	var object *Account
+
+	if singular {
+		var ok bool
+		object, ok = maybeAccount.(*Account)
+		if !ok {
+			object = new(Account)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAccount))
+			}
+		}
+	} else {
+		s, ok := maybeAccount.(*[]*Account)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAccount))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &accountR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &accountR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.communication_preferences`),
+		qm.WhereIn(`accounts_api.communication_preferences.account_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load communication_preferences")
+	}
+
+	var resultSlice []*CommunicationPreference
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice communication_preferences")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on communication_preferences")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for communication_preferences")
+	}
+
+	if len(communicationPreferenceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.CommunicationPreferences = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &communicationPreferenceR{}
+			}
+			foreign.R.Account = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.AccountID {
+				local.R.CommunicationPreferences = append(local.R.CommunicationPreferences, foreign)
+				if foreign.R == nil {
+					foreign.R = &communicationPreferenceR{}
+				}
+				foreign.R.Account = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadLegalAcceptances allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (accountL) LoadLegalAcceptances(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAccount interface{}, mods queries.Applicator) error {
+	var slice []*Account
+	var object *Account
+
+	if singular {
+		var ok bool
+		object, ok = maybeAccount.(*Account)
+		if !ok {
+			object = new(Account)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAccount))
+			}
+		}
+	} else {
+		s, ok := maybeAccount.(*[]*Account)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAccount))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &accountR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &accountR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.legal_acceptances`),
+		qm.WhereIn(`accounts_api.legal_acceptances.account_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load legal_acceptances")
+	}
+
+	var resultSlice []*LegalAcceptance
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice legal_acceptances")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on legal_acceptances")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for legal_acceptances")
+	}
+
+	if len(legalAcceptanceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.LegalAcceptances = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &legalAcceptanceR{}
+			}
+			foreign.R.Account = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.AccountID {
+				local.R.LegalAcceptances = append(local.R.LegalAcceptances, foreign)
+				if foreign.R == nil {
+					foreign.R = &legalAcceptanceR{}
+				}
+				foreign.R.Account = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRetiredReferralCodes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (accountL) LoadRetiredReferralCodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAccount interface{}, mods queries.Applicator) error {
+	var slice []*Account
+	var object *Account
+
+	if singular {
+		var ok bool
+		object, ok = maybeAccount.(*Account)
+		if !ok {
+			object = new(Account)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAccount))
+			}
+		}
+	} else {
+		s, ok := maybeAccount.(*[]*Account)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAccount))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &accountR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &accountR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.retired_referral_codes`),
+		qm.WhereIn(`accounts_api.retired_referral_codes.account_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load retired_referral_codes")
+	}
+
+	var resultSlice []*RetiredReferralCode
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice retired_referral_codes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on retired_referral_codes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for retired_referral_codes")
+	}
+
+	if len(retiredReferralCodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.RetiredReferralCodes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &retiredReferralCodeR{}
+			}
+			foreign.R.Account = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.AccountID) {
+				local.R.RetiredReferralCodes = append(local.R.RetiredReferralCodes, foreign)
+				if foreign.R == nil {
+					foreign.R = &retiredReferralCodeR{}
+				}
+				foreign.R.Account = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadSiweNonces allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (accountL) LoadSiweNonces(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAccount interface{}, mods queries.Applicator) error {
+	var slice []*Account
+	var object *Account
+
+	if singular {
+		var ok bool
+		object, ok = maybeAccount.(*Account)
+		if !ok {
+			object = new(Account)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAccount))
+			}
+		}
+	} else {
+		s, ok := maybeAccount.(*[]*Account)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAccount))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &accountR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &accountR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.siwe_nonces`),
+		qm.WhereIn(`accounts_api.siwe_nonces.account_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load siwe_nonces")
+	}
+
+	var resultSlice []*SiweNonce
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice siwe_nonces")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on siwe_nonces")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for siwe_nonces")
+	}
+
+	if len(siweNonceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.SiweNonces = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &siweNonceR{}
+			}
+			foreign.R.Account = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.AccountID {
+				local.R.SiweNonces = append(local.R.SiweNonces, foreign)
+				if foreign.R == nil {
+					foreign.R = &siweNonceR{}
+				}
+				foreign.R.Account = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadWallets allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (accountL) LoadWallets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAccount interface{}, mods queries.Applicator) error {
+	var slice []*Account
+	var object *Account
+
+	if singular {
+		var ok bool
+		object, ok = maybeAccount.(*Account)
+		if !ok {
+			object = new(Account)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAccount))
+			}
+		}
+	} else {
+		s, ok := maybeAccount.(*[]*Account)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAccount)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAccount))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &accountR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &accountR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.wallets`),
+		qm.WhereIn(`accounts_api.wallets.account_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load wallets")
+	}
+
+	var resultSlice []*Wallet
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice wallets")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on wallets")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for wallets")
+	}
+
+	if len(walletAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Wallets = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &walletR{}
+			}
+			foreign.R.Account = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.AccountID {
+				local.R.Wallets = append(local.R.Wallets, foreign)
+				if foreign.R == nil {
+					foreign.R = &walletR{}
+				}
+				foreign.R.Account = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetReferredByAccount of the account to the related item.
+// Sets o.R.ReferredByAccount to related.
+// Adds o to related.R.ReferredByAccounts.
+func (o *Account) SetReferredByAccount(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Account) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"accounts_api\".\"accounts\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"referred_by"}),
+		strmangle.WhereClause("\"", "\"", 2, accountPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.ReferredBy, related.ID)
+	if o.R == nil {
+		o.R = &accountR{
+			ReferredByAccount: related,
+		}
+	} else {
+		o.R.ReferredByAccount = related
+	}
+
+	if related.R == nil {
+		related.R = &accountR{
+			ReferredByAccounts: AccountSlice{o},
+		}
+	} else {
+		related.R.ReferredByAccounts = append(related.R.ReferredByAccounts, o)
+	}
+
+	return nil
+}
+
+// RemoveReferredByAccount relationship.
+// Sets o.R.ReferredByAccount to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Account) RemoveReferredByAccount(ctx context.Context, exec boil.ContextExecutor, related *Account) error {
+	var err error
+
+	queries.SetScanner(&o.ReferredBy, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("referred_by")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.ReferredByAccount = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.ReferredByAccounts {
+		if queries.Equal(o.ReferredBy, ri.ReferredBy) {
+			continue
+		}
+
+		ln := len(related.R.ReferredByAccounts)
+		if ln > 1 && i < ln-1 {
+			related.R.ReferredByAccounts[i] = related.R.ReferredByAccounts[ln-1]
+		}
+		related.R.ReferredByAccounts = related.R.ReferredByAccounts[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetReferralCampaign of the account to the related item.
+// Sets o.R.ReferralCampaign to related.
+// Adds o to related.R.Accounts.
+func (o *Account) SetReferralCampaign(ctx context.Context, exec boil.ContextExecutor, insert bool, related *ReferralCampaign) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"accounts_api\".\"accounts\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"referral_campaign_id"}),
+		strmangle.WhereClause("\"", "\"", 2, accountPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
 		return errors.Wrap(err, "failed to update local table")
 	}
 
-	queries.Assign(&o.ReferredBy, related.ID)
+	queries.Assign(&o.ReferralCampaignID, related.ID)
 	if o.R == nil {
 		o.R = &accountR{
-			ReferredByAccount: related,
+			ReferralCampaign: related,
 		}
 	} else {
-		o.R.ReferredByAccount = related
+		o.R.ReferralCampaign = related
 	}
 
 	if related.R == nil {
-		related.R = &accountR{
-			ReferredByAccounts: AccountSlice{o},
+		related.R = &referralCampaignR{
+			Accounts: AccountSlice{o},
 		}
 	} else {
-		related.R.ReferredByAccounts = append(related.R.ReferredByAccounts, o)
+		related.R.Accounts = append(related.R.Accounts, o)
 	}
 
 	return nil
 }
 
-// RemoveReferredByAccount relationship.
-// Sets o.R.ReferredByAccount to nil.
+// RemoveReferralCampaign relationship.
+// Sets o.R.ReferralCampaign to nil.
 // Removes o from all passed in related items' relationships struct.
-func (o *Account) RemoveReferredByAccount(ctx context.Context, exec boil.ContextExecutor, related *Account) error {
+func (o *Account) RemoveReferralCampaign(ctx context.Context, exec boil.ContextExecutor, related *ReferralCampaign) error {
+	var err error
+
+	queries.SetScanner(&o.ReferralCampaignID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("referral_campaign_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.ReferralCampaign = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.Accounts {
+		if queries.Equal(o.ReferralCampaignID, ri.ReferralCampaignID) {
+			continue
+		}
+
+		ln := len(related.R.Accounts)
+		if ln > 1 && i < ln-1 {
+			related.R.Accounts[i] = related.R.Accounts[ln-1]
+		}
+		related.R.Accounts = related.R.Accounts[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetEmail of the account to the related item.
+// Sets o.R.Email to related.
+// Adds o to related.R.Account.
+func (o *Account) SetEmail(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Email) error {
+	var err error
+
+	if insert {
+		related.AccountID = o.ID
+
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"accounts_api\".\"emails\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+			strmangle.WhereClause("\"", "\"", 2, emailPrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.Address}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, updateQuery)
+			fmt.Fprintln(writer, values)
+		}
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		related.AccountID = o.ID
+	}
+
+	if o.R == nil {
+		o.R = &accountR{
+			Email: related,
+		}
+	} else {
+		o.R.Email = related
+	}
+
+	if related.R == nil {
+		related.R = &emailR{
+			Account: o,
+		}
+	} else {
+		related.R.Account = o
+	}
+	return nil
+}
+
+// SetPendingEmailChange of the account to the related item.
+// Sets o.R.PendingEmailChange to related.
+// Adds o to related.R.Account.
+func (o *Account) SetPendingEmailChange(ctx context.Context, exec boil.ContextExecutor, insert bool, related *PendingEmailChange) error {
+	var err error
+
+	if insert {
+		related.AccountID = o.ID
+
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"accounts_api\".\"pending_email_changes\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+			strmangle.WhereClause("\"", "\"", 2, pendingEmailChangePrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.AccountID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, updateQuery)
+			fmt.Fprintln(writer, values)
+		}
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		related.AccountID = o.ID
+	}
+
+	if o.R == nil {
+		o.R = &accountR{
+			PendingEmailChange: related,
+		}
+	} else {
+		o.R.PendingEmailChange = related
+	}
+
+	if related.R == nil {
+		related.R = &pendingEmailChangeR{
+			Account: o,
+		}
+	} else {
+		related.R.Account = o
+	}
+	return nil
+}
+
+// AddReferredByAccounts adds the given related objects to the existing relationships
+// of the account, optionally inserting them as new records.
+// Appends related to o.R.ReferredByAccounts.
+// Sets related.R.ReferredByAccount appropriately.
+func (o *Account) AddReferredByAccounts(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Account) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.ReferredBy, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"accounts_api\".\"accounts\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"referred_by"}),
+				strmangle.WhereClause("\"", "\"", 2, accountPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.ReferredBy, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &accountR{
+			ReferredByAccounts: related,
+		}
+	} else {
+		o.R.ReferredByAccounts = append(o.R.ReferredByAccounts, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &accountR{
+				ReferredByAccount: o,
+			}
+		} else {
+			rel.R.ReferredByAccount = o
+		}
+	}
+	return nil
+}
+
+// SetReferredByAccounts removes all previously related items of the
+// account replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.ReferredByAccount's ReferredByAccounts accordingly.
+// Replaces o.R.ReferredByAccounts with related.
+// Sets related.R.ReferredByAccount's ReferredByAccounts accordingly.
+func (o *Account) SetReferredByAccounts(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Account) error {
+	query := "update \"accounts_api\".\"accounts\" set \"referred_by\" = null where \"referred_by\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.ReferredByAccounts {
+			queries.SetScanner(&rel.ReferredBy, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.ReferredByAccount = nil
+		}
+		o.R.ReferredByAccounts = nil
+	}
+
+	return o.AddReferredByAccounts(ctx, exec, insert, related...)
+}
+
+// RemoveReferredByAccounts relationships from objects passed in.
+// Removes related items from R.ReferredByAccounts (uses pointer comparison, removal does not keep order)
+// Sets related.R.ReferredByAccount.
+func (o *Account) RemoveReferredByAccounts(ctx context.Context, exec boil.ContextExecutor, related ...*Account) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.ReferredBy, nil)
+		if rel.R != nil {
+			rel.R.ReferredByAccount = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("referred_by")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.ReferredByAccounts {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.ReferredByAccounts)
+			if ln > 1 && i < ln-1 {
+				o.R.ReferredByAccounts[i] = o.R.ReferredByAccounts[ln-1]
+			}
+			o.R.ReferredByAccounts = o.R.ReferredByAccounts[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddCommunicationPreferenceChanges adds the given related objects to the existing relationships
+// of the account, optionally inserting them as new records.
+// Appends related to o.R.CommunicationPreferenceChanges.
+// Sets related.R.Account appropriately.
+func (o *Account) AddCommunicationPreferenceChanges(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*CommunicationPreferenceChange) error {
 	var err error
+	for _, rel := range related {
+		if insert {
+			rel.AccountID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"accounts_api\".\"communication_preference_changes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+				strmangle.WhereClause("\"", "\"", 2, communicationPreferenceChangePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
 
-	queries.SetScanner(&o.ReferredBy, nil)
-	if _, err = o.Update(ctx, exec, boil.Whitelist("referred_by")); err != nil {
-		return errors.Wrap(err, "failed to update local table")
-	}
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
 
-	if o.R != nil {
-		o.R.ReferredByAccount = nil
-	}
-	if related == nil || related.R == nil {
-		return nil
+			rel.AccountID = o.ID
+		}
 	}
 
-	for i, ri := range related.R.ReferredByAccounts {
-		if queries.Equal(o.ReferredBy, ri.ReferredBy) {
-			continue
+	if o.R == nil {
+		o.R = &accountR{
+			CommunicationPreferenceChanges: related,
 		}
+	} else {
+		o.R.CommunicationPreferenceChanges = append(o.R.CommunicationPreferenceChanges, related...)
+	}
 
-		ln := len(related.R.ReferredByAccounts)
-		if ln > 1 && i < ln-1 {
-			related.R.ReferredByAccounts[i] = related.R.ReferredByAccounts[ln-1]
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &communicationPreferenceChangeR{
+				Account: o,
+			}
+		} else {
+			rel.R.Account = o
 		}
-		related.R.ReferredByAccounts = related.R.ReferredByAccounts[:ln-1]
-		break
 	}
 	return nil
 }
 
-// SetEmail of the account to the related item.
-// Sets o.R.Email to related.
-// Adds o to related.R.Account.
-func (o *Account) SetEmail(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Email) error {
+// AddCommunicationPreferences adds the given related objects to the existing relationships
+// of the account, optionally inserting them as new records.
+// Appends related to o.R.CommunicationPreferences.
+// Sets related.R.Account appropriately.
+func (o *Account) AddCommunicationPreferences(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*CommunicationPreference) error {
 	var err error
+	for _, rel := range related {
+		if insert {
+			rel.AccountID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"accounts_api\".\"communication_preferences\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+				strmangle.WhereClause("\"", "\"", 2, communicationPreferencePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.AccountID, rel.Channel}
 
-	if insert {
-		related.AccountID = o.ID
-
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
-		}
-	} else {
-		updateQuery := fmt.Sprintf(
-			"UPDATE \"accounts_api\".\"emails\" SET %s WHERE %s",
-			strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
-			strmangle.WhereClause("\"", "\"", 2, emailPrimaryKeyColumns),
-		)
-		values := []interface{}{o.ID, related.Address}
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
 
-		if boil.IsDebug(ctx) {
-			writer := boil.DebugWriterFrom(ctx)
-			fmt.Fprintln(writer, updateQuery)
-			fmt.Fprintln(writer, values)
-		}
-		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-			return errors.Wrap(err, "failed to update foreign table")
+			rel.AccountID = o.ID
 		}
-
-		related.AccountID = o.ID
 	}
 
 	if o.R == nil {
 		o.R = &accountR{
-			Email: related,
+			CommunicationPreferences: related,
 		}
 	} else {
-		o.R.Email = related
+		o.R.CommunicationPreferences = append(o.R.CommunicationPreferences, related...)
 	}
 
-	if related.R == nil {
-		related.R = &emailR{
-			Account: o,
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &communicationPreferenceR{
+				Account: o,
+			}
+		} else {
+			rel.R.Account = o
 		}
-	} else {
-		related.R.Account = o
 	}
 	return nil
 }
 
-// SetWallet of the account to the related item.
-// Sets o.R.Wallet to related.
-// Adds o to related.R.Account.
-func (o *Account) SetWallet(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Wallet) error {
+// AddLegalAcceptances adds the given related objects to the existing relationships
+// of the account, optionally inserting them as new records.
+// Appends related to o.R.LegalAcceptances.
+// Sets related.R.Account appropriately.
+func (o *Account) AddLegalAcceptances(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*LegalAcceptance) error {
 	var err error
+	for _, rel := range related {
+		if insert {
+			rel.AccountID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"accounts_api\".\"legal_acceptances\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+				strmangle.WhereClause("\"", "\"", 2, legalAcceptancePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.AccountID, rel.Kind, rel.Version}
 
-	if insert {
-		related.AccountID = o.ID
-
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
-		}
-	} else {
-		updateQuery := fmt.Sprintf(
-			"UPDATE \"accounts_api\".\"wallets\" SET %s WHERE %s",
-			strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
-			strmangle.WhereClause("\"", "\"", 2, walletPrimaryKeyColumns),
-		)
-		values := []interface{}{o.ID, related.Address}
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
 
-		if boil.IsDebug(ctx) {
-			writer := boil.DebugWriterFrom(ctx)
-			fmt.Fprintln(writer, updateQuery)
-			fmt.Fprintln(writer, values)
-		}
-		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-			return errors.Wrap(err, "failed to update foreign table")
+			rel.AccountID = o.ID
 		}
-
-		related.AccountID = o.ID
 	}
 
 	if o.R == nil {
 		o.R = &accountR{
-			Wallet: related,
+			LegalAcceptances: related,
 		}
 	} else {
-		o.R.Wallet = related
+		o.R.LegalAcceptances = append(o.R.LegalAcceptances, related...)
 	}
 
-	if related.R == nil {
-		related.R = &walletR{
-			Account: o,
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &legalAcceptanceR{
+				Account: o,
+			}
+		} else {
+			rel.R.Account = o
 		}
-	} else {
-		related.R.Account = o
 	}
 	return nil
 }
 
-// AddReferredByAccounts adds the given related objects to the existing relationships
+// AddRetiredReferralCodes adds the given related objects to the existing relationships
 // of the account, optionally inserting them as new records.
-// Appends related to o.R.ReferredByAccounts.
-// Sets related.R.ReferredByAccount appropriately.
-func (o *Account) AddReferredByAccounts(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Account) error {
+// Appends related to o.R.RetiredReferralCodes.
+// Sets related.R.Account appropriately.
+func (o *Account) AddRetiredReferralCodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*RetiredReferralCode) error {
 	var err error
 	for _, rel := range related {
 		if insert {
-			queries.Assign(&rel.ReferredBy, o.ID)
+			queries.Assign(&rel.AccountID, o.ID)
 			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
 				return errors.Wrap(err, "failed to insert into foreign table")
 			}
 		} else {
 			updateQuery := fmt.Sprintf(
-				"UPDATE \"accounts_api\".\"accounts\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"referred_by"}),
-				strmangle.WhereClause("\"", "\"", 2, accountPrimaryKeyColumns),
+				"UPDATE \"accounts_api\".\"retired_referral_codes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+				strmangle.WhereClause("\"", "\"", 2, retiredReferralCodePrimaryKeyColumns),
 			)
-			values := []interface{}{o.ID, rel.ID}
+			values := []interface{}{o.ID, rel.Code}
 
 			if boil.IsDebug(ctx) {
 				writer := boil.DebugWriterFrom(ctx)
@@ -1318,38 +2747,38 @@ func (o *Account) AddReferredByAccounts(ctx context.Context, exec boil.ContextEx
 				return errors.Wrap(err, "failed to update foreign table")
 			}
 
-			queries.Assign(&rel.ReferredBy, o.ID)
+			queries.Assign(&rel.AccountID, o.ID)
 		}
 	}
 
 	if o.R == nil {
 		o.R = &accountR{
-			ReferredByAccounts: related,
+			RetiredReferralCodes: related,
 		}
 	} else {
-		o.R.ReferredByAccounts = append(o.R.ReferredByAccounts, related...)
+		o.R.RetiredReferralCodes = append(o.R.RetiredReferralCodes, related...)
 	}
 
 	for _, rel := range related {
 		if rel.R == nil {
-			rel.R = &accountR{
-				ReferredByAccount: o,
+			rel.R = &retiredReferralCodeR{
+				Account: o,
 			}
 		} else {
-			rel.R.ReferredByAccount = o
+			rel.R.Account = o
 		}
 	}
 	return nil
 }
 
-// SetReferredByAccounts removes all previously related items of the
+// SetRetiredReferralCodes removes all previously related items of the
 // account replacing them completely with the passed
 // in related items, optionally inserting them as new records.
-// Sets o.R.ReferredByAccount's ReferredByAccounts accordingly.
-// Replaces o.R.ReferredByAccounts with related.
-// Sets related.R.ReferredByAccount's ReferredByAccounts accordingly.
-func (o *Account) SetReferredByAccounts(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Account) error {
-	query := "update \"accounts_api\".\"accounts\" set \"referred_by\" = null where \"referred_by\" = $1"
+// Sets o.R.Account's RetiredReferralCodes accordingly.
+// Replaces o.R.RetiredReferralCodes with related.
+// Sets related.R.Account's RetiredReferralCodes accordingly.
+func (o *Account) SetRetiredReferralCodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*RetiredReferralCode) error {
+	query := "update \"accounts_api\".\"retired_referral_codes\" set \"account_id\" = null where \"account_id\" = $1"
 	values := []interface{}{o.ID}
 	if boil.IsDebug(ctx) {
 		writer := boil.DebugWriterFrom(ctx)
@@ -1362,35 +2791,35 @@ func (o *Account) SetReferredByAccounts(ctx context.Context, exec boil.ContextEx
 	}
 
 	if o.R != nil {
-		for _, rel := range o.R.ReferredByAccounts {
-			queries.SetScanner(&rel.ReferredBy, nil)
+		for _, rel := range o.R.RetiredReferralCodes {
+			queries.SetScanner(&rel.AccountID, nil)
 			if rel.R == nil {
 				continue
 			}
 
-			rel.R.ReferredByAccount = nil
+			rel.R.Account = nil
 		}
-		o.R.ReferredByAccounts = nil
+		o.R.RetiredReferralCodes = nil
 	}
 
-	return o.AddReferredByAccounts(ctx, exec, insert, related...)
+	return o.AddRetiredReferralCodes(ctx, exec, insert, related...)
 }
 
-// RemoveReferredByAccounts relationships from objects passed in.
-// Removes related items from R.ReferredByAccounts (uses pointer comparison, removal does not keep order)
-// Sets related.R.ReferredByAccount.
-func (o *Account) RemoveReferredByAccounts(ctx context.Context, exec boil.ContextExecutor, related ...*Account) error {
+// RemoveRetiredReferralCodes relationships from objects passed in.
+// Removes related items from R.RetiredReferralCodes (uses pointer comparison, removal does not keep order)
+// Sets related.R.Account.
+func (o *Account) RemoveRetiredReferralCodes(ctx context.Context, exec boil.ContextExecutor, related ...*RetiredReferralCode) error {
 	if len(related) == 0 {
 		return nil
 	}
 
 	var err error
 	for _, rel := range related {
-		queries.SetScanner(&rel.ReferredBy, nil)
+		queries.SetScanner(&rel.AccountID, nil)
 		if rel.R != nil {
-			rel.R.ReferredByAccount = nil
+			rel.R.Account = nil
 		}
-		if _, err = rel.Update(ctx, exec, boil.Whitelist("referred_by")); err != nil {
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("account_id")); err != nil {
 			return err
 		}
 	}
@@ -1399,16 +2828,16 @@ func (o *Account) RemoveReferredByAccounts(ctx context.Context, exec boil.Contex
 	}
 
 	for _, rel := range related {
-		for i, ri := range o.R.ReferredByAccounts {
+		for i, ri := range o.R.RetiredReferralCodes {
 			if rel != ri {
 				continue
 			}
 
-			ln := len(o.R.ReferredByAccounts)
+			ln := len(o.R.RetiredReferralCodes)
 			if ln > 1 && i < ln-1 {
-				o.R.ReferredByAccounts[i] = o.R.ReferredByAccounts[ln-1]
+				o.R.RetiredReferralCodes[i] = o.R.RetiredReferralCodes[ln-1]
 			}
-			o.R.ReferredByAccounts = o.R.ReferredByAccounts[:ln-1]
+			o.R.RetiredReferralCodes = o.R.RetiredReferralCodes[:ln-1]
 			break
 		}
 	}
@@ -1416,6 +2845,112 @@ func (o *Account) RemoveReferredByAccounts(ctx context.Context, exec boil.Contex
 	return nil
 }
 
+// AddSiweNonces adds the given related objects to the existing relationships
+// of the account, optionally inserting them as new records.
+// Appends related to o.R.SiweNonces.
+// Sets related.R.Account appropriately.
+func (o *Account) AddSiweNonces(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*SiweNonce) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.AccountID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"accounts_api\".\"siwe_nonces\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+				strmangle.WhereClause("\"", "\"", 2, siweNoncePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.Nonce}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.AccountID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &accountR{
+			SiweNonces: related,
+		}
+	} else {
+		o.R.SiweNonces = append(o.R.SiweNonces, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &siweNonceR{
+				Account: o,
+			}
+		} else {
+			rel.R.Account = o
+		}
+	}
+	return nil
+}
+
+// AddWallets adds the given related objects to the existing relationships
+// of the account, optionally inserting them as new records.
+// Appends related to o.R.Wallets.
+// Sets related.R.Account appropriately.
+func (o *Account) AddWallets(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Wallet) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.AccountID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"accounts_api\".\"wallets\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+				strmangle.WhereClause("\"", "\"", 2, walletPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.Address}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.AccountID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &accountR{
+			Wallets: related,
+		}
+	} else {
+		o.R.Wallets = append(o.R.Wallets, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &walletR{
+				Account: o,
+			}
+		} else {
+			rel.R.Account = o
+		}
+	}
+	return nil
+}
+
 // Accounts retrieves all the records using an executor.
 func Accounts(mods ...qm.QueryMod) accountQuery {
 	mods = append(mods, qm.From("\"accounts_api\".\"accounts\""))
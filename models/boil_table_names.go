@@ -1,14 +1,32 @@
-// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
 
 package models
 
 var TableNames = struct {
-	Accounts string
-	Emails   string
-	Wallets  string
+	Accounts                       string
+	CommunicationPreferenceChanges string
+	CommunicationPreferences       string
+	Emails                         string
+	LegalAcceptances               string
+	LegalDocuments                 string
+	OutboxMessages                 string
+	PendingEmailChanges            string
+	ReferralCampaigns              string
+	RetiredReferralCodes           string
+	SiweNonces                     string
+	Wallets                        string
 }{
-	Accounts: "accounts",
-	Emails:   "emails",
-	Wallets:  "wallets",
+	Accounts:                       "accounts",
+	CommunicationPreferenceChanges: "communication_preference_changes",
+	CommunicationPreferences:       "communication_preferences",
+	Emails:                         "emails",
+	LegalAcceptances:               "legal_acceptances",
+	LegalDocuments:                 "legal_documents",
+	OutboxMessages:                 "outbox_messages",
+	PendingEmailChanges:            "pending_email_changes",
+	ReferralCampaigns:              "referral_campaigns",
+	RetiredReferralCodes:           "retired_referral_codes",
+	SiweNonces:                     "siwe_nonces",
+	Wallets:                        "wallets",
 }
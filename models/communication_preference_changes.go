@@ -0,0 +1,1177 @@
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// CommunicationPreferenceChange is an object representing the database table.
+type CommunicationPreferenceChange struct {
+	ID        int64     `boil:"id" json:"id" toml:"id" yaml:"id"`
+	AccountID string    `boil:"account_id" json:"account_id" toml:"account_id" yaml:"account_id"`
+	Channel   string    `boil:"channel" json:"channel" toml:"channel" yaml:"channel"`
+	Enabled   bool      `boil:"enabled" json:"enabled" toml:"enabled" yaml:"enabled"`
+	ChangedAt time.Time `boil:"changed_at" json:"changed_at" toml:"changed_at" yaml:"changed_at"`
+	Source    string    `boil:"source" json:"source" toml:"source" yaml:"source"`
+
+	R *communicationPreferenceChangeR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L communicationPreferenceChangeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var CommunicationPreferenceChangeColumns = struct {
+	ID        string
+	AccountID string
+	Channel   string
+	Enabled   string
+	ChangedAt string
+	Source    string
+}{
+	ID:        "id",
+	AccountID: "account_id",
+	Channel:   "channel",
+	Enabled:   "enabled",
+	ChangedAt: "changed_at",
+	Source:    "source",
+}
+
+var CommunicationPreferenceChangeTableColumns = struct {
+	ID        string
+	AccountID string
+	Channel   string
+	Enabled   string
+	ChangedAt string
+	Source    string
+}{
+	ID:        "communication_preference_changes.id",
+	AccountID: "communication_preference_changes.account_id",
+	Channel:   "communication_preference_changes.channel",
+	Enabled:   "communication_preference_changes.enabled",
+	ChangedAt: "communication_preference_changes.changed_at",
+	Source:    "communication_preference_changes.source",
+}
+
+// Generated where
+
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelperbool struct{ field string }
+
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+var CommunicationPreferenceChangeWhere = struct {
+	ID        whereHelperint64
+	AccountID whereHelperstring
+	Channel   whereHelperstring
+	Enabled   whereHelperbool
+	ChangedAt whereHelpertime_Time
+	Source    whereHelperstring
+}{
+	ID:        whereHelperint64{field: "\"accounts_api\".\"communication_preference_changes\".\"id\""},
+	AccountID: whereHelperstring{field: "\"accounts_api\".\"communication_preference_changes\".\"account_id\""},
+	Channel:   whereHelperstring{field: "\"accounts_api\".\"communication_preference_changes\".\"channel\""},
+	Enabled:   whereHelperbool{field: "\"accounts_api\".\"communication_preference_changes\".\"enabled\""},
+	ChangedAt: whereHelpertime_Time{field: "\"accounts_api\".\"communication_preference_changes\".\"changed_at\""},
+	Source:    whereHelperstring{field: "\"accounts_api\".\"communication_preference_changes\".\"source\""},
+}
+
+// CommunicationPreferenceChangeRels is where relationship names are stored.
+var CommunicationPreferenceChangeRels = struct {
+	Account string
+}{
+	Account: "Account",
+}
+
+// communicationPreferenceChangeR is where relationships are stored.
+type communicationPreferenceChangeR struct {
+	Account *Account `boil:"Account" json:"Account" toml:"Account" yaml:"Account"`
+}
+
+// NewStruct creates a new relationship struct
+func (*communicationPreferenceChangeR) NewStruct() *communicationPreferenceChangeR {
+	return &communicationPreferenceChangeR{}
+}
+
+func (r *communicationPreferenceChangeR) GetAccount() *Account {
+	if r == nil {
+		return nil
+	}
+	return r.Account
+}
+
+// communicationPreferenceChangeL is where Load methods for each relationship are stored.
+type communicationPreferenceChangeL struct{}
+
+var (
+	communicationPreferenceChangeAllColumns            = []string{"id", "account_id", "channel", "enabled", "changed_at", "source"}
+	communicationPreferenceChangeColumnsWithoutDefault = []string{"account_id", "channel", "enabled", "source"}
+	communicationPreferenceChangeColumnsWithDefault    = []string{"id", "changed_at"}
+	communicationPreferenceChangePrimaryKeyColumns     = []string{"id"}
+	communicationPreferenceChangeGeneratedColumns      = []string{}
+)
+
+type (
+	// CommunicationPreferenceChangeSlice is an alias for a slice of pointers to CommunicationPreferenceChange.
+	// This should almost always be used instead of []CommunicationPreferenceChange.
+	CommunicationPreferenceChangeSlice []*CommunicationPreferenceChange
+	// CommunicationPreferenceChangeHook is the signature for custom CommunicationPreferenceChange hook methods
+	CommunicationPreferenceChangeHook func(context.Context, boil.ContextExecutor, *CommunicationPreferenceChange) error
+
+	communicationPreferenceChangeQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	communicationPreferenceChangeType                 = reflect.TypeOf(&CommunicationPreferenceChange{})
+	communicationPreferenceChangeMapping              = queries.MakeStructMapping(communicationPreferenceChangeType)
+	communicationPreferenceChangePrimaryKeyMapping, _ = queries.BindMapping(communicationPreferenceChangeType, communicationPreferenceChangeMapping, communicationPreferenceChangePrimaryKeyColumns)
+	communicationPreferenceChangeInsertCacheMut       sync.RWMutex
+	communicationPreferenceChangeInsertCache          = make(map[string]insertCache)
+	communicationPreferenceChangeUpdateCacheMut       sync.RWMutex
+	communicationPreferenceChangeUpdateCache          = make(map[string]updateCache)
+	communicationPreferenceChangeUpsertCacheMut       sync.RWMutex
+	communicationPreferenceChangeUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var communicationPreferenceChangeAfterSelectMu sync.Mutex
+var communicationPreferenceChangeAfterSelectHooks []CommunicationPreferenceChangeHook
+
+var communicationPreferenceChangeBeforeInsertMu sync.Mutex
+var communicationPreferenceChangeBeforeInsertHooks []CommunicationPreferenceChangeHook
+var communicationPreferenceChangeAfterInsertMu sync.Mutex
+var communicationPreferenceChangeAfterInsertHooks []CommunicationPreferenceChangeHook
+
+var communicationPreferenceChangeBeforeUpdateMu sync.Mutex
+var communicationPreferenceChangeBeforeUpdateHooks []CommunicationPreferenceChangeHook
+var communicationPreferenceChangeAfterUpdateMu sync.Mutex
+var communicationPreferenceChangeAfterUpdateHooks []CommunicationPreferenceChangeHook
+
+var communicationPreferenceChangeBeforeDeleteMu sync.Mutex
+var communicationPreferenceChangeBeforeDeleteHooks []CommunicationPreferenceChangeHook
+var communicationPreferenceChangeAfterDeleteMu sync.Mutex
+var communicationPreferenceChangeAfterDeleteHooks []CommunicationPreferenceChangeHook
+
+var communicationPreferenceChangeBeforeUpsertMu sync.Mutex
+var communicationPreferenceChangeBeforeUpsertHooks []CommunicationPreferenceChangeHook
+var communicationPreferenceChangeAfterUpsertMu sync.Mutex
+var communicationPreferenceChangeAfterUpsertHooks []CommunicationPreferenceChangeHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *CommunicationPreferenceChange) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceChangeAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *CommunicationPreferenceChange) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceChangeBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *CommunicationPreferenceChange) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceChangeAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *CommunicationPreferenceChange) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceChangeBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *CommunicationPreferenceChange) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceChangeAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *CommunicationPreferenceChange) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceChangeBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *CommunicationPreferenceChange) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceChangeAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *CommunicationPreferenceChange) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceChangeBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *CommunicationPreferenceChange) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceChangeAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddCommunicationPreferenceChangeHook registers your hook function for all future operations.
+func AddCommunicationPreferenceChangeHook(hookPoint boil.HookPoint, communicationPreferenceChangeHook CommunicationPreferenceChangeHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		communicationPreferenceChangeAfterSelectMu.Lock()
+		communicationPreferenceChangeAfterSelectHooks = append(communicationPreferenceChangeAfterSelectHooks, communicationPreferenceChangeHook)
+		communicationPreferenceChangeAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		communicationPreferenceChangeBeforeInsertMu.Lock()
+		communicationPreferenceChangeBeforeInsertHooks = append(communicationPreferenceChangeBeforeInsertHooks, communicationPreferenceChangeHook)
+		communicationPreferenceChangeBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		communicationPreferenceChangeAfterInsertMu.Lock()
+		communicationPreferenceChangeAfterInsertHooks = append(communicationPreferenceChangeAfterInsertHooks, communicationPreferenceChangeHook)
+		communicationPreferenceChangeAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		communicationPreferenceChangeBeforeUpdateMu.Lock()
+		communicationPreferenceChangeBeforeUpdateHooks = append(communicationPreferenceChangeBeforeUpdateHooks, communicationPreferenceChangeHook)
+		communicationPreferenceChangeBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		communicationPreferenceChangeAfterUpdateMu.Lock()
+		communicationPreferenceChangeAfterUpdateHooks = append(communicationPreferenceChangeAfterUpdateHooks, communicationPreferenceChangeHook)
+		communicationPreferenceChangeAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		communicationPreferenceChangeBeforeDeleteMu.Lock()
+		communicationPreferenceChangeBeforeDeleteHooks = append(communicationPreferenceChangeBeforeDeleteHooks, communicationPreferenceChangeHook)
+		communicationPreferenceChangeBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		communicationPreferenceChangeAfterDeleteMu.Lock()
+		communicationPreferenceChangeAfterDeleteHooks = append(communicationPreferenceChangeAfterDeleteHooks, communicationPreferenceChangeHook)
+		communicationPreferenceChangeAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		communicationPreferenceChangeBeforeUpsertMu.Lock()
+		communicationPreferenceChangeBeforeUpsertHooks = append(communicationPreferenceChangeBeforeUpsertHooks, communicationPreferenceChangeHook)
+		communicationPreferenceChangeBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		communicationPreferenceChangeAfterUpsertMu.Lock()
+		communicationPreferenceChangeAfterUpsertHooks = append(communicationPreferenceChangeAfterUpsertHooks, communicationPreferenceChangeHook)
+		communicationPreferenceChangeAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single communicationPreferenceChange record from the query.
+func (q communicationPreferenceChangeQuery) One(ctx context.Context, exec boil.ContextExecutor) (*CommunicationPreferenceChange, error) {
+	o := &CommunicationPreferenceChange{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for communication_preference_changes")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all CommunicationPreferenceChange records from the query.
+func (q communicationPreferenceChangeQuery) All(ctx context.Context, exec boil.ContextExecutor) (CommunicationPreferenceChangeSlice, error) {
+	var o []*CommunicationPreferenceChange
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to CommunicationPreferenceChange slice")
+	}
+
+	if len(communicationPreferenceChangeAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all CommunicationPreferenceChange records in the query.
+func (q communicationPreferenceChangeQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count communication_preference_changes rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q communicationPreferenceChangeQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if communication_preference_changes exists")
+	}
+
+	return count > 0, nil
+}
+
+// Account pointed to by the foreign key.
+func (o *CommunicationPreferenceChange) Account(mods ...qm.QueryMod) accountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.AccountID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Accounts(queryMods...)
+}
+
+// LoadAccount allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (communicationPreferenceChangeL) LoadAccount(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCommunicationPreferenceChange interface{}, mods queries.Applicator) error {
+	var slice []*CommunicationPreferenceChange
+	var object *CommunicationPreferenceChange
+
+	if singular {
+		var ok bool
+		object, ok = maybeCommunicationPreferenceChange.(*CommunicationPreferenceChange)
+		if !ok {
+			object = new(CommunicationPreferenceChange)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCommunicationPreferenceChange)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCommunicationPreferenceChange))
+			}
+		}
+	} else {
+		s, ok := maybeCommunicationPreferenceChange.(*[]*CommunicationPreferenceChange)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCommunicationPreferenceChange)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCommunicationPreferenceChange))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &communicationPreferenceChangeR{}
+		}
+		args[object.AccountID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &communicationPreferenceChangeR{}
+			}
+
+			args[obj.AccountID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.accounts`),
+		qm.WhereIn(`accounts_api.accounts.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Account")
+	}
+
+	var resultSlice []*Account
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Account")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for accounts")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for accounts")
+	}
+
+	if len(accountAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Account = foreign
+		if foreign.R == nil {
+			foreign.R = &accountR{}
+		}
+		foreign.R.CommunicationPreferenceChanges = append(foreign.R.CommunicationPreferenceChanges, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.AccountID == foreign.ID {
+				local.R.Account = foreign
+				if foreign.R == nil {
+					foreign.R = &accountR{}
+				}
+				foreign.R.CommunicationPreferenceChanges = append(foreign.R.CommunicationPreferenceChanges, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetAccount of the communicationPreferenceChange to the related item.
+// Sets o.R.Account to related.
+// Adds o to related.R.CommunicationPreferenceChanges.
+func (o *CommunicationPreferenceChange) SetAccount(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Account) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"accounts_api\".\"communication_preference_changes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+		strmangle.WhereClause("\"", "\"", 2, communicationPreferenceChangePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.AccountID = related.ID
+	if o.R == nil {
+		o.R = &communicationPreferenceChangeR{
+			Account: related,
+		}
+	} else {
+		o.R.Account = related
+	}
+
+	if related.R == nil {
+		related.R = &accountR{
+			CommunicationPreferenceChanges: CommunicationPreferenceChangeSlice{o},
+		}
+	} else {
+		related.R.CommunicationPreferenceChanges = append(related.R.CommunicationPreferenceChanges, o)
+	}
+
+	return nil
+}
+
+// CommunicationPreferenceChanges retrieves all the records using an executor.
+func CommunicationPreferenceChanges(mods ...qm.QueryMod) communicationPreferenceChangeQuery {
+	mods = append(mods, qm.From("\"accounts_api\".\"communication_preference_changes\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"accounts_api\".\"communication_preference_changes\".*"})
+	}
+
+	return communicationPreferenceChangeQuery{q}
+}
+
+// FindCommunicationPreferenceChange retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindCommunicationPreferenceChange(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*CommunicationPreferenceChange, error) {
+	communicationPreferenceChangeObj := &CommunicationPreferenceChange{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"accounts_api\".\"communication_preference_changes\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, communicationPreferenceChangeObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from communication_preference_changes")
+	}
+
+	if err = communicationPreferenceChangeObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return communicationPreferenceChangeObj, err
+	}
+
+	return communicationPreferenceChangeObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *CommunicationPreferenceChange) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no communication_preference_changes provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(communicationPreferenceChangeColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	communicationPreferenceChangeInsertCacheMut.RLock()
+	cache, cached := communicationPreferenceChangeInsertCache[key]
+	communicationPreferenceChangeInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			communicationPreferenceChangeAllColumns,
+			communicationPreferenceChangeColumnsWithDefault,
+			communicationPreferenceChangeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(communicationPreferenceChangeType, communicationPreferenceChangeMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(communicationPreferenceChangeType, communicationPreferenceChangeMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"accounts_api\".\"communication_preference_changes\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"accounts_api\".\"communication_preference_changes\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into communication_preference_changes")
+	}
+
+	if !cached {
+		communicationPreferenceChangeInsertCacheMut.Lock()
+		communicationPreferenceChangeInsertCache[key] = cache
+		communicationPreferenceChangeInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the CommunicationPreferenceChange.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *CommunicationPreferenceChange) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	communicationPreferenceChangeUpdateCacheMut.RLock()
+	cache, cached := communicationPreferenceChangeUpdateCache[key]
+	communicationPreferenceChangeUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			communicationPreferenceChangeAllColumns,
+			communicationPreferenceChangePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update communication_preference_changes, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"accounts_api\".\"communication_preference_changes\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, communicationPreferenceChangePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(communicationPreferenceChangeType, communicationPreferenceChangeMapping, append(wl, communicationPreferenceChangePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update communication_preference_changes row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for communication_preference_changes")
+	}
+
+	if !cached {
+		communicationPreferenceChangeUpdateCacheMut.Lock()
+		communicationPreferenceChangeUpdateCache[key] = cache
+		communicationPreferenceChangeUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q communicationPreferenceChangeQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for communication_preference_changes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for communication_preference_changes")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o CommunicationPreferenceChangeSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), communicationPreferenceChangePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"accounts_api\".\"communication_preference_changes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, communicationPreferenceChangePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in communicationPreferenceChange slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all communicationPreferenceChange")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *CommunicationPreferenceChange) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no communication_preference_changes provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(communicationPreferenceChangeColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	communicationPreferenceChangeUpsertCacheMut.RLock()
+	cache, cached := communicationPreferenceChangeUpsertCache[key]
+	communicationPreferenceChangeUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			communicationPreferenceChangeAllColumns,
+			communicationPreferenceChangeColumnsWithDefault,
+			communicationPreferenceChangeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			communicationPreferenceChangeAllColumns,
+			communicationPreferenceChangePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert communication_preference_changes, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(communicationPreferenceChangeAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(communicationPreferenceChangePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert communication_preference_changes, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(communicationPreferenceChangePrimaryKeyColumns))
+			copy(conflict, communicationPreferenceChangePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"accounts_api\".\"communication_preference_changes\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(communicationPreferenceChangeType, communicationPreferenceChangeMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(communicationPreferenceChangeType, communicationPreferenceChangeMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert communication_preference_changes")
+	}
+
+	if !cached {
+		communicationPreferenceChangeUpsertCacheMut.Lock()
+		communicationPreferenceChangeUpsertCache[key] = cache
+		communicationPreferenceChangeUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single CommunicationPreferenceChange record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *CommunicationPreferenceChange) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no CommunicationPreferenceChange provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), communicationPreferenceChangePrimaryKeyMapping)
+	sql := "DELETE FROM \"accounts_api\".\"communication_preference_changes\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from communication_preference_changes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for communication_preference_changes")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q communicationPreferenceChangeQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no communicationPreferenceChangeQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from communication_preference_changes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for communication_preference_changes")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o CommunicationPreferenceChangeSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(communicationPreferenceChangeBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), communicationPreferenceChangePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"accounts_api\".\"communication_preference_changes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, communicationPreferenceChangePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from communicationPreferenceChange slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for communication_preference_changes")
+	}
+
+	if len(communicationPreferenceChangeAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *CommunicationPreferenceChange) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindCommunicationPreferenceChange(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *CommunicationPreferenceChangeSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := CommunicationPreferenceChangeSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), communicationPreferenceChangePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"accounts_api\".\"communication_preference_changes\".* FROM \"accounts_api\".\"communication_preference_changes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, communicationPreferenceChangePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in CommunicationPreferenceChangeSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// CommunicationPreferenceChangeExists checks if the CommunicationPreferenceChange row exists.
+func CommunicationPreferenceChangeExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"accounts_api\".\"communication_preference_changes\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if communication_preference_changes exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the CommunicationPreferenceChange row exists.
+func (o *CommunicationPreferenceChange) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return CommunicationPreferenceChangeExists(ctx, exec, o.ID)
+}
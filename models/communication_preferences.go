@@ -0,0 +1,1149 @@
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// CommunicationPreference is an object representing the database table.
+type CommunicationPreference struct {
+	AccountID string    `boil:"account_id" json:"account_id" toml:"account_id" yaml:"account_id"`
+	Channel   string    `boil:"channel" json:"channel" toml:"channel" yaml:"channel"`
+	Enabled   bool      `boil:"enabled" json:"enabled" toml:"enabled" yaml:"enabled"`
+	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *communicationPreferenceR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L communicationPreferenceL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var CommunicationPreferenceColumns = struct {
+	AccountID string
+	Channel   string
+	Enabled   string
+	UpdatedAt string
+}{
+	AccountID: "account_id",
+	Channel:   "channel",
+	Enabled:   "enabled",
+	UpdatedAt: "updated_at",
+}
+
+var CommunicationPreferenceTableColumns = struct {
+	AccountID string
+	Channel   string
+	Enabled   string
+	UpdatedAt string
+}{
+	AccountID: "communication_preferences.account_id",
+	Channel:   "communication_preferences.channel",
+	Enabled:   "communication_preferences.enabled",
+	UpdatedAt: "communication_preferences.updated_at",
+}
+
+// Generated where
+
+var CommunicationPreferenceWhere = struct {
+	AccountID whereHelperstring
+	Channel   whereHelperstring
+	Enabled   whereHelperbool
+	UpdatedAt whereHelpertime_Time
+}{
+	AccountID: whereHelperstring{field: "\"accounts_api\".\"communication_preferences\".\"account_id\""},
+	Channel:   whereHelperstring{field: "\"accounts_api\".\"communication_preferences\".\"channel\""},
+	Enabled:   whereHelperbool{field: "\"accounts_api\".\"communication_preferences\".\"enabled\""},
+	UpdatedAt: whereHelpertime_Time{field: "\"accounts_api\".\"communication_preferences\".\"updated_at\""},
+}
+
+// CommunicationPreferenceRels is where relationship names are stored.
+var CommunicationPreferenceRels = struct {
+	Account string
+}{
+	Account: "Account",
+}
+
+// communicationPreferenceR is where relationships are stored.
+type communicationPreferenceR struct {
+	Account *Account `boil:"Account" json:"Account" toml:"Account" yaml:"Account"`
+}
+
+// NewStruct creates a new relationship struct
+func (*communicationPreferenceR) NewStruct() *communicationPreferenceR {
+	return &communicationPreferenceR{}
+}
+
+func (r *communicationPreferenceR) GetAccount() *Account {
+	if r == nil {
+		return nil
+	}
+	return r.Account
+}
+
+// communicationPreferenceL is where Load methods for each relationship are stored.
+type communicationPreferenceL struct{}
+
+var (
+	communicationPreferenceAllColumns            = []string{"account_id", "channel", "enabled", "updated_at"}
+	communicationPreferenceColumnsWithoutDefault = []string{"account_id", "channel", "enabled"}
+	communicationPreferenceColumnsWithDefault    = []string{"updated_at"}
+	communicationPreferencePrimaryKeyColumns     = []string{"account_id", "channel"}
+	communicationPreferenceGeneratedColumns      = []string{}
+)
+
+type (
+	// CommunicationPreferenceSlice is an alias for a slice of pointers to CommunicationPreference.
+	// This should almost always be used instead of []CommunicationPreference.
+	CommunicationPreferenceSlice []*CommunicationPreference
+	// CommunicationPreferenceHook is the signature for custom CommunicationPreference hook methods
+	CommunicationPreferenceHook func(context.Context, boil.ContextExecutor, *CommunicationPreference) error
+
+	communicationPreferenceQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	communicationPreferenceType                 = reflect.TypeOf(&CommunicationPreference{})
+	communicationPreferenceMapping              = queries.MakeStructMapping(communicationPreferenceType)
+	communicationPreferencePrimaryKeyMapping, _ = queries.BindMapping(communicationPreferenceType, communicationPreferenceMapping, communicationPreferencePrimaryKeyColumns)
+	communicationPreferenceInsertCacheMut       sync.RWMutex
+	communicationPreferenceInsertCache          = make(map[string]insertCache)
+	communicationPreferenceUpdateCacheMut       sync.RWMutex
+	communicationPreferenceUpdateCache          = make(map[string]updateCache)
+	communicationPreferenceUpsertCacheMut       sync.RWMutex
+	communicationPreferenceUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var communicationPreferenceAfterSelectMu sync.Mutex
+var communicationPreferenceAfterSelectHooks []CommunicationPreferenceHook
+
+var communicationPreferenceBeforeInsertMu sync.Mutex
+var communicationPreferenceBeforeInsertHooks []CommunicationPreferenceHook
+var communicationPreferenceAfterInsertMu sync.Mutex
+var communicationPreferenceAfterInsertHooks []CommunicationPreferenceHook
+
+var communicationPreferenceBeforeUpdateMu sync.Mutex
+var communicationPreferenceBeforeUpdateHooks []CommunicationPreferenceHook
+var communicationPreferenceAfterUpdateMu sync.Mutex
+var communicationPreferenceAfterUpdateHooks []CommunicationPreferenceHook
+
+var communicationPreferenceBeforeDeleteMu sync.Mutex
+var communicationPreferenceBeforeDeleteHooks []CommunicationPreferenceHook
+var communicationPreferenceAfterDeleteMu sync.Mutex
+var communicationPreferenceAfterDeleteHooks []CommunicationPreferenceHook
+
+var communicationPreferenceBeforeUpsertMu sync.Mutex
+var communicationPreferenceBeforeUpsertHooks []CommunicationPreferenceHook
+var communicationPreferenceAfterUpsertMu sync.Mutex
+var communicationPreferenceAfterUpsertHooks []CommunicationPreferenceHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *CommunicationPreference) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *CommunicationPreference) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *CommunicationPreference) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *CommunicationPreference) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *CommunicationPreference) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *CommunicationPreference) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *CommunicationPreference) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *CommunicationPreference) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *CommunicationPreference) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range communicationPreferenceAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddCommunicationPreferenceHook registers your hook function for all future operations.
+func AddCommunicationPreferenceHook(hookPoint boil.HookPoint, communicationPreferenceHook CommunicationPreferenceHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		communicationPreferenceAfterSelectMu.Lock()
+		communicationPreferenceAfterSelectHooks = append(communicationPreferenceAfterSelectHooks, communicationPreferenceHook)
+		communicationPreferenceAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		communicationPreferenceBeforeInsertMu.Lock()
+		communicationPreferenceBeforeInsertHooks = append(communicationPreferenceBeforeInsertHooks, communicationPreferenceHook)
+		communicationPreferenceBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		communicationPreferenceAfterInsertMu.Lock()
+		communicationPreferenceAfterInsertHooks = append(communicationPreferenceAfterInsertHooks, communicationPreferenceHook)
+		communicationPreferenceAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		communicationPreferenceBeforeUpdateMu.Lock()
+		communicationPreferenceBeforeUpdateHooks = append(communicationPreferenceBeforeUpdateHooks, communicationPreferenceHook)
+		communicationPreferenceBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		communicationPreferenceAfterUpdateMu.Lock()
+		communicationPreferenceAfterUpdateHooks = append(communicationPreferenceAfterUpdateHooks, communicationPreferenceHook)
+		communicationPreferenceAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		communicationPreferenceBeforeDeleteMu.Lock()
+		communicationPreferenceBeforeDeleteHooks = append(communicationPreferenceBeforeDeleteHooks, communicationPreferenceHook)
+		communicationPreferenceBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		communicationPreferenceAfterDeleteMu.Lock()
+		communicationPreferenceAfterDeleteHooks = append(communicationPreferenceAfterDeleteHooks, communicationPreferenceHook)
+		communicationPreferenceAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		communicationPreferenceBeforeUpsertMu.Lock()
+		communicationPreferenceBeforeUpsertHooks = append(communicationPreferenceBeforeUpsertHooks, communicationPreferenceHook)
+		communicationPreferenceBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		communicationPreferenceAfterUpsertMu.Lock()
+		communicationPreferenceAfterUpsertHooks = append(communicationPreferenceAfterUpsertHooks, communicationPreferenceHook)
+		communicationPreferenceAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single communicationPreference record from the query.
+func (q communicationPreferenceQuery) One(ctx context.Context, exec boil.ContextExecutor) (*CommunicationPreference, error) {
+	o := &CommunicationPreference{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for communication_preferences")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all CommunicationPreference records from the query.
+func (q communicationPreferenceQuery) All(ctx context.Context, exec boil.ContextExecutor) (CommunicationPreferenceSlice, error) {
+	var o []*CommunicationPreference
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to CommunicationPreference slice")
+	}
+
+	if len(communicationPreferenceAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all CommunicationPreference records in the query.
+func (q communicationPreferenceQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count communication_preferences rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q communicationPreferenceQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if communication_preferences exists")
+	}
+
+	return count > 0, nil
+}
+
+// Account pointed to by the foreign key.
+func (o *CommunicationPreference) Account(mods ...qm.QueryMod) accountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.AccountID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Accounts(queryMods...)
+}
+
+// LoadAccount allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (communicationPreferenceL) LoadAccount(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCommunicationPreference interface{}, mods queries.Applicator) error {
+	var slice []*CommunicationPreference
+	var object *CommunicationPreference
+
+	if singular {
+		var ok bool
+		object, ok = maybeCommunicationPreference.(*CommunicationPreference)
+		if !ok {
+			object = new(CommunicationPreference)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCommunicationPreference)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCommunicationPreference))
+			}
+		}
+	} else {
+		s, ok := maybeCommunicationPreference.(*[]*CommunicationPreference)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCommunicationPreference)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCommunicationPreference))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &communicationPreferenceR{}
+		}
+		args[object.AccountID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &communicationPreferenceR{}
+			}
+
+			args[obj.AccountID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.accounts`),
+		qm.WhereIn(`accounts_api.accounts.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Account")
+	}
+
+	var resultSlice []*Account
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Account")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for accounts")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for accounts")
+	}
+
+	if len(accountAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Account = foreign
+		if foreign.R == nil {
+			foreign.R = &accountR{}
+		}
+		foreign.R.CommunicationPreferences = append(foreign.R.CommunicationPreferences, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.AccountID == foreign.ID {
+				local.R.Account = foreign
+				if foreign.R == nil {
+					foreign.R = &accountR{}
+				}
+				foreign.R.CommunicationPreferences = append(foreign.R.CommunicationPreferences, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetAccount of the communicationPreference to the related item.
+// Sets o.R.Account to related.
+// Adds o to related.R.CommunicationPreferences.
+func (o *CommunicationPreference) SetAccount(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Account) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"accounts_api\".\"communication_preferences\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+		strmangle.WhereClause("\"", "\"", 2, communicationPreferencePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.AccountID, o.Channel}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.AccountID = related.ID
+	if o.R == nil {
+		o.R = &communicationPreferenceR{
+			Account: related,
+		}
+	} else {
+		o.R.Account = related
+	}
+
+	if related.R == nil {
+		related.R = &accountR{
+			CommunicationPreferences: CommunicationPreferenceSlice{o},
+		}
+	} else {
+		related.R.CommunicationPreferences = append(related.R.CommunicationPreferences, o)
+	}
+
+	return nil
+}
+
+// CommunicationPreferences retrieves all the records using an executor.
+func CommunicationPreferences(mods ...qm.QueryMod) communicationPreferenceQuery {
+	mods = append(mods, qm.From("\"accounts_api\".\"communication_preferences\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"accounts_api\".\"communication_preferences\".*"})
+	}
+
+	return communicationPreferenceQuery{q}
+}
+
+// FindCommunicationPreference retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindCommunicationPreference(ctx context.Context, exec boil.ContextExecutor, accountID string, channel string, selectCols ...string) (*CommunicationPreference, error) {
+	communicationPreferenceObj := &CommunicationPreference{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"accounts_api\".\"communication_preferences\" where \"account_id\"=$1 AND \"channel\"=$2", sel,
+	)
+
+	q := queries.Raw(query, accountID, channel)
+
+	err := q.Bind(ctx, exec, communicationPreferenceObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from communication_preferences")
+	}
+
+	if err = communicationPreferenceObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return communicationPreferenceObj, err
+	}
+
+	return communicationPreferenceObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *CommunicationPreference) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no communication_preferences provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(communicationPreferenceColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	communicationPreferenceInsertCacheMut.RLock()
+	cache, cached := communicationPreferenceInsertCache[key]
+	communicationPreferenceInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			communicationPreferenceAllColumns,
+			communicationPreferenceColumnsWithDefault,
+			communicationPreferenceColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(communicationPreferenceType, communicationPreferenceMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(communicationPreferenceType, communicationPreferenceMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"accounts_api\".\"communication_preferences\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"accounts_api\".\"communication_preferences\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into communication_preferences")
+	}
+
+	if !cached {
+		communicationPreferenceInsertCacheMut.Lock()
+		communicationPreferenceInsertCache[key] = cache
+		communicationPreferenceInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the CommunicationPreference.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *CommunicationPreference) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	communicationPreferenceUpdateCacheMut.RLock()
+	cache, cached := communicationPreferenceUpdateCache[key]
+	communicationPreferenceUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			communicationPreferenceAllColumns,
+			communicationPreferencePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update communication_preferences, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"accounts_api\".\"communication_preferences\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, communicationPreferencePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(communicationPreferenceType, communicationPreferenceMapping, append(wl, communicationPreferencePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update communication_preferences row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for communication_preferences")
+	}
+
+	if !cached {
+		communicationPreferenceUpdateCacheMut.Lock()
+		communicationPreferenceUpdateCache[key] = cache
+		communicationPreferenceUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q communicationPreferenceQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for communication_preferences")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for communication_preferences")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o CommunicationPreferenceSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), communicationPreferencePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"accounts_api\".\"communication_preferences\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, communicationPreferencePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in communicationPreference slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all communicationPreference")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *CommunicationPreference) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no communication_preferences provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(communicationPreferenceColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	communicationPreferenceUpsertCacheMut.RLock()
+	cache, cached := communicationPreferenceUpsertCache[key]
+	communicationPreferenceUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			communicationPreferenceAllColumns,
+			communicationPreferenceColumnsWithDefault,
+			communicationPreferenceColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			communicationPreferenceAllColumns,
+			communicationPreferencePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert communication_preferences, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(communicationPreferenceAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(communicationPreferencePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert communication_preferences, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(communicationPreferencePrimaryKeyColumns))
+			copy(conflict, communicationPreferencePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"accounts_api\".\"communication_preferences\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(communicationPreferenceType, communicationPreferenceMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(communicationPreferenceType, communicationPreferenceMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert communication_preferences")
+	}
+
+	if !cached {
+		communicationPreferenceUpsertCacheMut.Lock()
+		communicationPreferenceUpsertCache[key] = cache
+		communicationPreferenceUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single CommunicationPreference record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *CommunicationPreference) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no CommunicationPreference provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), communicationPreferencePrimaryKeyMapping)
+	sql := "DELETE FROM \"accounts_api\".\"communication_preferences\" WHERE \"account_id\"=$1 AND \"channel\"=$2"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from communication_preferences")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for communication_preferences")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q communicationPreferenceQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no communicationPreferenceQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from communication_preferences")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for communication_preferences")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o CommunicationPreferenceSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(communicationPreferenceBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), communicationPreferencePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"accounts_api\".\"communication_preferences\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, communicationPreferencePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from communicationPreference slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for communication_preferences")
+	}
+
+	if len(communicationPreferenceAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *CommunicationPreference) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindCommunicationPreference(ctx, exec, o.AccountID, o.Channel)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *CommunicationPreferenceSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := CommunicationPreferenceSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), communicationPreferencePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"accounts_api\".\"communication_preferences\".* FROM \"accounts_api\".\"communication_preferences\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, communicationPreferencePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in CommunicationPreferenceSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// CommunicationPreferenceExists checks if the CommunicationPreference row exists.
+func CommunicationPreferenceExists(ctx context.Context, exec boil.ContextExecutor, accountID string, channel string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"accounts_api\".\"communication_preferences\" where \"account_id\"=$1 AND \"channel\"=$2 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, accountID, channel)
+	}
+	row := exec.QueryRowContext(ctx, sql, accountID, channel)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if communication_preferences exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the CommunicationPreference row exists.
+func (o *CommunicationPreference) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return CommunicationPreferenceExists(ctx, exec, o.AccountID, o.Channel)
+}
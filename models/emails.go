@@ -1,4 +1,4 @@
-// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
 
 package models
@@ -24,44 +24,93 @@ import (
 
 // Email is an object representing the database table.
 type Email struct {
-	Address     string    `boil:"address" json:"address" toml:"address" yaml:"address"`
-	AccountID   string    `boil:"account_id" json:"account_id" toml:"account_id" yaml:"account_id"`
-	ConfirmedAt null.Time `boil:"confirmed_at" json:"confirmed_at,omitempty" toml:"confirmed_at" yaml:"confirmed_at,omitempty"`
+	Address              string      `boil:"address" json:"address" toml:"address" yaml:"address"`
+	AccountID            string      `boil:"account_id" json:"account_id" toml:"account_id" yaml:"account_id"`
+	ConfirmedAt          null.Time   `boil:"confirmed_at" json:"confirmed_at,omitempty" toml:"confirmed_at" yaml:"confirmed_at,omitempty"`
+	CodeExpiresAt        null.Time   `boil:"code_expires_at" json:"code_expires_at,omitempty" toml:"code_expires_at" yaml:"code_expires_at,omitempty"`
+	ConfirmationCodeHash null.String `boil:"confirmation_code_hash" json:"confirmation_code_hash,omitempty" toml:"confirmation_code_hash" yaml:"confirmation_code_hash,omitempty"`
+	ConfirmationAttempts int         `boil:"confirmation_attempts" json:"confirmation_attempts" toml:"confirmation_attempts" yaml:"confirmation_attempts"`
+	CodeSentAt           null.Time   `boil:"code_sent_at" json:"code_sent_at,omitempty" toml:"code_sent_at" yaml:"code_sent_at,omitempty"`
+	SendWindowStartedAt  null.Time   `boil:"send_window_started_at" json:"send_window_started_at,omitempty" toml:"send_window_started_at" yaml:"send_window_started_at,omitempty"`
+	SendWindowCount      int         `boil:"send_window_count" json:"send_window_count" toml:"send_window_count" yaml:"send_window_count"`
+	LinkTokenID          null.String `boil:"link_token_id" json:"link_token_id,omitempty" toml:"link_token_id" yaml:"link_token_id,omitempty"`
 
 	R *emailR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L emailL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var EmailColumns = struct {
-	Address     string
-	AccountID   string
-	ConfirmedAt string
+	Address              string
+	AccountID            string
+	ConfirmedAt          string
+	CodeExpiresAt        string
+	ConfirmationCodeHash string
+	ConfirmationAttempts string
+	CodeSentAt           string
+	SendWindowStartedAt  string
+	SendWindowCount      string
+	LinkTokenID          string
 }{
-	Address:     "address",
-	AccountID:   "account_id",
-	ConfirmedAt: "confirmed_at",
+	Address:              "address",
+	AccountID:            "account_id",
+	ConfirmedAt:          "confirmed_at",
+	CodeExpiresAt:        "code_expires_at",
+	ConfirmationCodeHash: "confirmation_code_hash",
+	ConfirmationAttempts: "confirmation_attempts",
+	CodeSentAt:           "code_sent_at",
+	SendWindowStartedAt:  "send_window_started_at",
+	SendWindowCount:      "send_window_count",
+	LinkTokenID:          "link_token_id",
 }
 
 var EmailTableColumns = struct {
-	Address     string
-	AccountID   string
-	ConfirmedAt string
+	Address              string
+	AccountID            string
+	ConfirmedAt          string
+	CodeExpiresAt        string
+	ConfirmationCodeHash string
+	ConfirmationAttempts string
+	CodeSentAt           string
+	SendWindowStartedAt  string
+	SendWindowCount      string
+	LinkTokenID          string
 }{
-	Address:     "emails.address",
-	AccountID:   "emails.account_id",
-	ConfirmedAt: "emails.confirmed_at",
+	Address:              "emails.address",
+	AccountID:            "emails.account_id",
+	ConfirmedAt:          "emails.confirmed_at",
+	CodeExpiresAt:        "emails.code_expires_at",
+	ConfirmationCodeHash: "emails.confirmation_code_hash",
+	ConfirmationAttempts: "emails.confirmation_attempts",
+	CodeSentAt:           "emails.code_sent_at",
+	SendWindowStartedAt:  "emails.send_window_started_at",
+	SendWindowCount:      "emails.send_window_count",
+	LinkTokenID:          "emails.link_token_id",
 }
 
 // Generated where
 
 var EmailWhere = struct {
-	Address     whereHelperstring
-	AccountID   whereHelperstring
-	ConfirmedAt whereHelpernull_Time
+	Address              whereHelperstring
+	AccountID            whereHelperstring
+	ConfirmedAt          whereHelpernull_Time
+	CodeExpiresAt        whereHelpernull_Time
+	ConfirmationCodeHash whereHelpernull_String
+	ConfirmationAttempts whereHelperint
+	CodeSentAt           whereHelpernull_Time
+	SendWindowStartedAt  whereHelpernull_Time
+	SendWindowCount      whereHelperint
+	LinkTokenID          whereHelpernull_String
 }{
-	Address:     whereHelperstring{field: "\"accounts_api\".\"emails\".\"address\""},
-	AccountID:   whereHelperstring{field: "\"accounts_api\".\"emails\".\"account_id\""},
-	ConfirmedAt: whereHelpernull_Time{field: "\"accounts_api\".\"emails\".\"confirmed_at\""},
+	Address:              whereHelperstring{field: "\"accounts_api\".\"emails\".\"address\""},
+	AccountID:            whereHelperstring{field: "\"accounts_api\".\"emails\".\"account_id\""},
+	ConfirmedAt:          whereHelpernull_Time{field: "\"accounts_api\".\"emails\".\"confirmed_at\""},
+	CodeExpiresAt:        whereHelpernull_Time{field: "\"accounts_api\".\"emails\".\"code_expires_at\""},
+	ConfirmationCodeHash: whereHelpernull_String{field: "\"accounts_api\".\"emails\".\"confirmation_code_hash\""},
+	ConfirmationAttempts: whereHelperint{field: "\"accounts_api\".\"emails\".\"confirmation_attempts\""},
+	CodeSentAt:           whereHelpernull_Time{field: "\"accounts_api\".\"emails\".\"code_sent_at\""},
+	SendWindowStartedAt:  whereHelpernull_Time{field: "\"accounts_api\".\"emails\".\"send_window_started_at\""},
+	SendWindowCount:      whereHelperint{field: "\"accounts_api\".\"emails\".\"send_window_count\""},
+	LinkTokenID:          whereHelpernull_String{field: "\"accounts_api\".\"emails\".\"link_token_id\""},
 }
 
 // EmailRels is where relationship names are stored.
@@ -92,9 +141,9 @@ func (r *emailR) GetAccount() *Account {
 type emailL struct{}
 
 var (
-	emailAllColumns            = []string{"address", "account_id", "confirmed_at"}
+	emailAllColumns            = []string{"address", "account_id", "confirmed_at", "code_expires_at", "confirmation_code_hash", "confirmation_attempts", "code_sent_at", "send_window_started_at", "send_window_count", "link_token_id"}
 	emailColumnsWithoutDefault = []string{"address", "account_id"}
-	emailColumnsWithDefault    = []string{"confirmed_at"}
+	emailColumnsWithDefault    = []string{"confirmed_at", "code_expires_at", "confirmation_code_hash", "confirmation_attempts", "code_sent_at", "send_window_started_at", "send_window_count", "link_token_id"}
 	emailPrimaryKeyColumns     = []string{"address"}
 	emailGeneratedColumns      = []string{}
 )
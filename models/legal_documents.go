@@ -0,0 +1,935 @@
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// LegalDocument is an object representing the database table.
+type LegalDocument struct {
+	Kind        string    `boil:"kind" json:"kind" toml:"kind" yaml:"kind"`
+	Version     string    `boil:"version" json:"version" toml:"version" yaml:"version"`
+	EffectiveAt time.Time `boil:"effective_at" json:"effective_at" toml:"effective_at" yaml:"effective_at"`
+
+	R *legalDocumentR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L legalDocumentL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var LegalDocumentColumns = struct {
+	Kind        string
+	Version     string
+	EffectiveAt string
+}{
+	Kind:        "kind",
+	Version:     "version",
+	EffectiveAt: "effective_at",
+}
+
+var LegalDocumentTableColumns = struct {
+	Kind        string
+	Version     string
+	EffectiveAt string
+}{
+	Kind:        "legal_documents.kind",
+	Version:     "legal_documents.version",
+	EffectiveAt: "legal_documents.effective_at",
+}
+
+// Generated where
+
+var LegalDocumentWhere = struct {
+	Kind        whereHelperstring
+	Version     whereHelperstring
+	EffectiveAt whereHelpertime_Time
+}{
+	Kind:        whereHelperstring{field: "\"accounts_api\".\"legal_documents\".\"kind\""},
+	Version:     whereHelperstring{field: "\"accounts_api\".\"legal_documents\".\"version\""},
+	EffectiveAt: whereHelpertime_Time{field: "\"accounts_api\".\"legal_documents\".\"effective_at\""},
+}
+
+// LegalDocumentRels is where relationship names are stored.
+var LegalDocumentRels = struct {
+}{}
+
+// legalDocumentR is where relationships are stored.
+type legalDocumentR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*legalDocumentR) NewStruct() *legalDocumentR {
+	return &legalDocumentR{}
+}
+
+// legalDocumentL is where Load methods for each relationship are stored.
+type legalDocumentL struct{}
+
+var (
+	legalDocumentAllColumns            = []string{"kind", "version", "effective_at"}
+	legalDocumentColumnsWithoutDefault = []string{"kind", "version"}
+	legalDocumentColumnsWithDefault    = []string{"effective_at"}
+	legalDocumentPrimaryKeyColumns     = []string{"kind", "version"}
+	legalDocumentGeneratedColumns      = []string{}
+)
+
+type (
+	// LegalDocumentSlice is an alias for a slice of pointers to LegalDocument.
+	// This should almost always be used instead of []LegalDocument.
+	LegalDocumentSlice []*LegalDocument
+	// LegalDocumentHook is the signature for custom LegalDocument hook methods
+	LegalDocumentHook func(context.Context, boil.ContextExecutor, *LegalDocument) error
+
+	legalDocumentQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	legalDocumentType                 = reflect.TypeOf(&LegalDocument{})
+	legalDocumentMapping              = queries.MakeStructMapping(legalDocumentType)
+	legalDocumentPrimaryKeyMapping, _ = queries.BindMapping(legalDocumentType, legalDocumentMapping, legalDocumentPrimaryKeyColumns)
+	legalDocumentInsertCacheMut       sync.RWMutex
+	legalDocumentInsertCache          = make(map[string]insertCache)
+	legalDocumentUpdateCacheMut       sync.RWMutex
+	legalDocumentUpdateCache          = make(map[string]updateCache)
+	legalDocumentUpsertCacheMut       sync.RWMutex
+	legalDocumentUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var legalDocumentAfterSelectMu sync.Mutex
+var legalDocumentAfterSelectHooks []LegalDocumentHook
+
+var legalDocumentBeforeInsertMu sync.Mutex
+var legalDocumentBeforeInsertHooks []LegalDocumentHook
+var legalDocumentAfterInsertMu sync.Mutex
+var legalDocumentAfterInsertHooks []LegalDocumentHook
+
+var legalDocumentBeforeUpdateMu sync.Mutex
+var legalDocumentBeforeUpdateHooks []LegalDocumentHook
+var legalDocumentAfterUpdateMu sync.Mutex
+var legalDocumentAfterUpdateHooks []LegalDocumentHook
+
+var legalDocumentBeforeDeleteMu sync.Mutex
+var legalDocumentBeforeDeleteHooks []LegalDocumentHook
+var legalDocumentAfterDeleteMu sync.Mutex
+var legalDocumentAfterDeleteHooks []LegalDocumentHook
+
+var legalDocumentBeforeUpsertMu sync.Mutex
+var legalDocumentBeforeUpsertHooks []LegalDocumentHook
+var legalDocumentAfterUpsertMu sync.Mutex
+var legalDocumentAfterUpsertHooks []LegalDocumentHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *LegalDocument) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range legalDocumentAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *LegalDocument) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range legalDocumentBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *LegalDocument) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range legalDocumentAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *LegalDocument) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range legalDocumentBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *LegalDocument) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range legalDocumentAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *LegalDocument) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range legalDocumentBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *LegalDocument) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range legalDocumentAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *LegalDocument) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range legalDocumentBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *LegalDocument) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range legalDocumentAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddLegalDocumentHook registers your hook function for all future operations.
+func AddLegalDocumentHook(hookPoint boil.HookPoint, legalDocumentHook LegalDocumentHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		legalDocumentAfterSelectMu.Lock()
+		legalDocumentAfterSelectHooks = append(legalDocumentAfterSelectHooks, legalDocumentHook)
+		legalDocumentAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		legalDocumentBeforeInsertMu.Lock()
+		legalDocumentBeforeInsertHooks = append(legalDocumentBeforeInsertHooks, legalDocumentHook)
+		legalDocumentBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		legalDocumentAfterInsertMu.Lock()
+		legalDocumentAfterInsertHooks = append(legalDocumentAfterInsertHooks, legalDocumentHook)
+		legalDocumentAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		legalDocumentBeforeUpdateMu.Lock()
+		legalDocumentBeforeUpdateHooks = append(legalDocumentBeforeUpdateHooks, legalDocumentHook)
+		legalDocumentBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		legalDocumentAfterUpdateMu.Lock()
+		legalDocumentAfterUpdateHooks = append(legalDocumentAfterUpdateHooks, legalDocumentHook)
+		legalDocumentAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		legalDocumentBeforeDeleteMu.Lock()
+		legalDocumentBeforeDeleteHooks = append(legalDocumentBeforeDeleteHooks, legalDocumentHook)
+		legalDocumentBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		legalDocumentAfterDeleteMu.Lock()
+		legalDocumentAfterDeleteHooks = append(legalDocumentAfterDeleteHooks, legalDocumentHook)
+		legalDocumentAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		legalDocumentBeforeUpsertMu.Lock()
+		legalDocumentBeforeUpsertHooks = append(legalDocumentBeforeUpsertHooks, legalDocumentHook)
+		legalDocumentBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		legalDocumentAfterUpsertMu.Lock()
+		legalDocumentAfterUpsertHooks = append(legalDocumentAfterUpsertHooks, legalDocumentHook)
+		legalDocumentAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single legalDocument record from the query.
+func (q legalDocumentQuery) One(ctx context.Context, exec boil.ContextExecutor) (*LegalDocument, error) {
+	o := &LegalDocument{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for legal_documents")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all LegalDocument records from the query.
+func (q legalDocumentQuery) All(ctx context.Context, exec boil.ContextExecutor) (LegalDocumentSlice, error) {
+	var o []*LegalDocument
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to LegalDocument slice")
+	}
+
+	if len(legalDocumentAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all LegalDocument records in the query.
+func (q legalDocumentQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count legal_documents rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q legalDocumentQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if legal_documents exists")
+	}
+
+	return count > 0, nil
+}
+
+// LegalDocuments retrieves all the records using an executor.
+func LegalDocuments(mods ...qm.QueryMod) legalDocumentQuery {
+	mods = append(mods, qm.From("\"accounts_api\".\"legal_documents\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"accounts_api\".\"legal_documents\".*"})
+	}
+
+	return legalDocumentQuery{q}
+}
+
+// FindLegalDocument retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindLegalDocument(ctx context.Context, exec boil.ContextExecutor, kind string, version string, selectCols ...string) (*LegalDocument, error) {
+	legalDocumentObj := &LegalDocument{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"accounts_api\".\"legal_documents\" where \"kind\"=$1 AND \"version\"=$2", sel,
+	)
+
+	q := queries.Raw(query, kind, version)
+
+	err := q.Bind(ctx, exec, legalDocumentObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from legal_documents")
+	}
+
+	if err = legalDocumentObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return legalDocumentObj, err
+	}
+
+	return legalDocumentObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *LegalDocument) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no legal_documents provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(legalDocumentColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	legalDocumentInsertCacheMut.RLock()
+	cache, cached := legalDocumentInsertCache[key]
+	legalDocumentInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			legalDocumentAllColumns,
+			legalDocumentColumnsWithDefault,
+			legalDocumentColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(legalDocumentType, legalDocumentMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(legalDocumentType, legalDocumentMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"accounts_api\".\"legal_documents\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"accounts_api\".\"legal_documents\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into legal_documents")
+	}
+
+	if !cached {
+		legalDocumentInsertCacheMut.Lock()
+		legalDocumentInsertCache[key] = cache
+		legalDocumentInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the LegalDocument.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *LegalDocument) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	legalDocumentUpdateCacheMut.RLock()
+	cache, cached := legalDocumentUpdateCache[key]
+	legalDocumentUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			legalDocumentAllColumns,
+			legalDocumentPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update legal_documents, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"accounts_api\".\"legal_documents\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, legalDocumentPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(legalDocumentType, legalDocumentMapping, append(wl, legalDocumentPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update legal_documents row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for legal_documents")
+	}
+
+	if !cached {
+		legalDocumentUpdateCacheMut.Lock()
+		legalDocumentUpdateCache[key] = cache
+		legalDocumentUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q legalDocumentQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for legal_documents")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for legal_documents")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o LegalDocumentSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), legalDocumentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"accounts_api\".\"legal_documents\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, legalDocumentPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in legalDocument slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all legalDocument")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *LegalDocument) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no legal_documents provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(legalDocumentColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	legalDocumentUpsertCacheMut.RLock()
+	cache, cached := legalDocumentUpsertCache[key]
+	legalDocumentUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			legalDocumentAllColumns,
+			legalDocumentColumnsWithDefault,
+			legalDocumentColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			legalDocumentAllColumns,
+			legalDocumentPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert legal_documents, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(legalDocumentAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(legalDocumentPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert legal_documents, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(legalDocumentPrimaryKeyColumns))
+			copy(conflict, legalDocumentPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"accounts_api\".\"legal_documents\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(legalDocumentType, legalDocumentMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(legalDocumentType, legalDocumentMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert legal_documents")
+	}
+
+	if !cached {
+		legalDocumentUpsertCacheMut.Lock()
+		legalDocumentUpsertCache[key] = cache
+		legalDocumentUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single LegalDocument record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *LegalDocument) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no LegalDocument provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), legalDocumentPrimaryKeyMapping)
+	sql := "DELETE FROM \"accounts_api\".\"legal_documents\" WHERE \"kind\"=$1 AND \"version\"=$2"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from legal_documents")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for legal_documents")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q legalDocumentQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no legalDocumentQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from legal_documents")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for legal_documents")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o LegalDocumentSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(legalDocumentBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), legalDocumentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"accounts_api\".\"legal_documents\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, legalDocumentPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from legalDocument slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for legal_documents")
+	}
+
+	if len(legalDocumentAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *LegalDocument) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindLegalDocument(ctx, exec, o.Kind, o.Version)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *LegalDocumentSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := LegalDocumentSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), legalDocumentPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"accounts_api\".\"legal_documents\".* FROM \"accounts_api\".\"legal_documents\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, legalDocumentPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in LegalDocumentSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// LegalDocumentExists checks if the LegalDocument row exists.
+func LegalDocumentExists(ctx context.Context, exec boil.ContextExecutor, kind string, version string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"accounts_api\".\"legal_documents\" where \"kind\"=$1 AND \"version\"=$2 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, kind, version)
+	}
+	row := exec.QueryRowContext(ctx, sql, kind, version)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if legal_documents exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the LegalDocument row exists.
+func (o *LegalDocument) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return LegalDocumentExists(ctx, exec, o.Kind, o.Version)
+}
@@ -0,0 +1,1159 @@
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// PendingEmailChange is an object representing the database table.
+type PendingEmailChange struct {
+	AccountID            string    `boil:"account_id" json:"account_id" toml:"account_id" yaml:"account_id"`
+	Address              string    `boil:"address" json:"address" toml:"address" yaml:"address"`
+	ConfirmationCodeHash string    `boil:"confirmation_code_hash" json:"confirmation_code_hash" toml:"confirmation_code_hash" yaml:"confirmation_code_hash"`
+	CodeExpiresAt        time.Time `boil:"code_expires_at" json:"code_expires_at" toml:"code_expires_at" yaml:"code_expires_at"`
+	ConfirmationAttempts int       `boil:"confirmation_attempts" json:"confirmation_attempts" toml:"confirmation_attempts" yaml:"confirmation_attempts"`
+	CreatedAt            time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *pendingEmailChangeR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L pendingEmailChangeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var PendingEmailChangeColumns = struct {
+	AccountID            string
+	Address              string
+	ConfirmationCodeHash string
+	CodeExpiresAt        string
+	ConfirmationAttempts string
+	CreatedAt            string
+}{
+	AccountID:            "account_id",
+	Address:              "address",
+	ConfirmationCodeHash: "confirmation_code_hash",
+	CodeExpiresAt:        "code_expires_at",
+	ConfirmationAttempts: "confirmation_attempts",
+	CreatedAt:            "created_at",
+}
+
+var PendingEmailChangeTableColumns = struct {
+	AccountID            string
+	Address              string
+	ConfirmationCodeHash string
+	CodeExpiresAt        string
+	ConfirmationAttempts string
+	CreatedAt            string
+}{
+	AccountID:            "pending_email_changes.account_id",
+	Address:              "pending_email_changes.address",
+	ConfirmationCodeHash: "pending_email_changes.confirmation_code_hash",
+	CodeExpiresAt:        "pending_email_changes.code_expires_at",
+	ConfirmationAttempts: "pending_email_changes.confirmation_attempts",
+	CreatedAt:            "pending_email_changes.created_at",
+}
+
+// Generated where
+
+var PendingEmailChangeWhere = struct {
+	AccountID            whereHelperstring
+	Address              whereHelperstring
+	ConfirmationCodeHash whereHelperstring
+	CodeExpiresAt        whereHelpertime_Time
+	ConfirmationAttempts whereHelperint
+	CreatedAt            whereHelpertime_Time
+}{
+	AccountID:            whereHelperstring{field: "\"accounts_api\".\"pending_email_changes\".\"account_id\""},
+	Address:              whereHelperstring{field: "\"accounts_api\".\"pending_email_changes\".\"address\""},
+	ConfirmationCodeHash: whereHelperstring{field: "\"accounts_api\".\"pending_email_changes\".\"confirmation_code_hash\""},
+	CodeExpiresAt:        whereHelpertime_Time{field: "\"accounts_api\".\"pending_email_changes\".\"code_expires_at\""},
+	ConfirmationAttempts: whereHelperint{field: "\"accounts_api\".\"pending_email_changes\".\"confirmation_attempts\""},
+	CreatedAt:            whereHelpertime_Time{field: "\"accounts_api\".\"pending_email_changes\".\"created_at\""},
+}
+
+// PendingEmailChangeRels is where relationship names are stored.
+var PendingEmailChangeRels = struct {
+	Account string
+}{
+	Account: "Account",
+}
+
+// pendingEmailChangeR is where relationships are stored.
+type pendingEmailChangeR struct {
+	Account *Account `boil:"Account" json:"Account" toml:"Account" yaml:"Account"`
+}
+
+// NewStruct creates a new relationship struct
+func (*pendingEmailChangeR) NewStruct() *pendingEmailChangeR {
+	return &pendingEmailChangeR{}
+}
+
+func (r *pendingEmailChangeR) GetAccount() *Account {
+	if r == nil {
+		return nil
+	}
+	return r.Account
+}
+
+// pendingEmailChangeL is where Load methods for each relationship are stored.
+type pendingEmailChangeL struct{}
+
+var (
+	pendingEmailChangeAllColumns            = []string{"account_id", "address", "confirmation_code_hash", "code_expires_at", "confirmation_attempts", "created_at"}
+	pendingEmailChangeColumnsWithoutDefault = []string{"account_id", "address", "confirmation_code_hash", "code_expires_at"}
+	pendingEmailChangeColumnsWithDefault    = []string{"confirmation_attempts", "created_at"}
+	pendingEmailChangePrimaryKeyColumns     = []string{"account_id"}
+	pendingEmailChangeGeneratedColumns      = []string{}
+)
+
+type (
+	// PendingEmailChangeSlice is an alias for a slice of pointers to PendingEmailChange.
+	// This should almost always be used instead of []PendingEmailChange.
+	PendingEmailChangeSlice []*PendingEmailChange
+	// PendingEmailChangeHook is the signature for custom PendingEmailChange hook methods
+	PendingEmailChangeHook func(context.Context, boil.ContextExecutor, *PendingEmailChange) error
+
+	pendingEmailChangeQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	pendingEmailChangeType                 = reflect.TypeOf(&PendingEmailChange{})
+	pendingEmailChangeMapping              = queries.MakeStructMapping(pendingEmailChangeType)
+	pendingEmailChangePrimaryKeyMapping, _ = queries.BindMapping(pendingEmailChangeType, pendingEmailChangeMapping, pendingEmailChangePrimaryKeyColumns)
+	pendingEmailChangeInsertCacheMut       sync.RWMutex
+	pendingEmailChangeInsertCache          = make(map[string]insertCache)
+	pendingEmailChangeUpdateCacheMut       sync.RWMutex
+	pendingEmailChangeUpdateCache          = make(map[string]updateCache)
+	pendingEmailChangeUpsertCacheMut       sync.RWMutex
+	pendingEmailChangeUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var pendingEmailChangeAfterSelectMu sync.Mutex
+var pendingEmailChangeAfterSelectHooks []PendingEmailChangeHook
+
+var pendingEmailChangeBeforeInsertMu sync.Mutex
+var pendingEmailChangeBeforeInsertHooks []PendingEmailChangeHook
+var pendingEmailChangeAfterInsertMu sync.Mutex
+var pendingEmailChangeAfterInsertHooks []PendingEmailChangeHook
+
+var pendingEmailChangeBeforeUpdateMu sync.Mutex
+var pendingEmailChangeBeforeUpdateHooks []PendingEmailChangeHook
+var pendingEmailChangeAfterUpdateMu sync.Mutex
+var pendingEmailChangeAfterUpdateHooks []PendingEmailChangeHook
+
+var pendingEmailChangeBeforeDeleteMu sync.Mutex
+var pendingEmailChangeBeforeDeleteHooks []PendingEmailChangeHook
+var pendingEmailChangeAfterDeleteMu sync.Mutex
+var pendingEmailChangeAfterDeleteHooks []PendingEmailChangeHook
+
+var pendingEmailChangeBeforeUpsertMu sync.Mutex
+var pendingEmailChangeBeforeUpsertHooks []PendingEmailChangeHook
+var pendingEmailChangeAfterUpsertMu sync.Mutex
+var pendingEmailChangeAfterUpsertHooks []PendingEmailChangeHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *PendingEmailChange) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range pendingEmailChangeAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *PendingEmailChange) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range pendingEmailChangeBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *PendingEmailChange) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range pendingEmailChangeAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *PendingEmailChange) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range pendingEmailChangeBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *PendingEmailChange) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range pendingEmailChangeAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *PendingEmailChange) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range pendingEmailChangeBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *PendingEmailChange) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range pendingEmailChangeAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *PendingEmailChange) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range pendingEmailChangeBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *PendingEmailChange) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range pendingEmailChangeAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddPendingEmailChangeHook registers your hook function for all future operations.
+func AddPendingEmailChangeHook(hookPoint boil.HookPoint, pendingEmailChangeHook PendingEmailChangeHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		pendingEmailChangeAfterSelectMu.Lock()
+		pendingEmailChangeAfterSelectHooks = append(pendingEmailChangeAfterSelectHooks, pendingEmailChangeHook)
+		pendingEmailChangeAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		pendingEmailChangeBeforeInsertMu.Lock()
+		pendingEmailChangeBeforeInsertHooks = append(pendingEmailChangeBeforeInsertHooks, pendingEmailChangeHook)
+		pendingEmailChangeBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		pendingEmailChangeAfterInsertMu.Lock()
+		pendingEmailChangeAfterInsertHooks = append(pendingEmailChangeAfterInsertHooks, pendingEmailChangeHook)
+		pendingEmailChangeAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		pendingEmailChangeBeforeUpdateMu.Lock()
+		pendingEmailChangeBeforeUpdateHooks = append(pendingEmailChangeBeforeUpdateHooks, pendingEmailChangeHook)
+		pendingEmailChangeBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		pendingEmailChangeAfterUpdateMu.Lock()
+		pendingEmailChangeAfterUpdateHooks = append(pendingEmailChangeAfterUpdateHooks, pendingEmailChangeHook)
+		pendingEmailChangeAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		pendingEmailChangeBeforeDeleteMu.Lock()
+		pendingEmailChangeBeforeDeleteHooks = append(pendingEmailChangeBeforeDeleteHooks, pendingEmailChangeHook)
+		pendingEmailChangeBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		pendingEmailChangeAfterDeleteMu.Lock()
+		pendingEmailChangeAfterDeleteHooks = append(pendingEmailChangeAfterDeleteHooks, pendingEmailChangeHook)
+		pendingEmailChangeAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		pendingEmailChangeBeforeUpsertMu.Lock()
+		pendingEmailChangeBeforeUpsertHooks = append(pendingEmailChangeBeforeUpsertHooks, pendingEmailChangeHook)
+		pendingEmailChangeBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		pendingEmailChangeAfterUpsertMu.Lock()
+		pendingEmailChangeAfterUpsertHooks = append(pendingEmailChangeAfterUpsertHooks, pendingEmailChangeHook)
+		pendingEmailChangeAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single pendingEmailChange record from the query.
+func (q pendingEmailChangeQuery) One(ctx context.Context, exec boil.ContextExecutor) (*PendingEmailChange, error) {
+	o := &PendingEmailChange{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for pending_email_changes")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all PendingEmailChange records from the query.
+func (q pendingEmailChangeQuery) All(ctx context.Context, exec boil.ContextExecutor) (PendingEmailChangeSlice, error) {
+	var o []*PendingEmailChange
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to PendingEmailChange slice")
+	}
+
+	if len(pendingEmailChangeAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all PendingEmailChange records in the query.
+func (q pendingEmailChangeQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count pending_email_changes rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q pendingEmailChangeQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if pending_email_changes exists")
+	}
+
+	return count > 0, nil
+}
+
+// Account pointed to by the foreign key.
+func (o *PendingEmailChange) Account(mods ...qm.QueryMod) accountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.AccountID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Accounts(queryMods...)
+}
+
+// LoadAccount allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (pendingEmailChangeL) LoadAccount(ctx context.Context, e boil.ContextExecutor, singular bool, maybePendingEmailChange interface{}, mods queries.Applicator) error {
+	var slice []*PendingEmailChange
+	var object *PendingEmailChange
+
+	if singular {
+		var ok bool
+		object, ok = maybePendingEmailChange.(*PendingEmailChange)
+		if !ok {
+			object = new(PendingEmailChange)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybePendingEmailChange)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybePendingEmailChange))
+			}
+		}
+	} else {
+		s, ok := maybePendingEmailChange.(*[]*PendingEmailChange)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybePendingEmailChange)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybePendingEmailChange))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &pendingEmailChangeR{}
+		}
+		args[object.AccountID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &pendingEmailChangeR{}
+			}
+
+			args[obj.AccountID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.accounts`),
+		qm.WhereIn(`accounts_api.accounts.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Account")
+	}
+
+	var resultSlice []*Account
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Account")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for accounts")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for accounts")
+	}
+
+	if len(accountAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Account = foreign
+		if foreign.R == nil {
+			foreign.R = &accountR{}
+		}
+		foreign.R.PendingEmailChange = object
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.AccountID == foreign.ID {
+				local.R.Account = foreign
+				if foreign.R == nil {
+					foreign.R = &accountR{}
+				}
+				foreign.R.PendingEmailChange = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetAccount of the pendingEmailChange to the related item.
+// Sets o.R.Account to related.
+// Adds o to related.R.PendingEmailChange.
+func (o *PendingEmailChange) SetAccount(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Account) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"accounts_api\".\"pending_email_changes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+		strmangle.WhereClause("\"", "\"", 2, pendingEmailChangePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.AccountID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.AccountID = related.ID
+	if o.R == nil {
+		o.R = &pendingEmailChangeR{
+			Account: related,
+		}
+	} else {
+		o.R.Account = related
+	}
+
+	if related.R == nil {
+		related.R = &accountR{
+			PendingEmailChange: o,
+		}
+	} else {
+		related.R.PendingEmailChange = o
+	}
+
+	return nil
+}
+
+// PendingEmailChanges retrieves all the records using an executor.
+func PendingEmailChanges(mods ...qm.QueryMod) pendingEmailChangeQuery {
+	mods = append(mods, qm.From("\"accounts_api\".\"pending_email_changes\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"accounts_api\".\"pending_email_changes\".*"})
+	}
+
+	return pendingEmailChangeQuery{q}
+}
+
+// FindPendingEmailChange retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindPendingEmailChange(ctx context.Context, exec boil.ContextExecutor, accountID string, selectCols ...string) (*PendingEmailChange, error) {
+	pendingEmailChangeObj := &PendingEmailChange{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"accounts_api\".\"pending_email_changes\" where \"account_id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, accountID)
+
+	err := q.Bind(ctx, exec, pendingEmailChangeObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from pending_email_changes")
+	}
+
+	if err = pendingEmailChangeObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return pendingEmailChangeObj, err
+	}
+
+	return pendingEmailChangeObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *PendingEmailChange) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no pending_email_changes provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(pendingEmailChangeColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	pendingEmailChangeInsertCacheMut.RLock()
+	cache, cached := pendingEmailChangeInsertCache[key]
+	pendingEmailChangeInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			pendingEmailChangeAllColumns,
+			pendingEmailChangeColumnsWithDefault,
+			pendingEmailChangeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(pendingEmailChangeType, pendingEmailChangeMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(pendingEmailChangeType, pendingEmailChangeMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"accounts_api\".\"pending_email_changes\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"accounts_api\".\"pending_email_changes\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into pending_email_changes")
+	}
+
+	if !cached {
+		pendingEmailChangeInsertCacheMut.Lock()
+		pendingEmailChangeInsertCache[key] = cache
+		pendingEmailChangeInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the PendingEmailChange.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *PendingEmailChange) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	pendingEmailChangeUpdateCacheMut.RLock()
+	cache, cached := pendingEmailChangeUpdateCache[key]
+	pendingEmailChangeUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			pendingEmailChangeAllColumns,
+			pendingEmailChangePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update pending_email_changes, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"accounts_api\".\"pending_email_changes\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, pendingEmailChangePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(pendingEmailChangeType, pendingEmailChangeMapping, append(wl, pendingEmailChangePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update pending_email_changes row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for pending_email_changes")
+	}
+
+	if !cached {
+		pendingEmailChangeUpdateCacheMut.Lock()
+		pendingEmailChangeUpdateCache[key] = cache
+		pendingEmailChangeUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q pendingEmailChangeQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for pending_email_changes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for pending_email_changes")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o PendingEmailChangeSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), pendingEmailChangePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"accounts_api\".\"pending_email_changes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, pendingEmailChangePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in pendingEmailChange slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all pendingEmailChange")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *PendingEmailChange) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no pending_email_changes provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(pendingEmailChangeColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	pendingEmailChangeUpsertCacheMut.RLock()
+	cache, cached := pendingEmailChangeUpsertCache[key]
+	pendingEmailChangeUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			pendingEmailChangeAllColumns,
+			pendingEmailChangeColumnsWithDefault,
+			pendingEmailChangeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			pendingEmailChangeAllColumns,
+			pendingEmailChangePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert pending_email_changes, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(pendingEmailChangeAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(pendingEmailChangePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert pending_email_changes, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(pendingEmailChangePrimaryKeyColumns))
+			copy(conflict, pendingEmailChangePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"accounts_api\".\"pending_email_changes\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(pendingEmailChangeType, pendingEmailChangeMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(pendingEmailChangeType, pendingEmailChangeMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert pending_email_changes")
+	}
+
+	if !cached {
+		pendingEmailChangeUpsertCacheMut.Lock()
+		pendingEmailChangeUpsertCache[key] = cache
+		pendingEmailChangeUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single PendingEmailChange record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *PendingEmailChange) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no PendingEmailChange provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), pendingEmailChangePrimaryKeyMapping)
+	sql := "DELETE FROM \"accounts_api\".\"pending_email_changes\" WHERE \"account_id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from pending_email_changes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for pending_email_changes")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q pendingEmailChangeQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no pendingEmailChangeQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from pending_email_changes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for pending_email_changes")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o PendingEmailChangeSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(pendingEmailChangeBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), pendingEmailChangePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"accounts_api\".\"pending_email_changes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, pendingEmailChangePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from pendingEmailChange slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for pending_email_changes")
+	}
+
+	if len(pendingEmailChangeAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *PendingEmailChange) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindPendingEmailChange(ctx, exec, o.AccountID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *PendingEmailChangeSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := PendingEmailChangeSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), pendingEmailChangePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"accounts_api\".\"pending_email_changes\".* FROM \"accounts_api\".\"pending_email_changes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, pendingEmailChangePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in PendingEmailChangeSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// PendingEmailChangeExists checks if the PendingEmailChange row exists.
+func PendingEmailChangeExists(ctx context.Context, exec boil.ContextExecutor, accountID string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"accounts_api\".\"pending_email_changes\" where \"account_id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, accountID)
+	}
+	row := exec.QueryRowContext(ctx, sql, accountID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if pending_email_changes exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the PendingEmailChange row exists.
+func (o *PendingEmailChange) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return PendingEmailChangeExists(ctx, exec, o.AccountID)
+}
@@ -0,0 +1,1288 @@
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// ReferralCampaign is an object representing the database table.
+type ReferralCampaign struct {
+	ID        string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Code      string    `boil:"code" json:"code" toml:"code" yaml:"code"`
+	StartsAt  null.Time `boil:"starts_at" json:"starts_at,omitempty" toml:"starts_at" yaml:"starts_at,omitempty"`
+	EndsAt    null.Time `boil:"ends_at" json:"ends_at,omitempty" toml:"ends_at" yaml:"ends_at,omitempty"`
+	MaxUses   null.Int  `boil:"max_uses" json:"max_uses,omitempty" toml:"max_uses" yaml:"max_uses,omitempty"`
+	Uses      int       `boil:"uses" json:"uses" toml:"uses" yaml:"uses"`
+	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *referralCampaignR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L referralCampaignL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ReferralCampaignColumns = struct {
+	ID        string
+	Name      string
+	Code      string
+	StartsAt  string
+	EndsAt    string
+	MaxUses   string
+	Uses      string
+	CreatedAt string
+}{
+	ID:        "id",
+	Name:      "name",
+	Code:      "code",
+	StartsAt:  "starts_at",
+	EndsAt:    "ends_at",
+	MaxUses:   "max_uses",
+	Uses:      "uses",
+	CreatedAt: "created_at",
+}
+
+var ReferralCampaignTableColumns = struct {
+	ID        string
+	Name      string
+	Code      string
+	StartsAt  string
+	EndsAt    string
+	MaxUses   string
+	Uses      string
+	CreatedAt string
+}{
+	ID:        "referral_campaigns.id",
+	Name:      "referral_campaigns.name",
+	Code:      "referral_campaigns.code",
+	StartsAt:  "referral_campaigns.starts_at",
+	EndsAt:    "referral_campaigns.ends_at",
+	MaxUses:   "referral_campaigns.max_uses",
+	Uses:      "referral_campaigns.uses",
+	CreatedAt: "referral_campaigns.created_at",
+}
+
+// Generated where
+
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var ReferralCampaignWhere = struct {
+	ID        whereHelperstring
+	Name      whereHelperstring
+	Code      whereHelperstring
+	StartsAt  whereHelpernull_Time
+	EndsAt    whereHelpernull_Time
+	MaxUses   whereHelpernull_Int
+	Uses      whereHelperint
+	CreatedAt whereHelpertime_Time
+}{
+	ID:        whereHelperstring{field: "\"accounts_api\".\"referral_campaigns\".\"id\""},
+	Name:      whereHelperstring{field: "\"accounts_api\".\"referral_campaigns\".\"name\""},
+	Code:      whereHelperstring{field: "\"accounts_api\".\"referral_campaigns\".\"code\""},
+	StartsAt:  whereHelpernull_Time{field: "\"accounts_api\".\"referral_campaigns\".\"starts_at\""},
+	EndsAt:    whereHelpernull_Time{field: "\"accounts_api\".\"referral_campaigns\".\"ends_at\""},
+	MaxUses:   whereHelpernull_Int{field: "\"accounts_api\".\"referral_campaigns\".\"max_uses\""},
+	Uses:      whereHelperint{field: "\"accounts_api\".\"referral_campaigns\".\"uses\""},
+	CreatedAt: whereHelpertime_Time{field: "\"accounts_api\".\"referral_campaigns\".\"created_at\""},
+}
+
+// ReferralCampaignRels is where relationship names are stored.
+var ReferralCampaignRels = struct {
+	Accounts string
+}{
+	Accounts: "Accounts",
+}
+
+// referralCampaignR is where relationships are stored.
+type referralCampaignR struct {
+	Accounts AccountSlice `boil:"Accounts" json:"Accounts" toml:"Accounts" yaml:"Accounts"`
+}
+
+// NewStruct creates a new relationship struct
+func (*referralCampaignR) NewStruct() *referralCampaignR {
+	return &referralCampaignR{}
+}
+
+func (r *referralCampaignR) GetAccounts() AccountSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Accounts
+}
+
+// referralCampaignL is where Load methods for each relationship are stored.
+type referralCampaignL struct{}
+
+var (
+	referralCampaignAllColumns            = []string{"id", "name", "code", "starts_at", "ends_at", "max_uses", "uses", "created_at"}
+	referralCampaignColumnsWithoutDefault = []string{"id", "name", "code"}
+	referralCampaignColumnsWithDefault    = []string{"starts_at", "ends_at", "max_uses", "uses", "created_at"}
+	referralCampaignPrimaryKeyColumns     = []string{"id"}
+	referralCampaignGeneratedColumns      = []string{}
+)
+
+type (
+	// ReferralCampaignSlice is an alias for a slice of pointers to ReferralCampaign.
+	// This should almost always be used instead of []ReferralCampaign.
+	ReferralCampaignSlice []*ReferralCampaign
+	// ReferralCampaignHook is the signature for custom ReferralCampaign hook methods
+	ReferralCampaignHook func(context.Context, boil.ContextExecutor, *ReferralCampaign) error
+
+	referralCampaignQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	referralCampaignType                 = reflect.TypeOf(&ReferralCampaign{})
+	referralCampaignMapping              = queries.MakeStructMapping(referralCampaignType)
+	referralCampaignPrimaryKeyMapping, _ = queries.BindMapping(referralCampaignType, referralCampaignMapping, referralCampaignPrimaryKeyColumns)
+	referralCampaignInsertCacheMut       sync.RWMutex
+	referralCampaignInsertCache          = make(map[string]insertCache)
+	referralCampaignUpdateCacheMut       sync.RWMutex
+	referralCampaignUpdateCache          = make(map[string]updateCache)
+	referralCampaignUpsertCacheMut       sync.RWMutex
+	referralCampaignUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var referralCampaignAfterSelectMu sync.Mutex
+var referralCampaignAfterSelectHooks []ReferralCampaignHook
+
+var referralCampaignBeforeInsertMu sync.Mutex
+var referralCampaignBeforeInsertHooks []ReferralCampaignHook
+var referralCampaignAfterInsertMu sync.Mutex
+var referralCampaignAfterInsertHooks []ReferralCampaignHook
+
+var referralCampaignBeforeUpdateMu sync.Mutex
+var referralCampaignBeforeUpdateHooks []ReferralCampaignHook
+var referralCampaignAfterUpdateMu sync.Mutex
+var referralCampaignAfterUpdateHooks []ReferralCampaignHook
+
+var referralCampaignBeforeDeleteMu sync.Mutex
+var referralCampaignBeforeDeleteHooks []ReferralCampaignHook
+var referralCampaignAfterDeleteMu sync.Mutex
+var referralCampaignAfterDeleteHooks []ReferralCampaignHook
+
+var referralCampaignBeforeUpsertMu sync.Mutex
+var referralCampaignBeforeUpsertHooks []ReferralCampaignHook
+var referralCampaignAfterUpsertMu sync.Mutex
+var referralCampaignAfterUpsertHooks []ReferralCampaignHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ReferralCampaign) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range referralCampaignAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ReferralCampaign) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range referralCampaignBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ReferralCampaign) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range referralCampaignAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ReferralCampaign) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range referralCampaignBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ReferralCampaign) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range referralCampaignAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ReferralCampaign) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range referralCampaignBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ReferralCampaign) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range referralCampaignAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ReferralCampaign) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range referralCampaignBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ReferralCampaign) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range referralCampaignAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddReferralCampaignHook registers your hook function for all future operations.
+func AddReferralCampaignHook(hookPoint boil.HookPoint, referralCampaignHook ReferralCampaignHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		referralCampaignAfterSelectMu.Lock()
+		referralCampaignAfterSelectHooks = append(referralCampaignAfterSelectHooks, referralCampaignHook)
+		referralCampaignAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		referralCampaignBeforeInsertMu.Lock()
+		referralCampaignBeforeInsertHooks = append(referralCampaignBeforeInsertHooks, referralCampaignHook)
+		referralCampaignBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		referralCampaignAfterInsertMu.Lock()
+		referralCampaignAfterInsertHooks = append(referralCampaignAfterInsertHooks, referralCampaignHook)
+		referralCampaignAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		referralCampaignBeforeUpdateMu.Lock()
+		referralCampaignBeforeUpdateHooks = append(referralCampaignBeforeUpdateHooks, referralCampaignHook)
+		referralCampaignBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		referralCampaignAfterUpdateMu.Lock()
+		referralCampaignAfterUpdateHooks = append(referralCampaignAfterUpdateHooks, referralCampaignHook)
+		referralCampaignAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		referralCampaignBeforeDeleteMu.Lock()
+		referralCampaignBeforeDeleteHooks = append(referralCampaignBeforeDeleteHooks, referralCampaignHook)
+		referralCampaignBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		referralCampaignAfterDeleteMu.Lock()
+		referralCampaignAfterDeleteHooks = append(referralCampaignAfterDeleteHooks, referralCampaignHook)
+		referralCampaignAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		referralCampaignBeforeUpsertMu.Lock()
+		referralCampaignBeforeUpsertHooks = append(referralCampaignBeforeUpsertHooks, referralCampaignHook)
+		referralCampaignBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		referralCampaignAfterUpsertMu.Lock()
+		referralCampaignAfterUpsertHooks = append(referralCampaignAfterUpsertHooks, referralCampaignHook)
+		referralCampaignAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single referralCampaign record from the query.
+func (q referralCampaignQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ReferralCampaign, error) {
+	o := &ReferralCampaign{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for referral_campaigns")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ReferralCampaign records from the query.
+func (q referralCampaignQuery) All(ctx context.Context, exec boil.ContextExecutor) (ReferralCampaignSlice, error) {
+	var o []*ReferralCampaign
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ReferralCampaign slice")
+	}
+
+	if len(referralCampaignAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ReferralCampaign records in the query.
+func (q referralCampaignQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count referral_campaigns rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q referralCampaignQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if referral_campaigns exists")
+	}
+
+	return count > 0, nil
+}
+
+// Accounts retrieves all the account's Accounts with an executor.
+func (o *ReferralCampaign) Accounts(mods ...qm.QueryMod) accountQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"accounts_api\".\"accounts\".\"referral_campaign_id\"=?", o.ID),
+	)
+
+	return Accounts(queryMods...)
+}
+
+// LoadAccounts allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (referralCampaignL) LoadAccounts(ctx context.Context, e boil.ContextExecutor, singular bool, maybeReferralCampaign interface{}, mods queries.Applicator) error {
+	var slice []*ReferralCampaign
+	var object *ReferralCampaign
+
+	if singular {
+		var ok bool
+		object, ok = maybeReferralCampaign.(*ReferralCampaign)
+		if !ok {
+			object = new(ReferralCampaign)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeReferralCampaign)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeReferralCampaign))
+			}
+		}
+	} else {
+		s, ok := maybeReferralCampaign.(*[]*ReferralCampaign)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeReferralCampaign)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeReferralCampaign))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &referralCampaignR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &referralCampaignR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.accounts`),
+		qm.WhereIn(`accounts_api.accounts.referral_campaign_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load accounts")
+	}
+
+	var resultSlice []*Account
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice accounts")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on accounts")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for accounts")
+	}
+
+	if len(accountAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Accounts = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &accountR{}
+			}
+			foreign.R.ReferralCampaign = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.ReferralCampaignID) {
+				local.R.Accounts = append(local.R.Accounts, foreign)
+				if foreign.R == nil {
+					foreign.R = &accountR{}
+				}
+				foreign.R.ReferralCampaign = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddAccounts adds the given related objects to the existing relationships
+// of the referral_campaign, optionally inserting them as new records.
+// Appends related to o.R.Accounts.
+// Sets related.R.ReferralCampaign appropriately.
+func (o *ReferralCampaign) AddAccounts(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Account) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.ReferralCampaignID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"accounts_api\".\"accounts\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"referral_campaign_id"}),
+				strmangle.WhereClause("\"", "\"", 2, accountPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.ReferralCampaignID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &referralCampaignR{
+			Accounts: related,
+		}
+	} else {
+		o.R.Accounts = append(o.R.Accounts, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &accountR{
+				ReferralCampaign: o,
+			}
+		} else {
+			rel.R.ReferralCampaign = o
+		}
+	}
+	return nil
+}
+
+// SetAccounts removes all previously related items of the
+// referral_campaign replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.ReferralCampaign's Accounts accordingly.
+// Replaces o.R.Accounts with related.
+// Sets related.R.ReferralCampaign's Accounts accordingly.
+func (o *ReferralCampaign) SetAccounts(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Account) error {
+	query := "update \"accounts_api\".\"accounts\" set \"referral_campaign_id\" = null where \"referral_campaign_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Accounts {
+			queries.SetScanner(&rel.ReferralCampaignID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.ReferralCampaign = nil
+		}
+		o.R.Accounts = nil
+	}
+
+	return o.AddAccounts(ctx, exec, insert, related...)
+}
+
+// RemoveAccounts relationships from objects passed in.
+// Removes related items from R.Accounts (uses pointer comparison, removal does not keep order)
+// Sets related.R.ReferralCampaign.
+func (o *ReferralCampaign) RemoveAccounts(ctx context.Context, exec boil.ContextExecutor, related ...*Account) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.ReferralCampaignID, nil)
+		if rel.R != nil {
+			rel.R.ReferralCampaign = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("referral_campaign_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Accounts {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Accounts)
+			if ln > 1 && i < ln-1 {
+				o.R.Accounts[i] = o.R.Accounts[ln-1]
+			}
+			o.R.Accounts = o.R.Accounts[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// ReferralCampaigns retrieves all the records using an executor.
+func ReferralCampaigns(mods ...qm.QueryMod) referralCampaignQuery {
+	mods = append(mods, qm.From("\"accounts_api\".\"referral_campaigns\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"accounts_api\".\"referral_campaigns\".*"})
+	}
+
+	return referralCampaignQuery{q}
+}
+
+// FindReferralCampaign retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindReferralCampaign(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*ReferralCampaign, error) {
+	referralCampaignObj := &ReferralCampaign{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"accounts_api\".\"referral_campaigns\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, referralCampaignObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from referral_campaigns")
+	}
+
+	if err = referralCampaignObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return referralCampaignObj, err
+	}
+
+	return referralCampaignObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ReferralCampaign) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no referral_campaigns provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(referralCampaignColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	referralCampaignInsertCacheMut.RLock()
+	cache, cached := referralCampaignInsertCache[key]
+	referralCampaignInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			referralCampaignAllColumns,
+			referralCampaignColumnsWithDefault,
+			referralCampaignColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(referralCampaignType, referralCampaignMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(referralCampaignType, referralCampaignMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"accounts_api\".\"referral_campaigns\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"accounts_api\".\"referral_campaigns\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into referral_campaigns")
+	}
+
+	if !cached {
+		referralCampaignInsertCacheMut.Lock()
+		referralCampaignInsertCache[key] = cache
+		referralCampaignInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ReferralCampaign.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ReferralCampaign) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	referralCampaignUpdateCacheMut.RLock()
+	cache, cached := referralCampaignUpdateCache[key]
+	referralCampaignUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			referralCampaignAllColumns,
+			referralCampaignPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update referral_campaigns, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"accounts_api\".\"referral_campaigns\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, referralCampaignPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(referralCampaignType, referralCampaignMapping, append(wl, referralCampaignPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update referral_campaigns row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for referral_campaigns")
+	}
+
+	if !cached {
+		referralCampaignUpdateCacheMut.Lock()
+		referralCampaignUpdateCache[key] = cache
+		referralCampaignUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q referralCampaignQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for referral_campaigns")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for referral_campaigns")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ReferralCampaignSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), referralCampaignPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"accounts_api\".\"referral_campaigns\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, referralCampaignPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in referralCampaign slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all referralCampaign")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ReferralCampaign) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no referral_campaigns provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(referralCampaignColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	referralCampaignUpsertCacheMut.RLock()
+	cache, cached := referralCampaignUpsertCache[key]
+	referralCampaignUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			referralCampaignAllColumns,
+			referralCampaignColumnsWithDefault,
+			referralCampaignColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			referralCampaignAllColumns,
+			referralCampaignPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert referral_campaigns, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(referralCampaignAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(referralCampaignPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert referral_campaigns, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(referralCampaignPrimaryKeyColumns))
+			copy(conflict, referralCampaignPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"accounts_api\".\"referral_campaigns\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(referralCampaignType, referralCampaignMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(referralCampaignType, referralCampaignMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert referral_campaigns")
+	}
+
+	if !cached {
+		referralCampaignUpsertCacheMut.Lock()
+		referralCampaignUpsertCache[key] = cache
+		referralCampaignUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ReferralCampaign record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ReferralCampaign) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ReferralCampaign provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), referralCampaignPrimaryKeyMapping)
+	sql := "DELETE FROM \"accounts_api\".\"referral_campaigns\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from referral_campaigns")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for referral_campaigns")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q referralCampaignQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no referralCampaignQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from referral_campaigns")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for referral_campaigns")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ReferralCampaignSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(referralCampaignBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), referralCampaignPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"accounts_api\".\"referral_campaigns\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, referralCampaignPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from referralCampaign slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for referral_campaigns")
+	}
+
+	if len(referralCampaignAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ReferralCampaign) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindReferralCampaign(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ReferralCampaignSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ReferralCampaignSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), referralCampaignPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"accounts_api\".\"referral_campaigns\".* FROM \"accounts_api\".\"referral_campaigns\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, referralCampaignPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ReferralCampaignSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ReferralCampaignExists checks if the ReferralCampaign row exists.
+func ReferralCampaignExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"accounts_api\".\"referral_campaigns\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if referral_campaigns exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the ReferralCampaign row exists.
+func (o *ReferralCampaign) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return ReferralCampaignExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1169 @@
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// RetiredReferralCode is an object representing the database table.
+type RetiredReferralCode struct {
+	Code      string      `boil:"code" json:"code" toml:"code" yaml:"code"`
+	AccountID null.String `boil:"account_id" json:"account_id,omitempty" toml:"account_id" yaml:"account_id,omitempty"`
+	RetiredAt time.Time   `boil:"retired_at" json:"retired_at" toml:"retired_at" yaml:"retired_at"`
+	ExpiresAt time.Time   `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+
+	R *retiredReferralCodeR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L retiredReferralCodeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var RetiredReferralCodeColumns = struct {
+	Code      string
+	AccountID string
+	RetiredAt string
+	ExpiresAt string
+}{
+	Code:      "code",
+	AccountID: "account_id",
+	RetiredAt: "retired_at",
+	ExpiresAt: "expires_at",
+}
+
+var RetiredReferralCodeTableColumns = struct {
+	Code      string
+	AccountID string
+	RetiredAt string
+	ExpiresAt string
+}{
+	Code:      "retired_referral_codes.code",
+	AccountID: "retired_referral_codes.account_id",
+	RetiredAt: "retired_referral_codes.retired_at",
+	ExpiresAt: "retired_referral_codes.expires_at",
+}
+
+// Generated where
+
+var RetiredReferralCodeWhere = struct {
+	Code      whereHelperstring
+	AccountID whereHelpernull_String
+	RetiredAt whereHelpertime_Time
+	ExpiresAt whereHelpertime_Time
+}{
+	Code:      whereHelperstring{field: "\"accounts_api\".\"retired_referral_codes\".\"code\""},
+	AccountID: whereHelpernull_String{field: "\"accounts_api\".\"retired_referral_codes\".\"account_id\""},
+	RetiredAt: whereHelpertime_Time{field: "\"accounts_api\".\"retired_referral_codes\".\"retired_at\""},
+	ExpiresAt: whereHelpertime_Time{field: "\"accounts_api\".\"retired_referral_codes\".\"expires_at\""},
+}
+
+// RetiredReferralCodeRels is where relationship names are stored.
+var RetiredReferralCodeRels = struct {
+	Account string
+}{
+	Account: "Account",
+}
+
+// retiredReferralCodeR is where relationships are stored.
+type retiredReferralCodeR struct {
+	Account *Account `boil:"Account" json:"Account" toml:"Account" yaml:"Account"`
+}
+
+// NewStruct creates a new relationship struct
+func (*retiredReferralCodeR) NewStruct() *retiredReferralCodeR {
+	return &retiredReferralCodeR{}
+}
+
+func (r *retiredReferralCodeR) GetAccount() *Account {
+	if r == nil {
+		return nil
+	}
+	return r.Account
+}
+
+// retiredReferralCodeL is where Load methods for each relationship are stored.
+type retiredReferralCodeL struct{}
+
+var (
+	retiredReferralCodeAllColumns            = []string{"code", "account_id", "retired_at", "expires_at"}
+	retiredReferralCodeColumnsWithoutDefault = []string{"code", "expires_at"}
+	retiredReferralCodeColumnsWithDefault    = []string{"account_id", "retired_at"}
+	retiredReferralCodePrimaryKeyColumns     = []string{"code"}
+	retiredReferralCodeGeneratedColumns      = []string{}
+)
+
+type (
+	// RetiredReferralCodeSlice is an alias for a slice of pointers to RetiredReferralCode.
+	// This should almost always be used instead of []RetiredReferralCode.
+	RetiredReferralCodeSlice []*RetiredReferralCode
+	// RetiredReferralCodeHook is the signature for custom RetiredReferralCode hook methods
+	RetiredReferralCodeHook func(context.Context, boil.ContextExecutor, *RetiredReferralCode) error
+
+	retiredReferralCodeQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	retiredReferralCodeType                 = reflect.TypeOf(&RetiredReferralCode{})
+	retiredReferralCodeMapping              = queries.MakeStructMapping(retiredReferralCodeType)
+	retiredReferralCodePrimaryKeyMapping, _ = queries.BindMapping(retiredReferralCodeType, retiredReferralCodeMapping, retiredReferralCodePrimaryKeyColumns)
+	retiredReferralCodeInsertCacheMut       sync.RWMutex
+	retiredReferralCodeInsertCache          = make(map[string]insertCache)
+	retiredReferralCodeUpdateCacheMut       sync.RWMutex
+	retiredReferralCodeUpdateCache          = make(map[string]updateCache)
+	retiredReferralCodeUpsertCacheMut       sync.RWMutex
+	retiredReferralCodeUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var retiredReferralCodeAfterSelectMu sync.Mutex
+var retiredReferralCodeAfterSelectHooks []RetiredReferralCodeHook
+
+var retiredReferralCodeBeforeInsertMu sync.Mutex
+var retiredReferralCodeBeforeInsertHooks []RetiredReferralCodeHook
+var retiredReferralCodeAfterInsertMu sync.Mutex
+var retiredReferralCodeAfterInsertHooks []RetiredReferralCodeHook
+
+var retiredReferralCodeBeforeUpdateMu sync.Mutex
+var retiredReferralCodeBeforeUpdateHooks []RetiredReferralCodeHook
+var retiredReferralCodeAfterUpdateMu sync.Mutex
+var retiredReferralCodeAfterUpdateHooks []RetiredReferralCodeHook
+
+var retiredReferralCodeBeforeDeleteMu sync.Mutex
+var retiredReferralCodeBeforeDeleteHooks []RetiredReferralCodeHook
+var retiredReferralCodeAfterDeleteMu sync.Mutex
+var retiredReferralCodeAfterDeleteHooks []RetiredReferralCodeHook
+
+var retiredReferralCodeBeforeUpsertMu sync.Mutex
+var retiredReferralCodeBeforeUpsertHooks []RetiredReferralCodeHook
+var retiredReferralCodeAfterUpsertMu sync.Mutex
+var retiredReferralCodeAfterUpsertHooks []RetiredReferralCodeHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *RetiredReferralCode) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range retiredReferralCodeAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *RetiredReferralCode) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range retiredReferralCodeBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *RetiredReferralCode) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range retiredReferralCodeAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *RetiredReferralCode) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range retiredReferralCodeBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *RetiredReferralCode) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range retiredReferralCodeAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *RetiredReferralCode) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range retiredReferralCodeBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *RetiredReferralCode) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range retiredReferralCodeAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *RetiredReferralCode) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range retiredReferralCodeBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *RetiredReferralCode) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range retiredReferralCodeAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddRetiredReferralCodeHook registers your hook function for all future operations.
+func AddRetiredReferralCodeHook(hookPoint boil.HookPoint, retiredReferralCodeHook RetiredReferralCodeHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		retiredReferralCodeAfterSelectMu.Lock()
+		retiredReferralCodeAfterSelectHooks = append(retiredReferralCodeAfterSelectHooks, retiredReferralCodeHook)
+		retiredReferralCodeAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		retiredReferralCodeBeforeInsertMu.Lock()
+		retiredReferralCodeBeforeInsertHooks = append(retiredReferralCodeBeforeInsertHooks, retiredReferralCodeHook)
+		retiredReferralCodeBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		retiredReferralCodeAfterInsertMu.Lock()
+		retiredReferralCodeAfterInsertHooks = append(retiredReferralCodeAfterInsertHooks, retiredReferralCodeHook)
+		retiredReferralCodeAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		retiredReferralCodeBeforeUpdateMu.Lock()
+		retiredReferralCodeBeforeUpdateHooks = append(retiredReferralCodeBeforeUpdateHooks, retiredReferralCodeHook)
+		retiredReferralCodeBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		retiredReferralCodeAfterUpdateMu.Lock()
+		retiredReferralCodeAfterUpdateHooks = append(retiredReferralCodeAfterUpdateHooks, retiredReferralCodeHook)
+		retiredReferralCodeAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		retiredReferralCodeBeforeDeleteMu.Lock()
+		retiredReferralCodeBeforeDeleteHooks = append(retiredReferralCodeBeforeDeleteHooks, retiredReferralCodeHook)
+		retiredReferralCodeBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		retiredReferralCodeAfterDeleteMu.Lock()
+		retiredReferralCodeAfterDeleteHooks = append(retiredReferralCodeAfterDeleteHooks, retiredReferralCodeHook)
+		retiredReferralCodeAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		retiredReferralCodeBeforeUpsertMu.Lock()
+		retiredReferralCodeBeforeUpsertHooks = append(retiredReferralCodeBeforeUpsertHooks, retiredReferralCodeHook)
+		retiredReferralCodeBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		retiredReferralCodeAfterUpsertMu.Lock()
+		retiredReferralCodeAfterUpsertHooks = append(retiredReferralCodeAfterUpsertHooks, retiredReferralCodeHook)
+		retiredReferralCodeAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single retiredReferralCode record from the query.
+func (q retiredReferralCodeQuery) One(ctx context.Context, exec boil.ContextExecutor) (*RetiredReferralCode, error) {
+	o := &RetiredReferralCode{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for retired_referral_codes")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all RetiredReferralCode records from the query.
+func (q retiredReferralCodeQuery) All(ctx context.Context, exec boil.ContextExecutor) (RetiredReferralCodeSlice, error) {
+	var o []*RetiredReferralCode
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to RetiredReferralCode slice")
+	}
+
+	if len(retiredReferralCodeAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all RetiredReferralCode records in the query.
+func (q retiredReferralCodeQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count retired_referral_codes rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q retiredReferralCodeQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if retired_referral_codes exists")
+	}
+
+	return count > 0, nil
+}
+
+// Account pointed to by the foreign key.
+func (o *RetiredReferralCode) Account(mods ...qm.QueryMod) accountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.AccountID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Accounts(queryMods...)
+}
+
+// LoadAccount allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (retiredReferralCodeL) LoadAccount(ctx context.Context, e boil.ContextExecutor, singular bool, maybeRetiredReferralCode interface{}, mods queries.Applicator) error {
+	var slice []*RetiredReferralCode
+	var object *RetiredReferralCode
+
+	if singular {
+		var ok bool
+		object, ok = maybeRetiredReferralCode.(*RetiredReferralCode)
+		if !ok {
+			object = new(RetiredReferralCode)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeRetiredReferralCode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeRetiredReferralCode))
+			}
+		}
+	} else {
+		s, ok := maybeRetiredReferralCode.(*[]*RetiredReferralCode)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeRetiredReferralCode)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeRetiredReferralCode))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &retiredReferralCodeR{}
+		}
+		if !queries.IsNil(object.AccountID) {
+			args[object.AccountID] = struct{}{}
+		}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &retiredReferralCodeR{}
+			}
+
+			if !queries.IsNil(obj.AccountID) {
+				args[obj.AccountID] = struct{}{}
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.accounts`),
+		qm.WhereIn(`accounts_api.accounts.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Account")
+	}
+
+	var resultSlice []*Account
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Account")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for accounts")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for accounts")
+	}
+
+	if len(accountAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Account = foreign
+		if foreign.R == nil {
+			foreign.R = &accountR{}
+		}
+		foreign.R.RetiredReferralCodes = append(foreign.R.RetiredReferralCodes, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.AccountID, foreign.ID) {
+				local.R.Account = foreign
+				if foreign.R == nil {
+					foreign.R = &accountR{}
+				}
+				foreign.R.RetiredReferralCodes = append(foreign.R.RetiredReferralCodes, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetAccount of the retiredReferralCode to the related item.
+// Sets o.R.Account to related.
+// Adds o to related.R.RetiredReferralCodes.
+func (o *RetiredReferralCode) SetAccount(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Account) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"accounts_api\".\"retired_referral_codes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+		strmangle.WhereClause("\"", "\"", 2, retiredReferralCodePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.Code}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.AccountID, related.ID)
+	if o.R == nil {
+		o.R = &retiredReferralCodeR{
+			Account: related,
+		}
+	} else {
+		o.R.Account = related
+	}
+
+	if related.R == nil {
+		related.R = &accountR{
+			RetiredReferralCodes: RetiredReferralCodeSlice{o},
+		}
+	} else {
+		related.R.RetiredReferralCodes = append(related.R.RetiredReferralCodes, o)
+	}
+
+	return nil
+}
+
+// RemoveAccount relationship.
+// Sets o.R.Account to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *RetiredReferralCode) RemoveAccount(ctx context.Context, exec boil.ContextExecutor, related *Account) error {
+	var err error
+
+	queries.SetScanner(&o.AccountID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("account_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Account = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.RetiredReferralCodes {
+		if queries.Equal(o.AccountID, ri.AccountID) {
+			continue
+		}
+
+		ln := len(related.R.RetiredReferralCodes)
+		if ln > 1 && i < ln-1 {
+			related.R.RetiredReferralCodes[i] = related.R.RetiredReferralCodes[ln-1]
+		}
+		related.R.RetiredReferralCodes = related.R.RetiredReferralCodes[:ln-1]
+		break
+	}
+	return nil
+}
+
+// RetiredReferralCodes retrieves all the records using an executor.
+func RetiredReferralCodes(mods ...qm.QueryMod) retiredReferralCodeQuery {
+	mods = append(mods, qm.From("\"accounts_api\".\"retired_referral_codes\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"accounts_api\".\"retired_referral_codes\".*"})
+	}
+
+	return retiredReferralCodeQuery{q}
+}
+
+// FindRetiredReferralCode retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindRetiredReferralCode(ctx context.Context, exec boil.ContextExecutor, code string, selectCols ...string) (*RetiredReferralCode, error) {
+	retiredReferralCodeObj := &RetiredReferralCode{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"accounts_api\".\"retired_referral_codes\" where \"code\"=$1", sel,
+	)
+
+	q := queries.Raw(query, code)
+
+	err := q.Bind(ctx, exec, retiredReferralCodeObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from retired_referral_codes")
+	}
+
+	if err = retiredReferralCodeObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return retiredReferralCodeObj, err
+	}
+
+	return retiredReferralCodeObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *RetiredReferralCode) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no retired_referral_codes provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(retiredReferralCodeColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	retiredReferralCodeInsertCacheMut.RLock()
+	cache, cached := retiredReferralCodeInsertCache[key]
+	retiredReferralCodeInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			retiredReferralCodeAllColumns,
+			retiredReferralCodeColumnsWithDefault,
+			retiredReferralCodeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(retiredReferralCodeType, retiredReferralCodeMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(retiredReferralCodeType, retiredReferralCodeMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"accounts_api\".\"retired_referral_codes\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"accounts_api\".\"retired_referral_codes\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into retired_referral_codes")
+	}
+
+	if !cached {
+		retiredReferralCodeInsertCacheMut.Lock()
+		retiredReferralCodeInsertCache[key] = cache
+		retiredReferralCodeInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the RetiredReferralCode.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *RetiredReferralCode) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	retiredReferralCodeUpdateCacheMut.RLock()
+	cache, cached := retiredReferralCodeUpdateCache[key]
+	retiredReferralCodeUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			retiredReferralCodeAllColumns,
+			retiredReferralCodePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update retired_referral_codes, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"accounts_api\".\"retired_referral_codes\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, retiredReferralCodePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(retiredReferralCodeType, retiredReferralCodeMapping, append(wl, retiredReferralCodePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update retired_referral_codes row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for retired_referral_codes")
+	}
+
+	if !cached {
+		retiredReferralCodeUpdateCacheMut.Lock()
+		retiredReferralCodeUpdateCache[key] = cache
+		retiredReferralCodeUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q retiredReferralCodeQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for retired_referral_codes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for retired_referral_codes")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o RetiredReferralCodeSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), retiredReferralCodePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"accounts_api\".\"retired_referral_codes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, retiredReferralCodePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in retiredReferralCode slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all retiredReferralCode")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *RetiredReferralCode) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no retired_referral_codes provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(retiredReferralCodeColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	retiredReferralCodeUpsertCacheMut.RLock()
+	cache, cached := retiredReferralCodeUpsertCache[key]
+	retiredReferralCodeUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			retiredReferralCodeAllColumns,
+			retiredReferralCodeColumnsWithDefault,
+			retiredReferralCodeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			retiredReferralCodeAllColumns,
+			retiredReferralCodePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert retired_referral_codes, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(retiredReferralCodeAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(retiredReferralCodePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert retired_referral_codes, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(retiredReferralCodePrimaryKeyColumns))
+			copy(conflict, retiredReferralCodePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"accounts_api\".\"retired_referral_codes\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(retiredReferralCodeType, retiredReferralCodeMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(retiredReferralCodeType, retiredReferralCodeMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert retired_referral_codes")
+	}
+
+	if !cached {
+		retiredReferralCodeUpsertCacheMut.Lock()
+		retiredReferralCodeUpsertCache[key] = cache
+		retiredReferralCodeUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single RetiredReferralCode record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *RetiredReferralCode) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no RetiredReferralCode provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), retiredReferralCodePrimaryKeyMapping)
+	sql := "DELETE FROM \"accounts_api\".\"retired_referral_codes\" WHERE \"code\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from retired_referral_codes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for retired_referral_codes")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q retiredReferralCodeQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no retiredReferralCodeQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from retired_referral_codes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for retired_referral_codes")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o RetiredReferralCodeSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(retiredReferralCodeBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), retiredReferralCodePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"accounts_api\".\"retired_referral_codes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, retiredReferralCodePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from retiredReferralCode slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for retired_referral_codes")
+	}
+
+	if len(retiredReferralCodeAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *RetiredReferralCode) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindRetiredReferralCode(ctx, exec, o.Code)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *RetiredReferralCodeSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := RetiredReferralCodeSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), retiredReferralCodePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"accounts_api\".\"retired_referral_codes\".* FROM \"accounts_api\".\"retired_referral_codes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, retiredReferralCodePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in RetiredReferralCodeSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// RetiredReferralCodeExists checks if the RetiredReferralCode row exists.
+func RetiredReferralCodeExists(ctx context.Context, exec boil.ContextExecutor, code string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"accounts_api\".\"retired_referral_codes\" where \"code\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, code)
+	}
+	row := exec.QueryRowContext(ctx, sql, code)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if retired_referral_codes exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the RetiredReferralCode row exists.
+func (o *RetiredReferralCode) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return RetiredReferralCodeExists(ctx, exec, o.Code)
+}
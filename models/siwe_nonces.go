@@ -0,0 +1,1124 @@
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// SiweNonce is an object representing the database table.
+type SiweNonce struct {
+	Nonce     string    `boil:"nonce" json:"nonce" toml:"nonce" yaml:"nonce"`
+	AccountID string    `boil:"account_id" json:"account_id" toml:"account_id" yaml:"account_id"`
+	ExpiresAt time.Time `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+
+	R *siweNonceR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L siweNonceL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var SiweNonceColumns = struct {
+	Nonce     string
+	AccountID string
+	ExpiresAt string
+}{
+	Nonce:     "nonce",
+	AccountID: "account_id",
+	ExpiresAt: "expires_at",
+}
+
+var SiweNonceTableColumns = struct {
+	Nonce     string
+	AccountID string
+	ExpiresAt string
+}{
+	Nonce:     "siwe_nonces.nonce",
+	AccountID: "siwe_nonces.account_id",
+	ExpiresAt: "siwe_nonces.expires_at",
+}
+
+// Generated where
+
+var SiweNonceWhere = struct {
+	Nonce     whereHelperstring
+	AccountID whereHelperstring
+	ExpiresAt whereHelpertime_Time
+}{
+	Nonce:     whereHelperstring{field: "\"accounts_api\".\"siwe_nonces\".\"nonce\""},
+	AccountID: whereHelperstring{field: "\"accounts_api\".\"siwe_nonces\".\"account_id\""},
+	ExpiresAt: whereHelpertime_Time{field: "\"accounts_api\".\"siwe_nonces\".\"expires_at\""},
+}
+
+// SiweNonceRels is where relationship names are stored.
+var SiweNonceRels = struct {
+	Account string
+}{
+	Account: "Account",
+}
+
+// siweNonceR is where relationships are stored.
+type siweNonceR struct {
+	Account *Account `boil:"Account" json:"Account" toml:"Account" yaml:"Account"`
+}
+
+// NewStruct creates a new relationship struct
+func (*siweNonceR) NewStruct() *siweNonceR {
+	return &siweNonceR{}
+}
+
+func (r *siweNonceR) GetAccount() *Account {
+	if r == nil {
+		return nil
+	}
+	return r.Account
+}
+
+// siweNonceL is where Load methods for each relationship are stored.
+type siweNonceL struct{}
+
+var (
+	siweNonceAllColumns            = []string{"nonce", "account_id", "expires_at"}
+	siweNonceColumnsWithoutDefault = []string{"nonce", "account_id", "expires_at"}
+	siweNonceColumnsWithDefault    = []string{}
+	siweNoncePrimaryKeyColumns     = []string{"nonce"}
+	siweNonceGeneratedColumns      = []string{}
+)
+
+type (
+	// SiweNonceSlice is an alias for a slice of pointers to SiweNonce.
+	// This should almost always be used instead of []SiweNonce.
+	SiweNonceSlice []*SiweNonce
+	// SiweNonceHook is the signature for custom SiweNonce hook methods
+	SiweNonceHook func(context.Context, boil.ContextExecutor, *SiweNonce) error
+
+	siweNonceQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	siweNonceType                 = reflect.TypeOf(&SiweNonce{})
+	siweNonceMapping              = queries.MakeStructMapping(siweNonceType)
+	siweNoncePrimaryKeyMapping, _ = queries.BindMapping(siweNonceType, siweNonceMapping, siweNoncePrimaryKeyColumns)
+	siweNonceInsertCacheMut       sync.RWMutex
+	siweNonceInsertCache          = make(map[string]insertCache)
+	siweNonceUpdateCacheMut       sync.RWMutex
+	siweNonceUpdateCache          = make(map[string]updateCache)
+	siweNonceUpsertCacheMut       sync.RWMutex
+	siweNonceUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var siweNonceAfterSelectMu sync.Mutex
+var siweNonceAfterSelectHooks []SiweNonceHook
+
+var siweNonceBeforeInsertMu sync.Mutex
+var siweNonceBeforeInsertHooks []SiweNonceHook
+var siweNonceAfterInsertMu sync.Mutex
+var siweNonceAfterInsertHooks []SiweNonceHook
+
+var siweNonceBeforeUpdateMu sync.Mutex
+var siweNonceBeforeUpdateHooks []SiweNonceHook
+var siweNonceAfterUpdateMu sync.Mutex
+var siweNonceAfterUpdateHooks []SiweNonceHook
+
+var siweNonceBeforeDeleteMu sync.Mutex
+var siweNonceBeforeDeleteHooks []SiweNonceHook
+var siweNonceAfterDeleteMu sync.Mutex
+var siweNonceAfterDeleteHooks []SiweNonceHook
+
+var siweNonceBeforeUpsertMu sync.Mutex
+var siweNonceBeforeUpsertHooks []SiweNonceHook
+var siweNonceAfterUpsertMu sync.Mutex
+var siweNonceAfterUpsertHooks []SiweNonceHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *SiweNonce) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range siweNonceAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *SiweNonce) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range siweNonceBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *SiweNonce) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range siweNonceAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *SiweNonce) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range siweNonceBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *SiweNonce) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range siweNonceAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *SiweNonce) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range siweNonceBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *SiweNonce) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range siweNonceAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *SiweNonce) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range siweNonceBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *SiweNonce) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range siweNonceAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddSiweNonceHook registers your hook function for all future operations.
+func AddSiweNonceHook(hookPoint boil.HookPoint, siweNonceHook SiweNonceHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		siweNonceAfterSelectMu.Lock()
+		siweNonceAfterSelectHooks = append(siweNonceAfterSelectHooks, siweNonceHook)
+		siweNonceAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		siweNonceBeforeInsertMu.Lock()
+		siweNonceBeforeInsertHooks = append(siweNonceBeforeInsertHooks, siweNonceHook)
+		siweNonceBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		siweNonceAfterInsertMu.Lock()
+		siweNonceAfterInsertHooks = append(siweNonceAfterInsertHooks, siweNonceHook)
+		siweNonceAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		siweNonceBeforeUpdateMu.Lock()
+		siweNonceBeforeUpdateHooks = append(siweNonceBeforeUpdateHooks, siweNonceHook)
+		siweNonceBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		siweNonceAfterUpdateMu.Lock()
+		siweNonceAfterUpdateHooks = append(siweNonceAfterUpdateHooks, siweNonceHook)
+		siweNonceAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		siweNonceBeforeDeleteMu.Lock()
+		siweNonceBeforeDeleteHooks = append(siweNonceBeforeDeleteHooks, siweNonceHook)
+		siweNonceBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		siweNonceAfterDeleteMu.Lock()
+		siweNonceAfterDeleteHooks = append(siweNonceAfterDeleteHooks, siweNonceHook)
+		siweNonceAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		siweNonceBeforeUpsertMu.Lock()
+		siweNonceBeforeUpsertHooks = append(siweNonceBeforeUpsertHooks, siweNonceHook)
+		siweNonceBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		siweNonceAfterUpsertMu.Lock()
+		siweNonceAfterUpsertHooks = append(siweNonceAfterUpsertHooks, siweNonceHook)
+		siweNonceAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single siweNonce record from the query.
+func (q siweNonceQuery) One(ctx context.Context, exec boil.ContextExecutor) (*SiweNonce, error) {
+	o := &SiweNonce{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for siwe_nonces")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all SiweNonce records from the query.
+func (q siweNonceQuery) All(ctx context.Context, exec boil.ContextExecutor) (SiweNonceSlice, error) {
+	var o []*SiweNonce
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to SiweNonce slice")
+	}
+
+	if len(siweNonceAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all SiweNonce records in the query.
+func (q siweNonceQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count siwe_nonces rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q siweNonceQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if siwe_nonces exists")
+	}
+
+	return count > 0, nil
+}
+
+// Account pointed to by the foreign key.
+func (o *SiweNonce) Account(mods ...qm.QueryMod) accountQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.AccountID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Accounts(queryMods...)
+}
+
+// LoadAccount allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (siweNonceL) LoadAccount(ctx context.Context, e boil.ContextExecutor, singular bool, maybeSiweNonce interface{}, mods queries.Applicator) error {
+	var slice []*SiweNonce
+	var object *SiweNonce
+
+	if singular {
+		var ok bool
+		object, ok = maybeSiweNonce.(*SiweNonce)
+		if !ok {
+			object = new(SiweNonce)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeSiweNonce)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeSiweNonce))
+			}
+		}
+	} else {
+		s, ok := maybeSiweNonce.(*[]*SiweNonce)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeSiweNonce)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeSiweNonce))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &siweNonceR{}
+		}
+		args[object.AccountID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &siweNonceR{}
+			}
+
+			args[obj.AccountID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`accounts_api.accounts`),
+		qm.WhereIn(`accounts_api.accounts.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Account")
+	}
+
+	var resultSlice []*Account
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Account")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for accounts")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for accounts")
+	}
+
+	if len(accountAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Account = foreign
+		if foreign.R == nil {
+			foreign.R = &accountR{}
+		}
+		foreign.R.SiweNonces = append(foreign.R.SiweNonces, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.AccountID == foreign.ID {
+				local.R.Account = foreign
+				if foreign.R == nil {
+					foreign.R = &accountR{}
+				}
+				foreign.R.SiweNonces = append(foreign.R.SiweNonces, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetAccount of the siweNonce to the related item.
+// Sets o.R.Account to related.
+// Adds o to related.R.SiweNonces.
+func (o *SiweNonce) SetAccount(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Account) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"accounts_api\".\"siwe_nonces\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"account_id"}),
+		strmangle.WhereClause("\"", "\"", 2, siweNoncePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.Nonce}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.AccountID = related.ID
+	if o.R == nil {
+		o.R = &siweNonceR{
+			Account: related,
+		}
+	} else {
+		o.R.Account = related
+	}
+
+	if related.R == nil {
+		related.R = &accountR{
+			SiweNonces: SiweNonceSlice{o},
+		}
+	} else {
+		related.R.SiweNonces = append(related.R.SiweNonces, o)
+	}
+
+	return nil
+}
+
+// SiweNonces retrieves all the records using an executor.
+func SiweNonces(mods ...qm.QueryMod) siweNonceQuery {
+	mods = append(mods, qm.From("\"accounts_api\".\"siwe_nonces\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"accounts_api\".\"siwe_nonces\".*"})
+	}
+
+	return siweNonceQuery{q}
+}
+
+// FindSiweNonce retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindSiweNonce(ctx context.Context, exec boil.ContextExecutor, nonce string, selectCols ...string) (*SiweNonce, error) {
+	siweNonceObj := &SiweNonce{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"accounts_api\".\"siwe_nonces\" where \"nonce\"=$1", sel,
+	)
+
+	q := queries.Raw(query, nonce)
+
+	err := q.Bind(ctx, exec, siweNonceObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from siwe_nonces")
+	}
+
+	if err = siweNonceObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return siweNonceObj, err
+	}
+
+	return siweNonceObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *SiweNonce) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no siwe_nonces provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(siweNonceColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	siweNonceInsertCacheMut.RLock()
+	cache, cached := siweNonceInsertCache[key]
+	siweNonceInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			siweNonceAllColumns,
+			siweNonceColumnsWithDefault,
+			siweNonceColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(siweNonceType, siweNonceMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(siweNonceType, siweNonceMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"accounts_api\".\"siwe_nonces\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"accounts_api\".\"siwe_nonces\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into siwe_nonces")
+	}
+
+	if !cached {
+		siweNonceInsertCacheMut.Lock()
+		siweNonceInsertCache[key] = cache
+		siweNonceInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the SiweNonce.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *SiweNonce) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	siweNonceUpdateCacheMut.RLock()
+	cache, cached := siweNonceUpdateCache[key]
+	siweNonceUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			siweNonceAllColumns,
+			siweNoncePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update siwe_nonces, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"accounts_api\".\"siwe_nonces\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, siweNoncePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(siweNonceType, siweNonceMapping, append(wl, siweNoncePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update siwe_nonces row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for siwe_nonces")
+	}
+
+	if !cached {
+		siweNonceUpdateCacheMut.Lock()
+		siweNonceUpdateCache[key] = cache
+		siweNonceUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q siweNonceQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for siwe_nonces")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for siwe_nonces")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o SiweNonceSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), siweNoncePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"accounts_api\".\"siwe_nonces\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, siweNoncePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in siweNonce slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all siweNonce")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *SiweNonce) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no siwe_nonces provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(siweNonceColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	siweNonceUpsertCacheMut.RLock()
+	cache, cached := siweNonceUpsertCache[key]
+	siweNonceUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			siweNonceAllColumns,
+			siweNonceColumnsWithDefault,
+			siweNonceColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			siweNonceAllColumns,
+			siweNoncePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert siwe_nonces, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(siweNonceAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(siweNoncePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert siwe_nonces, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(siweNoncePrimaryKeyColumns))
+			copy(conflict, siweNoncePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"accounts_api\".\"siwe_nonces\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(siweNonceType, siweNonceMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(siweNonceType, siweNonceMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert siwe_nonces")
+	}
+
+	if !cached {
+		siweNonceUpsertCacheMut.Lock()
+		siweNonceUpsertCache[key] = cache
+		siweNonceUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single SiweNonce record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *SiweNonce) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no SiweNonce provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), siweNoncePrimaryKeyMapping)
+	sql := "DELETE FROM \"accounts_api\".\"siwe_nonces\" WHERE \"nonce\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from siwe_nonces")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for siwe_nonces")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q siweNonceQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no siweNonceQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from siwe_nonces")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for siwe_nonces")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o SiweNonceSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(siweNonceBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), siweNoncePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"accounts_api\".\"siwe_nonces\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, siweNoncePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from siweNonce slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for siwe_nonces")
+	}
+
+	if len(siweNonceAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *SiweNonce) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindSiweNonce(ctx, exec, o.Nonce)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *SiweNonceSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := SiweNonceSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), siweNoncePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"accounts_api\".\"siwe_nonces\".* FROM \"accounts_api\".\"siwe_nonces\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, siweNoncePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in SiweNonceSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// SiweNonceExists checks if the SiweNonce row exists.
+func SiweNonceExists(ctx context.Context, exec boil.ContextExecutor, nonce string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"accounts_api\".\"siwe_nonces\" where \"nonce\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, nonce)
+	}
+	row := exec.QueryRowContext(ctx, sql, nonce)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if siwe_nonces exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the SiweNonce row exists.
+func (o *SiweNonce) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return SiweNonceExists(ctx, exec, o.Nonce)
+}
@@ -1,4 +1,4 @@
-// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// Code generated by SQLBoiler 4.18.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
 
 package models
@@ -23,8 +23,10 @@ import (
 
 // Wallet is an object representing the database table.
 type Wallet struct {
-	Address   []byte `boil:"address" json:"address" toml:"address" yaml:"address"`
-	AccountID string `boil:"account_id" json:"account_id" toml:"account_id" yaml:"account_id"`
+	Address   []byte    `boil:"address" json:"address" toml:"address" yaml:"address"`
+	AccountID string    `boil:"account_id" json:"account_id" toml:"account_id" yaml:"account_id"`
+	IsPrimary bool      `boil:"is_primary" json:"is_primary" toml:"is_primary" yaml:"is_primary"`
+	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 
 	R *walletR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L walletL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -33,17 +35,25 @@ type Wallet struct {
 var WalletColumns = struct {
 	Address   string
 	AccountID string
+	IsPrimary string
+	CreatedAt string
 }{
 	Address:   "address",
 	AccountID: "account_id",
+	IsPrimary: "is_primary",
+	CreatedAt: "created_at",
 }
 
 var WalletTableColumns = struct {
 	Address   string
 	AccountID string
+	IsPrimary string
+	CreatedAt string
 }{
 	Address:   "wallets.address",
 	AccountID: "wallets.account_id",
+	IsPrimary: "wallets.is_primary",
+	CreatedAt: "wallets.created_at",
 }
 
 // Generated where
@@ -60,9 +70,13 @@ func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.f
 var WalletWhere = struct {
 	Address   whereHelper__byte
 	AccountID whereHelperstring
+	IsPrimary whereHelperbool
+	CreatedAt whereHelpertime_Time
 }{
 	Address:   whereHelper__byte{field: "\"accounts_api\".\"wallets\".\"address\""},
 	AccountID: whereHelperstring{field: "\"accounts_api\".\"wallets\".\"account_id\""},
+	IsPrimary: whereHelperbool{field: "\"accounts_api\".\"wallets\".\"is_primary\""},
+	CreatedAt: whereHelpertime_Time{field: "\"accounts_api\".\"wallets\".\"created_at\""},
 }
 
 // WalletRels is where relationship names are stored.
@@ -93,9 +107,9 @@ func (r *walletR) GetAccount() *Account {
 type walletL struct{}
 
 var (
-	walletAllColumns            = []string{"address", "account_id"}
+	walletAllColumns            = []string{"address", "account_id", "is_primary", "created_at"}
 	walletColumnsWithoutDefault = []string{"address", "account_id"}
-	walletColumnsWithDefault    = []string{}
+	walletColumnsWithDefault    = []string{"is_primary", "created_at"}
 	walletPrimaryKeyColumns     = []string{"address"}
 	walletGeneratedColumns      = []string{}
 )
@@ -516,7 +530,7 @@ func (walletL) LoadAccount(ctx context.Context, e boil.ContextExecutor, singular
 		if foreign.R == nil {
 			foreign.R = &accountR{}
 		}
-		foreign.R.Wallet = object
+		foreign.R.Wallets = append(foreign.R.Wallets, object)
 		return nil
 	}
 
@@ -527,7 +541,7 @@ func (walletL) LoadAccount(ctx context.Context, e boil.ContextExecutor, singular
 				if foreign.R == nil {
 					foreign.R = &accountR{}
 				}
-				foreign.R.Wallet = local
+				foreign.R.Wallets = append(foreign.R.Wallets, local)
 				break
 			}
 		}
@@ -538,7 +552,7 @@ func (walletL) LoadAccount(ctx context.Context, e boil.ContextExecutor, singular
 
 // SetAccount of the wallet to the related item.
 // Sets o.R.Account to related.
-// Adds o to related.R.Wallet.
+// Adds o to related.R.Wallets.
 func (o *Wallet) SetAccount(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Account) error {
 	var err error
 	if insert {
@@ -574,10 +588,10 @@ func (o *Wallet) SetAccount(ctx context.Context, exec boil.ContextExecutor, inse
 
 	if related.R == nil {
 		related.R = &accountR{
-			Wallet: o,
+			Wallets: WalletSlice{o},
 		}
 	} else {
-		related.R.Wallet = o
+		related.R.Wallets = append(related.R.Wallets, o)
 	}
 
 	return nil
@@ -632,6 +646,13 @@ func (o *Wallet) Insert(ctx context.Context, exec boil.ContextExecutor, columns
 	}
 
 	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
 
 	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
 		return err
@@ -837,6 +858,13 @@ func (o *Wallet) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOn
 	if o == nil {
 		return errors.New("models: no wallets provided for upsert")
 	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
 
 	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
 		return err
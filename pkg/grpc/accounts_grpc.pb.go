@@ -195,3 +195,269 @@ var Accounts_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/grpc/accounts.proto",
 }
+
+const (
+	ReferralCampaigns_CreateCampaign_FullMethodName = "/ReferralCampaigns/CreateCampaign"
+	ReferralCampaigns_GetCampaign_FullMethodName    = "/ReferralCampaigns/GetCampaign"
+	ReferralCampaigns_ListCampaigns_FullMethodName  = "/ReferralCampaigns/ListCampaigns"
+	ReferralCampaigns_UpdateCampaign_FullMethodName = "/ReferralCampaigns/UpdateCampaign"
+	ReferralCampaigns_EndCampaign_FullMethodName    = "/ReferralCampaigns/EndCampaign"
+)
+
+// ReferralCampaignsClient is the client API for ReferralCampaigns service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ReferralCampaigns manages the codes that partners and marketing hand out in place
+// of a user's own referral code. It's only served on the admin port, ADMIN_GRPC_PORT,
+// and every call must carry ADMIN_GRPC_TOKEN in an "authorization: Bearer" header.
+type ReferralCampaignsClient interface {
+	CreateCampaign(ctx context.Context, in *CreateCampaignRequest, opts ...grpc.CallOption) (*Campaign, error)
+	GetCampaign(ctx context.Context, in *GetCampaignRequest, opts ...grpc.CallOption) (*Campaign, error)
+	ListCampaigns(ctx context.Context, in *ListCampaignsRequest, opts ...grpc.CallOption) (*ListCampaignsResponse, error)
+	UpdateCampaign(ctx context.Context, in *UpdateCampaignRequest, opts ...grpc.CallOption) (*Campaign, error)
+	// Stop accepting the campaign's code right away. Accounts that already
+	// entered it keep it.
+	EndCampaign(ctx context.Context, in *EndCampaignRequest, opts ...grpc.CallOption) (*Campaign, error)
+}
+
+type referralCampaignsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReferralCampaignsClient(cc grpc.ClientConnInterface) ReferralCampaignsClient {
+	return &referralCampaignsClient{cc}
+}
+
+func (c *referralCampaignsClient) CreateCampaign(ctx context.Context, in *CreateCampaignRequest, opts ...grpc.CallOption) (*Campaign, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Campaign)
+	err := c.cc.Invoke(ctx, ReferralCampaigns_CreateCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralCampaignsClient) GetCampaign(ctx context.Context, in *GetCampaignRequest, opts ...grpc.CallOption) (*Campaign, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Campaign)
+	err := c.cc.Invoke(ctx, ReferralCampaigns_GetCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralCampaignsClient) ListCampaigns(ctx context.Context, in *ListCampaignsRequest, opts ...grpc.CallOption) (*ListCampaignsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCampaignsResponse)
+	err := c.cc.Invoke(ctx, ReferralCampaigns_ListCampaigns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralCampaignsClient) UpdateCampaign(ctx context.Context, in *UpdateCampaignRequest, opts ...grpc.CallOption) (*Campaign, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Campaign)
+	err := c.cc.Invoke(ctx, ReferralCampaigns_UpdateCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referralCampaignsClient) EndCampaign(ctx context.Context, in *EndCampaignRequest, opts ...grpc.CallOption) (*Campaign, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Campaign)
+	err := c.cc.Invoke(ctx, ReferralCampaigns_EndCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReferralCampaignsServer is the server API for ReferralCampaigns service.
+// All implementations must embed UnimplementedReferralCampaignsServer
+// for forward compatibility.
+//
+// ReferralCampaigns manages the codes that partners and marketing hand out in place
+// of a user's own referral code. It's only served on the admin port, ADMIN_GRPC_PORT,
+// and every call must carry ADMIN_GRPC_TOKEN in an "authorization: Bearer" header.
+type ReferralCampaignsServer interface {
+	CreateCampaign(context.Context, *CreateCampaignRequest) (*Campaign, error)
+	GetCampaign(context.Context, *GetCampaignRequest) (*Campaign, error)
+	ListCampaigns(context.Context, *ListCampaignsRequest) (*ListCampaignsResponse, error)
+	UpdateCampaign(context.Context, *UpdateCampaignRequest) (*Campaign, error)
+	// Stop accepting the campaign's code right away. Accounts that already
+	// entered it keep it.
+	EndCampaign(context.Context, *EndCampaignRequest) (*Campaign, error)
+	mustEmbedUnimplementedReferralCampaignsServer()
+}
+
+// UnimplementedReferralCampaignsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReferralCampaignsServer struct{}
+
+func (UnimplementedReferralCampaignsServer) CreateCampaign(context.Context, *CreateCampaignRequest) (*Campaign, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCampaign not implemented")
+}
+func (UnimplementedReferralCampaignsServer) GetCampaign(context.Context, *GetCampaignRequest) (*Campaign, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCampaign not implemented")
+}
+func (UnimplementedReferralCampaignsServer) ListCampaigns(context.Context, *ListCampaignsRequest) (*ListCampaignsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCampaigns not implemented")
+}
+func (UnimplementedReferralCampaignsServer) UpdateCampaign(context.Context, *UpdateCampaignRequest) (*Campaign, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCampaign not implemented")
+}
+func (UnimplementedReferralCampaignsServer) EndCampaign(context.Context, *EndCampaignRequest) (*Campaign, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndCampaign not implemented")
+}
+func (UnimplementedReferralCampaignsServer) mustEmbedUnimplementedReferralCampaignsServer() {}
+func (UnimplementedReferralCampaignsServer) testEmbeddedByValue()                           {}
+
+// UnsafeReferralCampaignsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReferralCampaignsServer will
+// result in compilation errors.
+type UnsafeReferralCampaignsServer interface {
+	mustEmbedUnimplementedReferralCampaignsServer()
+}
+
+func RegisterReferralCampaignsServer(s grpc.ServiceRegistrar, srv ReferralCampaignsServer) {
+	// If the following call pancis, it indicates UnimplementedReferralCampaignsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReferralCampaigns_ServiceDesc, srv)
+}
+
+func _ReferralCampaigns_CreateCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralCampaignsServer).CreateCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralCampaigns_CreateCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralCampaignsServer).CreateCampaign(ctx, req.(*CreateCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralCampaigns_GetCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralCampaignsServer).GetCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralCampaigns_GetCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralCampaignsServer).GetCampaign(ctx, req.(*GetCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralCampaigns_ListCampaigns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCampaignsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralCampaignsServer).ListCampaigns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralCampaigns_ListCampaigns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralCampaignsServer).ListCampaigns(ctx, req.(*ListCampaignsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralCampaigns_UpdateCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralCampaignsServer).UpdateCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralCampaigns_UpdateCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralCampaignsServer).UpdateCampaign(ctx, req.(*UpdateCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferralCampaigns_EndCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferralCampaignsServer).EndCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferralCampaigns_EndCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferralCampaignsServer).EndCampaign(ctx, req.(*EndCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReferralCampaigns_ServiceDesc is the grpc.ServiceDesc for ReferralCampaigns service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReferralCampaigns_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ReferralCampaigns",
+	HandlerType: (*ReferralCampaignsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCampaign",
+			Handler:    _ReferralCampaigns_CreateCampaign_Handler,
+		},
+		{
+			MethodName: "GetCampaign",
+			Handler:    _ReferralCampaigns_GetCampaign_Handler,
+		},
+		{
+			MethodName: "ListCampaigns",
+			Handler:    _ReferralCampaigns_ListCampaigns_Handler,
+		},
+		{
+			MethodName: "UpdateCampaign",
+			Handler:    _ReferralCampaigns_UpdateCampaign_Handler,
+		},
+		{
+			MethodName: "EndCampaign",
+			Handler:    _ReferralCampaigns_EndCampaign_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/grpc/accounts.proto",
+}